@@ -1,7 +1,11 @@
 package main
 
-import "github.com/ivanbulyk/clients_logistics_engine_api/internal/app"
+import (
+	"os"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/cli"
+)
 
 func main() {
-	app.MustRun()
+	os.Exit(cli.Main(os.Args[1:], os.Stdout, os.Stderr))
 }
@@ -2,19 +2,27 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
 	"github.com/ivanbulyk/clients_logistics_engine_api/internal/grpc_client"
 	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/config"
 	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/scenario"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/clock"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/generator"
 	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/operator"
-	"github.com/ivanbulyk/clients_logistics_engine_api/internal/pkg/printer"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/metrics"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/report"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/transport"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"log"
 	"math/rand"
+	"net/http"
 	"os"
 	"os/signal"
-	"strconv"
 	"sync"
 	"syscall"
 	"time"
@@ -27,87 +35,357 @@ const (
 	maxCargoUnits = 1024
 )
 
+// Indexes of App.statistics.Operation
+const (
+	opMoveUnit = iota
+	opUnitReachedWarehouse
+	opStreamMoveUnits
+	opMetricsReport
+	opPickedUp
+	opDelivered
+	opCreateOrder
+)
+
 // App is instance of application
 type App struct {
 	ctx       context.Context
 	ctxCancel context.CancelFunc
-
-	logisticsClient *grpc_client.APILogisticsClient
-	globalOperator  *operator.GlobalOperator
-
-	maxMoveWaitNumber int
-	reportTable       *printer.ASCIITablePrinter
+	cfg       *config.ClientAppConfig
+
+	logisticsTransport transport.LogisticsTransport
+	globalOperator     *operator.GlobalOperator
+	metrics            *metrics.Metrics
+	metricsServer      *http.Server
+
+	clock             *clock.Clock
+	workers           int
+	runID             string
+	requests          map[uint]*unitRequests
+	streamMoves       bool
+	moveBatchSize     int
+	moveStream        transport.MoveSender
+	baselineReport    *logistics_v1.MetricsReportResponse
+	pendingDeliveries []operator.Delivery
+	orders            *orderBook
 	statistics        *model.Statistics
 }
 
-// New returns a service instance
-func New(lc *grpc_client.APILogisticsClient, g *operator.GlobalOperator, cfg *config.ClientAppConfig) (*App, error) {
-	log.Printf("%s, initializing...\n", appName)
-
-	serviceCtx, serviceCtxCancel := context.WithCancel(context.Background())
-	connCtx, connCtxCancel := context.WithTimeout(serviceCtx, 30*time.Second)
-	defer connCtxCancel()
+// unitRequests tracks what was sent about a unit, it is only touched by the goroutine handling the unit
+type unitRequests struct {
+	// sequence of the last request API accepted or the move being sent
+	sequence uint64
+	// pendingMove API did not accept yet, it is resent as is
+	pendingMove        *logistics_v1.MoveUnitRequest
+	pendingAtWarehouse bool
+	// unloadedAt is simulated time the unit was unloaded at the warehouse, it is sent in every announcement resend
+	unloadedAt *timestamppb.Timestamp
+	// reachedAnnounced is set once API accepted UnitReachedWarehouse of the current stop, while the shipment
+	// event of the stop may still be resent
+	reachedAnnounced bool
+	// stops the unit was handled at so far
+	stops uint
+}
 
-	log.Printf("%s, trying to connect to API - %s...\n", appName, cfg.GetCombinedAddress())
-	if connErr := lc.Connect(cfg.GetCombinedAddress(), connCtx); connErr != nil {
-		serviceCtxCancel()
-		err := errors.New(fmt.Sprintf(
-			"%s, failed to connect to API (%s), error: %v",
-			appName,
-			cfg.GetCombinedAddress(),
-			connErr,
-		))
+// New returns a service instance sending requests over t, t stays open until its owner closes it.
+// rnd must be the same source the world operator was created with.
+func New(t transport.LogisticsTransport, g *operator.GlobalOperator, rnd *rand.Rand, m *metrics.Metrics, cfg *config.ClientAppConfig) (_ *App, err error) {
+	log.Printf("%s, initializing with seed %d...\n", appName, cfg.Seed)
 
-		return nil, err
+	if validateErr := validateConfig(cfg); validateErr != nil {
+		return nil, validateErr
+	}
+	clockMode, _ := clock.ParseMode(cfg.ClockMode)
+	if _, ok := t.(transport.MoveStreamer); cfg.StreamMoves && !ok {
+		return nil, fmt.Errorf("%s, transport can't stream moves", appName)
 	}
 
+	serviceCtx, serviceCtxCancel := context.WithCancel(context.Background())
+	// The context lives as long as the app, it is released right away when the app can't be created
+	defer func() {
+		if err != nil {
+			serviceCtxCancel()
+		}
+	}()
+
 	app := &App{
 		ctx:       serviceCtx,
 		ctxCancel: serviceCtxCancel,
 
-		logisticsClient: lc,
-		globalOperator:  g,
-
-		maxMoveWaitNumber: 100,
-		reportTable:       printer.NewASCIITablePrinter(),
+		cfg:                cfg,
+		logisticsTransport: t,
+		globalOperator:     g,
+		metrics:            m,
+
+		clock:         clock.New(time.Now().UTC().Truncate(time.Second), cfg.TickDuration, clockMode, cfg.ClockSpeedup),
+		workers:       cfg.Workers,
+		runID:         fmt.Sprintf("%d-%d", cfg.Seed, time.Now().UnixNano()),
+		requests:      make(map[uint]*unitRequests),
+		streamMoves:   cfg.StreamMoves,
+		moveBatchSize: cfg.MoveBatchSize,
 		statistics: &model.Statistics{
 			ExecTime: time.Now(),
 			Operation: []*model.Operation{
 				{Name: "MoveUnit"},
 				{Name: "UnitReachedWarehouse"},
+				{Name: "StreamMoveUnits"},
+				{Name: "MetricsReport"},
+				{Name: "PickedUp"},
+				{Name: "Delivered"},
+				{Name: "CreateOrder"},
 			},
 		},
 	}
 
-	app.reportTable.AddHeader([]string{"Operation", "Count", "Errors"})
-	worldPopulationErr := g.Populate(
-		uint32(rand.Intn(maxWarehouses-10+1)+10),
-		uint32(rand.Intn(maxCargoUnits-10+1)+10),
-	)
-	if worldPopulationErr != nil {
+	if worldPopulationErr := PopulateWorld(g, rnd, cfg); worldPopulationErr != nil {
 		return nil, worldPopulationErr
 	}
 
+	if cfg.ShipmentDropOffs > 0 {
+		if planErr := g.PlanShipments(cfg.ShipmentDropOffs); planErr != nil {
+			return nil, fmt.Errorf("%s, failed to plan shipments: %w", appName, planErr)
+		}
+	}
+
+	if cfg.Orders > 0 {
+		// An order no unit can carry would never be dispatched
+		carried := false
+		for _, unit := range g.GetDeliveryUnit() {
+			carried = carried || unit.CargoUnit().Fits(generator.MaxOrderWeight, generator.MaxOrderVolume)
+		}
+		if !carried {
+			return nil, fmt.Errorf("%s, no cargo unit can carry the largest order of %g kg and %g m3",
+				appName, generator.MaxOrderWeight, generator.MaxOrderVolume)
+		}
+
+		var warehouseIDs []uint
+		for _, warehouse := range g.GetWarehouses() {
+			warehouseIDs = append(warehouseIDs, warehouse.ID)
+		}
+
+		// Orders have a source of their own, so the world and unit movements don't depend on them
+		orderGenerator, generatorErr := generator.NewOrderGenerator(rand.New(rand.NewSource(rnd.Int63())), warehouseIDs, generator.OrderStream{
+			Rate:             cfg.OrderRate,
+			BurstProbability: cfg.OrderBurstProbability,
+			BurstSize:        cfg.OrderBurstSize,
+			Deadline:         cfg.OrderDeadline,
+			Total:            cfg.Orders,
+		})
+		if generatorErr != nil {
+			return nil, fmt.Errorf("%s, failed to generate orders: %w", appName, generatorErr)
+		}
+
+		app.orders = &orderBook{generator: orderGenerator, orders: make(map[uint]*model.Order)}
+	}
+
+	if len(cfg.ExportScenario) > 0 {
+		if exportErr := exportScenario(g, cfg.ExportScenario); exportErr != nil {
+			return nil, exportErr
+		}
+
+		log.Printf("%s, world exported to scenario %s\n", appName, cfg.ExportScenario)
+	}
+
+	for _, unit := range g.GetDeliveryUnit() {
+		app.requests[unit.ID] = &unitRequests{}
+	}
+
+	if len(cfg.MetricsAddr) > 0 {
+		metricsServer, serveErr := m.Serve(cfg.MetricsAddr)
+		if serveErr != nil {
+			return nil, fmt.Errorf("%s, failed to serve metrics on %s: %w", appName, cfg.MetricsAddr, serveErr)
+		}
+
+		app.metricsServer = metricsServer
+		log.Printf("%s, serving metrics on %s/metrics\n", appName, cfg.MetricsAddr)
+	}
+
 	return app, nil
 }
 
-// MustRun is wrapper around run() and it panics if any error occurs.
-func MustRun() {
-	if err := run(); err != nil {
-		panic(err)
+// validateConfig checks values of cfg that need neither API nor the world, so they are reported before connecting
+func validateConfig(cfg *config.ClientAppConfig) error {
+	if _, clockModeErr := clock.ParseMode(cfg.ClockMode); clockModeErr != nil {
+		return clockModeErr
+	}
+	if _, rendererErr := report.NewRenderer(report.Format(cfg.ReportFormat)); rendererErr != nil {
+		return rendererErr
+	}
+	if cfg.Orders > 0 && cfg.ShipmentDropOffs > 0 {
+		return fmt.Errorf("%s, orders and planned shipments can't be used together", appName)
+	}
+	switch cfg.Routing {
+	case config.RoutingGreedy, config.RoutingVRP:
+	default:
+		return fmt.Errorf("%s, unknown routing %q, expected %s or %s", appName, cfg.Routing, config.RoutingGreedy, config.RoutingVRP)
+	}
+	if cfg.RetryJitter < 0 || cfg.RetryJitter > 1 {
+		return fmt.Errorf("%s, retry jitter %g is out of range from 0 to 1", appName, cfg.RetryJitter)
+	}
+	if _, codesErr := grpc_client.ParseRetryableCodes(cfg.RetryCodes); codesErr != nil {
+		return fmt.Errorf("%s, invalid retry codes, error: %w", appName, codesErr)
+	}
+
+	return nil
+}
+
+// Connect lc to API of cfg, waiting at most cfg.ConnectTimeout, zero means no limit
+func Connect(ctx context.Context, lc *grpc_client.APILogisticsClient, cfg *config.ClientAppConfig) error {
+	connCtx, connCtxCancel := context.WithCancel(ctx)
+	if cfg.ConnectTimeout > 0 {
+		connCtx, connCtxCancel = context.WithTimeout(ctx, cfg.ConnectTimeout)
+	}
+	defer connCtxCancel()
+
+	if connErr := lc.Connect(cfg.GetCombinedAddress(), connCtx); connErr != nil {
+		return fmt.Errorf("%s, failed to connect to API (%s), error: %w", appName, cfg.GetCombinedAddress(), connErr)
+	}
+
+	return nil
+}
+
+// NewClient of API that calls it over the transport of cfg, connects over TLS, retries calls and bounds every attempt as cfg sets
+func NewClient(cfg *config.ClientAppConfig, opts ...grpc_client.Option) (*grpc_client.APILogisticsClient, error) {
+	retryPolicy := grpc_client.DefaultRetryPolicy()
+	retryPolicy.MaxAttempts = cfg.RetryMaxAttempts
+	retryPolicy.InitialBackoff = cfg.RetryInitialBackoff
+	retryPolicy.MaxBackoff = cfg.RetryMaxBackoff
+	retryPolicy.Jitter = cfg.RetryJitter
+	// Retries of a run reproduced from its seed are spread the same way
+	retryPolicy.Seed = cfg.Seed
+	retryPolicy.AttemptTimeout = cfg.CallTimeout
+	retryableCodes, codesErr := grpc_client.ParseRetryableCodes(cfg.RetryCodes)
+	if codesErr != nil {
+		return nil, fmt.Errorf("%s, invalid retry codes, error: %w", appName, codesErr)
+	}
+	retryPolicy.RetryableCodes = retryableCodes
+
+	clientOptions := []grpc_client.Option{grpc_client.WithRetryPolicy(retryPolicy)}
+	var tlsConfig *tls.Config
+	if cfg.TLS {
+		loadedConfig, tlsErr := grpc_client.LoadTLSConfig(grpc_client.TLSConfig{
+			CAFile:     cfg.TLSCAFile,
+			CertFile:   cfg.TLSCertFile,
+			KeyFile:    cfg.TLSKeyFile,
+			ServerName: cfg.TLSServerName,
+		})
+		if tlsErr != nil {
+			return nil, fmt.Errorf("%s, failed to load TLS config: %w", appName, tlsErr)
+		}
+		tlsConfig = loadedConfig
+	}
+
+	switch cfg.Transport {
+	case config.TransportGRPC:
+		if tlsConfig != nil {
+			clientOptions = append(clientOptions, grpc_client.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+		}
+	case config.TransportHTTP:
+		// The gateway has no route for StreamMoveUnits
+		if cfg.StreamMoves {
+			return nil, fmt.Errorf("%s, streamed moves are not available over %s transport", appName, config.TransportHTTP)
+		}
+		clientOptions = append(clientOptions, grpc_client.WithHTTPTransport(tlsConfig))
+	default:
+		return nil, fmt.Errorf("%s, unknown transport %q, expected %s or %s", appName, cfg.Transport, config.TransportGRPC, config.TransportHTTP)
+	}
+
+	authOptions, authErr := authOptions(cfg)
+	if authErr != nil {
+		return nil, authErr
+	}
+	clientOptions = append(clientOptions, authOptions...)
+
+	return grpc_client.NewLogisticsClient(append(clientOptions, opts...)...), nil
+}
+
+// authOptions attaching credentials of cfg to every call
+func authOptions(cfg *config.ClientAppConfig) ([]grpc_client.Option, error) {
+	if len(cfg.AuthToken) > 0 && len(cfg.AuthTokenFile) > 0 {
+		return nil, fmt.Errorf("%s, auth token and auth token file can't be used together", appName)
+	}
+
+	var options []grpc_client.Option
+	switch {
+	case len(cfg.AuthToken) > 0:
+		options = append(options, grpc_client.WithPerRPCCredentials(grpc_client.BearerToken{Token: cfg.AuthToken, AllowInsecure: cfg.AuthInsecure}))
+	case len(cfg.AuthTokenFile) > 0:
+		tokenFile, tokenErr := grpc_client.NewTokenFile(cfg.AuthTokenFile, cfg.AuthInsecure)
+		if tokenErr != nil {
+			return nil, fmt.Errorf("%s, %w", appName, tokenErr)
+		}
+		options = append(options, grpc_client.WithPerRPCCredentials(tokenFile))
+	}
+	if len(cfg.AuthAPIKey) > 0 {
+		options = append(options, grpc_client.WithPerRPCCredentials(grpc_client.APIKey{
+			Header:        cfg.AuthAPIKeyHeader,
+			Key:           cfg.AuthAPIKey,
+			AllowInsecure: cfg.AuthInsecure,
+		}))
 	}
+
+	// gRPC refuses such credentials too, but without naming the settings to change
+	if len(options) > 0 && !cfg.TLS && !cfg.AuthInsecure {
+		return nil, fmt.Errorf("%s, credentials are only sent over TLS, enable TLS or allow insecure auth", appName)
+	}
+
+	return options, nil
 }
 
-// Run app
-func run() error {
-	cfg := &config.ClientAppConfig{}
-	cfg.LoadFromEnv()
+// PopulateWorld of g from the scenario of cfg or randomly from rnd, the same seed and counts give the same world
+func PopulateWorld(g *operator.GlobalOperator, rnd *rand.Rand, cfg *config.ClientAppConfig) error {
+	// Counts are always drawn, so an explicit count does not shift the rest of the seeded run
+	warehouses := uint32(rnd.Intn(maxWarehouses-10+1) + 10)
+	cargoUnits := uint32(rnd.Intn(maxCargoUnits-10+1) + 10)
+	if cfg.Warehouses > 0 {
+		warehouses = uint32(cfg.Warehouses)
+	}
+	if cfg.CargoUnits > 0 {
+		cargoUnits = uint32(cfg.CargoUnits)
+	}
+
+	if len(cfg.Scenario) > 0 {
+		log.Printf("%s, loading world from scenario %s...\n", appName, cfg.Scenario)
 
-	apiLogisticsClient := grpc_client.NewLogisticsClient()
-	worldOperator := operator.New()
-	app, err := New(apiLogisticsClient, worldOperator, cfg)
-	if err != nil {
-		panic(err)
+		world, loadErr := scenario.Load(cfg.Scenario)
+		if loadErr != nil {
+			return loadErr
+		}
+
+		return g.PopulateFromScenario(world)
+	}
+
+	if populateErr := g.Populate(warehouses, cargoUnits); populateErr != nil {
+		return populateErr
+	}
+	g.EquipCargoUnits(cfg.UnitMaxWeight, cfg.UnitMaxVolume)
+
+	return nil
+}
+
+func exportScenario(g *operator.GlobalOperator, path string) error {
+	world, scenarioErr := g.Scenario()
+	if scenarioErr != nil {
+		return scenarioErr
+	}
+
+	return scenario.Save(path, world)
+}
+
+// Start a run configured by cfg and block until every unit is delivered or the process is interrupted
+func Start(cfg *config.ClientAppConfig) (err error) {
+	app, cleanup, initErr := initializeApp(cfg)
+	if initErr != nil {
+		return initErr
+	}
+
+	// The transport and the recording are closed once App stopped sending over them
+	var closeOnce sync.Once
+	closeApp := func() (closeErr error) {
+		closeOnce.Do(func() {
+			closeErr = app.Close()
+			cleanup()
+		})
+		return closeErr
 	}
 
 	signals := make(chan os.Signal, 1)
@@ -118,124 +396,140 @@ func run() error {
 
 		log.Printf("%s, shutting down...\n", appName)
 
-		app.ctxCancel()
-		if app.logisticsClient != nil {
-			_ = app.logisticsClient.Disconnect()
-		}
+		_ = closeApp()
 
 		log.Printf("%s, stopped!\n", appName)
 
 		os.Exit(0)
 	}()
 
-	deliveryUnits := app.globalOperator.GetDeliveryUnit()
+	defer func() {
+		err = errors.Join(err, closeApp())
+	}()
+
+	return app.Run()
+}
+
+// Close cancels in-flight calls and stops serving metrics, it may be called more than once.
+// The transport is closed by its owner, see initializeApp.
+func (a *App) Close() error {
+	a.ctxCancel()
+	a.closeMoveStream()
+	if a.metricsServer != nil {
+		return a.metricsServer.Close()
+	}
+
+	return nil
+}
+
+// Run simulation until every delivery unit reaches its warehouse, or delivers its shipment, and print the report.
+// With orders units wait for them and the run ends once every order is delivered, or with an error once pending
+// orders can't be carried by any unit that is not broken. Orders left on broken units are reported stranded.
+func (a *App) Run() error {
+	// API counters may already hold earlier runs, keep them to compare only what this run delivered
+	baselineReport, baselineErr := a.fetchMetricsReport()
+	if baselineErr != nil {
+		log.Printf("%s, failed to fetch MetricsReport before run, comparing with empty report: %v\n", appName, baselineErr)
+	}
+	a.baselineReport = baselineReport
+
+	deliveryUnits := a.globalOperator.GetDeliveryUnit()
 	totalDeliveryUnits := len(deliveryUnits)
+	unitsByID := make(map[uint]*model.GraphNode, totalDeliveryUnits)
+	for _, unit := range deliveryUnits {
+		unitsByID[unit.ID] = unit
+	}
+
+	// Units leave for their warehouse right away, with orders they stay idle until they get one
+	if a.orders == nil {
+		for _, unit := range deliveryUnits {
+			unit.CargoUnit().Status = model.CargoUnitEnRoute
+		}
+	}
 
+	var undeliverableErr, brokenErr error
 	for {
-		var wg sync.WaitGroup
-		unitsReachedObjective := 0
+		ordersDone := true
+		if a.orders != nil {
+			a.takeOrders(unitsByID)
+			if undeliverable := a.undeliverable(); len(undeliverable) > 0 {
+				undeliverableErr = fmt.Errorf("orders %v can't be carried, every unit able to carry them broke down", undeliverable)
+				log.Printf("%s, %v\n", appName, undeliverableErr)
+				break
+			}
+			ordersDone = a.orders.done()
+		}
+
+		unitsReachedObjective, unitsBroken := 0, 0
 
-		// Check if all units reached goal
+		// Check if all units reached goal, broken units never will
 		for _, unit := range deliveryUnits {
-			if unit.Metadata == true {
+			switch unit.CargoUnit().Status {
+			case model.CargoUnitIdle:
 				unitsReachedObjective++
+			case model.CargoUnitBroken:
+				unitsBroken++
 			}
 		}
 
-		if unitsReachedObjective == totalDeliveryUnits {
+		a.metrics.SetUnitsInFlight(totalDeliveryUnits - unitsReachedObjective - unitsBroken)
+
+		if unitsReachedObjective+unitsBroken == totalDeliveryUnits && ordersDone {
+			if unitsBroken > 0 {
+				brokenErr = fmt.Errorf("%s, %d of %d delivery units broke down before reaching their warehouse", appName, unitsBroken, totalDeliveryUnits)
+				log.Println(brokenErr)
+				break
+			}
+
 			log.Println("All delivery units reached warehouse...")
 			break
 		}
 
+		var movingUnits []*model.GraphNode
 		for _, unit := range deliveryUnits {
-			if unit.Metadata == true {
+			if unit.CargoUnit().Status != model.CargoUnitEnRoute || a.globalOperator.IsWaiting(unit.ID) {
 				continue
 			}
 
-			wg.Add(1)
-			go app.processDelivery(unit, &wg)
-
+			movingUnits = append(movingUnits, unit)
 		}
 
-		wg.Wait()
-	}
-
-	for _, o := range app.statistics.Operation {
-		app.reportTable.AddRow([]string{
-			o.Name,
-			strconv.FormatUint(o.A, 10),
-			strconv.FormatUint(o.B, 10),
-		})
-	}
-
-	fmt.Println("\nExecution time:", time.Since(app.statistics.ExecTime))
-	fmt.Println(app.reportTable)
+		var arrivedUnits []*model.GraphNode
+		if a.streamMoves {
+			arrivedUnits = a.processDeliveryBatch(movingUnits)
+		} else {
+			arrivedUnits = a.moveUnits(movingUnits)
+		}
 
-	return nil
-}
+		// Arrivals are handled in unit ID order, so queues don't depend on the order calls completed in
+		for _, unit := range arrivedUnits {
+			if a.globalOperator.ArriveAtWarehouse(unit.ID) {
+				log.Printf("%s - Warehouse is full, rerouting.\n", unit.Name)
+			}
+		}
 
-func (a *App) processDelivery(unit *model.GraphNode, wg *sync.WaitGroup) {
-	defer wg.Done()
+		for _, delivery := range a.globalOperator.ProcessWarehouses() {
+			a.requests[delivery.UnitID].unloadedAt = timestamppb.New(a.clock.Now())
+			a.pendingDeliveries = append(a.pendingDeliveries, delivery)
+		}
+		a.announceDeliveries(unitsByID)
 
-	time.Sleep(time.Duration(a.maxMoveWaitNumber) * time.Microsecond)
-	a.maxMoveWaitNumber = rand.Intn(a.maxMoveWaitNumber+1) + 1
-	if a.maxMoveWaitNumber >= 1 {
-		a.maxMoveWaitNumber = a.maxMoveWaitNumber >> 1
+		if clockErr := a.clock.Advance(a.ctx); clockErr != nil {
+			a.closeMoveStream()
+			return clockErr
+		}
+	}
+	a.closeMoveStream()
+	if a.orders != nil {
+		a.strandOrders(unitsByID)
 	}
 
-	oldCoordinate := *unit.Coordinate
-	newCoordinate := a.globalOperator.MoveDeliveryUnitToNearestWarehouse(unit.ID)
-	unitMessage := fmt.Sprintf("%s moving to - Latitude:%d, Longitude:%d", unit.Name, newCoordinate.X, newCoordinate.Y)
-
-	log.Println(unitMessage)
+	warehouses, mismatches, metricsErr := a.checkMetricsReport()
+	a.statistics.WarehouseQueues = a.globalOperator.QueueStatistics()
 
-	a.statistics.Operation[0].AddA()
-	moveErr := a.logisticsClient.MoveUnit(
-		a.ctx,
-		&logistics_v1.MoveUnitRequest{
-			CargoUnitId: int64(unit.ID),
-			Location: &logistics_v1.Location{
-				Latitude:  uint32(newCoordinate.X),
-				Longitude: uint32(newCoordinate.Y),
-			},
-		},
-	)
-	if moveErr != nil {
-		log.Printf("filed to send MoveUnit %s, API error: %v\n", unitMessage, moveErr)
-		a.statistics.Operation[0].AddB()
-
-		return
-	} else if newCoordinate != oldCoordinate {
-		return
-	}
-
-	announcement := fmt.Sprintf("%s - Reached Objective.", unitMessage)
-	warehouse := a.globalOperator.FindEntityByCoordinate(newCoordinate, model.Warehouses)
-	if warehouse == nil {
-		log.Printf("Warehouses not found in coordinates Latitude:%d Longitude:%d", newCoordinate.X, newCoordinate.Y)
-		return
-	}
-
-	a.statistics.Operation[1].AddA()
-	reachErr := a.logisticsClient.UnitReachedWarehouse(
-		a.ctx,
-		&logistics_v1.UnitReachedWarehouseRequest{
-			Location: &logistics_v1.Location{Latitude: uint32(newCoordinate.X), Longitude: uint32(newCoordinate.Y)},
-			Announcement: &logistics_v1.WarehouseAnnouncement{
-				CargoUnitId: int64(unit.ID),
-				WarehouseId: int64(warehouse.ID),
-				Message:     announcement,
-			},
-		},
-	)
-	if reachErr != nil {
-		log.Printf("filed to send UnitReachedWarehouse %s, API error: %v\n", unitMessage, moveErr)
-		a.statistics.Operation[1].AddB()
-		return
+	if reportErr := a.writeReport(warehouses, mismatches); reportErr != nil {
+		return errors.Join(undeliverableErr, brokenErr, metricsErr, reportErr)
 	}
 
-	log.Println(announcement)
-	unit.Metadata = true // Unit reached Warehouse
-
-	return
+	return errors.Join(undeliverableErr, brokenErr, metricsErr)
 }
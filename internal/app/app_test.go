@@ -0,0 +1,892 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/fakeserver"
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/grpc_client"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/config"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/operator"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/metrics"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/recorder"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/report"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/transport"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func newTestConfig(seed int64) *config.ClientAppConfig {
+	return &config.ClientAppConfig{
+		Host:          "bufnet",
+		Port:          "0",
+		Seed:          seed,
+		Warehouses:    5,
+		CargoUnits:    20,
+		MoveBatchSize: 7,
+		Routing:       config.RoutingGreedy,
+		ClockMode:     "asap",
+		TickDuration:  time.Second,
+		Workers:       4,
+		ReportFormat:  "ascii",
+		ReportFile:    os.DevNull,
+	}
+}
+
+// testRetryPolicy retries like the default policy but without waiting long between attempts
+var testRetryPolicy = grpc_client.RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     time.Millisecond,
+	Multiplier:     2,
+	RetryableCodes: grpc_client.DefaultRetryPolicy().RetryableCodes,
+}
+
+func newTestApp(t *testing.T, srv *fakeserver.Server, cfg *config.ClientAppConfig, opts ...grpc_client.Option) *App {
+	t.Helper()
+
+	dialOption, stop := srv.Listen()
+	t.Cleanup(stop)
+
+	rnd := rand.New(rand.NewSource(cfg.Seed))
+	m := metrics.New()
+	opts = append([]grpc_client.Option{
+		grpc_client.WithDialOptions(dialOption),
+		grpc_client.WithInterceptors(m.Interceptors()),
+		grpc_client.WithRetryPolicy(testRetryPolicy),
+	}, opts...)
+	lc := grpc_client.NewLogisticsClient(opts...)
+	if connErr := Connect(context.Background(), lc, cfg); connErr != nil {
+		t.Fatalf("Not expected error when connecting, error: %v", connErr)
+	}
+	t.Cleanup(func() { _ = lc.Disconnect() })
+	app, err := New(transport.NewGRPC(lc), operator.New(rnd), rnd, m, cfg)
+	if err != nil {
+		t.Fatalf("Not expected error when creating App, error: %v", err)
+	}
+	t.Cleanup(func() { _ = app.Close() })
+
+	return app
+}
+
+func assertEveryUnitReached(t *testing.T, srv *fakeserver.Server, cfg *config.ClientAppConfig) {
+	t.Helper()
+
+	reachedUnits := make(map[int64]bool)
+	for _, req := range srv.Reached() {
+		reachedUnits[req.GetAnnouncement().GetCargoUnitId()] = true
+	}
+
+	if len(reachedUnits) != int(cfg.CargoUnits) {
+		t.Errorf("Expected %d units to reach warehouse, but got %d", cfg.CargoUnits, len(reachedUnits))
+	}
+	if len(srv.Moves()) < int(cfg.CargoUnits) {
+		t.Errorf("Expected at least %d moves, but got %d", cfg.CargoUnits, len(srv.Moves()))
+	}
+}
+
+// assertSequencesWithoutGaps checks that every unit sent moves numbered from 1 without duplicates or gaps,
+// followed by exactly one announcement continuing the sequence
+func assertSequencesWithoutGaps(t *testing.T, srv *fakeserver.Server) {
+	t.Helper()
+
+	sequences := make(map[int64]uint64)
+	keys := make(map[string]bool)
+	for _, move := range srv.Moves() {
+		if move.Sequence != sequences[move.CargoUnitId]+1 {
+			t.Fatalf("Unit %d sent move %d after %d", move.CargoUnitId, move.Sequence, sequences[move.CargoUnitId])
+		}
+		sequences[move.CargoUnitId] = move.Sequence
+
+		if keys[move.IdempotencyKey] {
+			t.Fatalf("Idempotency key %s is used by more than one recorded move", move.IdempotencyKey)
+		}
+		keys[move.IdempotencyKey] = true
+	}
+
+	for _, req := range srv.Reached() {
+		unitID := req.GetAnnouncement().GetCargoUnitId()
+		if req.Sequence != sequences[unitID]+1 {
+			t.Errorf("Unit %d announced with sequence %d after move %d", unitID, req.Sequence, sequences[unitID])
+		}
+	}
+}
+
+// assertEveryRequestSequenced checks that moves, announcements and shipment events of every unit together
+// are numbered from 1 without duplicates or gaps
+func assertEveryRequestSequenced(t *testing.T, srv *fakeserver.Server) {
+	t.Helper()
+
+	sequences := make(map[int64][]uint64)
+	for _, move := range srv.Moves() {
+		sequences[move.CargoUnitId] = append(sequences[move.CargoUnitId], move.Sequence)
+	}
+	for _, req := range srv.Reached() {
+		unitID := req.GetAnnouncement().GetCargoUnitId()
+		sequences[unitID] = append(sequences[unitID], req.Sequence)
+	}
+	for _, req := range srv.PickUps() {
+		sequences[req.CargoUnitId] = append(sequences[req.CargoUnitId], req.Sequence)
+	}
+	for _, req := range srv.Deliveries() {
+		sequences[req.CargoUnitId] = append(sequences[req.CargoUnitId], req.Sequence)
+	}
+	for unitID, unitSequences := range sequences {
+		sort.Slice(unitSequences, func(i, j int) bool { return unitSequences[i] < unitSequences[j] })
+		for i, sequence := range unitSequences {
+			if sequence != uint64(i+1) {
+				t.Fatalf("Expected requests of unit %d numbered from 1 without gaps, but got %v", unitID, unitSequences)
+			}
+		}
+	}
+}
+
+// unitTrajectories groups received moves by unit, order of moves of different units depends on scheduling
+func unitTrajectories(moves []*logistics_v1.MoveUnitRequest) map[int64][]string {
+	trajectories := make(map[int64][]string)
+	for _, move := range moves {
+		trajectories[move.CargoUnitId] = append(trajectories[move.CargoUnitId], move.GetLocation().String())
+	}
+
+	return trajectories
+}
+
+func TestRunDeliversEveryUnit(t *testing.T) {
+	srv := fakeserver.New()
+	cfg := newTestConfig(1)
+	app := newTestApp(t, srv, cfg)
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Not expected error when running App, error: %v", err)
+	}
+
+	assertEveryUnitReached(t, srv, cfg)
+
+	var served uint64
+	for _, queue := range app.statistics.WarehouseQueues {
+		served += queue.Served
+	}
+	if served != uint64(cfg.CargoUnits) {
+		t.Errorf("Expected %d units served by warehouse queues, but got %d", cfg.CargoUnits, served)
+	}
+
+	for _, o := range app.statistics.Operation {
+		if o.Latency().Count != o.A {
+			t.Errorf("Expected latency of all %d %s calls, but got %d", o.A, o.Name, o.Latency().Count)
+		}
+	}
+
+	// Units move once per tick, so every next move of a unit is one tick later
+	lastMove := make(map[int64]time.Time)
+	for _, move := range srv.Moves() {
+		moved := move.GetSimulatedTime().AsTime()
+		if previous, ok := lastMove[move.CargoUnitId]; ok && moved.Sub(previous) != cfg.TickDuration {
+			t.Fatalf("Unit %d moved at %s after a move at %s", move.CargoUnitId, moved, previous)
+		}
+		lastMove[move.CargoUnitId] = moved
+	}
+	for _, req := range srv.Reached() {
+		if unloaded := req.GetSimulatedTime().AsTime(); unloaded.Before(lastMove[req.GetAnnouncement().GetCargoUnitId()]) {
+			t.Errorf("Unit %d was unloaded at %s before its last move", req.GetAnnouncement().GetCargoUnitId(), unloaded)
+		}
+	}
+}
+
+func TestRunStreamMoves(t *testing.T) {
+	srv := fakeserver.New()
+	cfg := newTestConfig(2)
+	cfg.StreamMoves = true
+	app := newTestApp(t, srv, cfg)
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Not expected error when running App, error: %v", err)
+	}
+
+	assertEveryUnitReached(t, srv, cfg)
+	if app.statistics.Operation[opStreamMoveUnits].A == 0 {
+		t.Errorf("Expected moves to be sent over StreamMoveUnits")
+	}
+}
+
+func TestRunDeliversShipments(t *testing.T) {
+	srv := fakeserver.New()
+	cfg := newTestConfig(4)
+	cfg.ShipmentDropOffs = 2
+	app := newTestApp(t, srv, cfg)
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Not expected error when running App, error: %v", err)
+	}
+
+	pickUps := make(map[int64]*logistics_v1.PickedUpRequest)
+	for _, req := range srv.PickUps() {
+		pickUps[req.CargoUnitId] = req
+	}
+	deliveries := make(map[int64][]*logistics_v1.DeliveredRequest)
+	for _, req := range srv.Deliveries() {
+		deliveries[req.CargoUnitId] = append(deliveries[req.CargoUnitId], req)
+	}
+
+	shipments := app.globalOperator.Shipments()
+	if len(shipments) != int(cfg.CargoUnits) {
+		t.Fatalf("Expected a shipment of every unit, but got %d", len(shipments))
+	}
+	for _, shipment := range shipments {
+		unitID := int64(shipment.UnitID)
+		if shipment.Status != model.ShipmentDelivered {
+			t.Errorf("Expected shipment %d to be delivered, but it is %s", shipment.ID, shipment.Status)
+		}
+
+		pickUp, ok := pickUps[unitID]
+		if !ok || pickUp.WarehouseId != int64(shipment.Pickup()) || len(pickUp.DropOffWarehouseIds) != len(shipment.DropOffs()) {
+			t.Errorf("Expected unit %d to pick up shipment at %d for %v, but got %v", unitID, shipment.Pickup(), shipment.DropOffs(), pickUp)
+		}
+
+		if len(deliveries[unitID]) != len(shipment.DropOffs()) {
+			t.Fatalf("Expected %d deliveries of unit %d, but got %d", len(shipment.DropOffs()), unitID, len(deliveries[unitID]))
+		}
+		for i, delivered := range deliveries[unitID] {
+			final := i == len(shipment.DropOffs())-1
+			if delivered.WarehouseId != int64(shipment.DropOffs()[i]) || delivered.DropOff != uint32(i+1) || delivered.Final != final {
+				t.Errorf("Expected drop-off %d of unit %d at %d, but got %v", i+1, unitID, shipment.DropOffs()[i], delivered)
+			}
+		}
+	}
+
+	assertEveryRequestSequenced(t, srv)
+}
+
+func TestRunDispatchesOrders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	srv := fakeserver.New()
+	cfg := newTestConfig(5)
+	cfg.CargoUnits = 4
+	cfg.Orders = 12
+	cfg.OrderRate = 0.5
+	cfg.OrderBurstProbability = 0.05
+	cfg.OrderBurstSize = 5
+	cfg.OrderDeadline = time.Hour
+	cfg.ReportFormat = "json"
+	cfg.ReportFile = path
+	if err := newTestApp(t, srv, cfg).Run(); err != nil {
+		t.Fatalf("Not expected error when running App, error: %v", err)
+	}
+
+	orders := make(map[int64]*logistics_v1.CreateOrderRequest)
+	for _, req := range srv.Orders() {
+		if req.OriginWarehouseId == req.DestinationWarehouseId || req.Priority == logistics_v1.OrderPriority_ORDER_PRIORITY_UNSPECIFIED {
+			t.Errorf("Expected order between two warehouses with a priority, but got %v", req)
+		}
+		orders[req.OrderId] = req
+	}
+	if len(orders) != int(cfg.Orders) {
+		t.Fatalf("Expected %d orders created, but got %d", cfg.Orders, len(orders))
+	}
+
+	for _, req := range srv.PickUps() {
+		if order, ok := orders[req.ShipmentId]; !ok || req.WarehouseId != order.OriginWarehouseId {
+			t.Errorf("Expected pickup of a created order at its origin, but got %v", req)
+		}
+	}
+	delivered := make(map[int64]bool)
+	for _, req := range srv.Deliveries() {
+		if order, ok := orders[req.ShipmentId]; !ok || req.WarehouseId != order.DestinationWarehouseId || !req.Final {
+			t.Errorf("Expected final delivery of a created order at its destination, but got %v", req)
+		}
+		delivered[req.ShipmentId] = true
+	}
+	if len(srv.PickUps()) != int(cfg.Orders) || len(delivered) != int(cfg.Orders) {
+		t.Errorf("Expected %d orders picked up and delivered, but got %d and %d", cfg.Orders, len(srv.PickUps()), len(delivered))
+	}
+
+	assertEveryRequestSequenced(t, srv)
+
+	loaded := 0
+	for _, req := range srv.Moves() {
+		state := req.GetState()
+		if state.GetStatus() != logistics_v1.CargoUnitStatus_CARGO_UNIT_STATUS_EN_ROUTE || state.GetMaxWeight() != model.DefaultCargoUnitMaxWeight || state.GetSpeed() != 1 {
+			t.Fatalf("Expected move of a unit en route with its state, but got %v", state)
+		}
+		if state.GetLoadWeight() > state.GetMaxWeight() || state.GetFuel() <= 0 {
+			t.Errorf("Expected unit within its capacity with fuel left, but got %v", state)
+		}
+		if state.GetLoadWeight() > 0 {
+			loaded++
+		}
+	}
+	if loaded == 0 {
+		t.Errorf("Expected units to move loaded with goods of orders")
+	}
+
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatalf("Not expected error when reading report, error: %v", readErr)
+	}
+	var runReport report.Report
+	if err := json.Unmarshal(data, &runReport); err != nil {
+		t.Fatalf("Not expected error when decoding report, error: %v", err)
+	}
+
+	var created, reportDelivered uint64
+	for _, totals := range runReport.Orders {
+		created += totals.Created
+		reportDelivered += totals.Delivered
+	}
+	if created != uint64(cfg.Orders) || reportDelivered != uint64(cfg.Orders) || len(runReport.Shipments) != 0 {
+		t.Errorf("Expected %d orders created and delivered in report, but got %+v", cfg.Orders, runReport.Orders)
+	}
+}
+
+func TestRunEndsWhenEveryUnitBreaksDown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	srv := fakeserver.New()
+	cfg := newTestConfig(5)
+	cfg.CargoUnits = 3
+	cfg.Orders = 8
+	cfg.OrderRate = 10
+	cfg.OrderDeadline = time.Hour
+	cfg.ReportFormat = "json"
+	cfg.ReportFile = path
+	app := newTestApp(t, srv, cfg)
+
+	// Units break down on their first step, with the orders they were given
+	for _, unit := range app.globalOperator.GetDeliveryUnit() {
+		unit.CargoUnit().Fuel = model.FuelPerStep
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- app.Run() }()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "can't be carried") {
+			t.Fatalf("Expected error when no unit is left to carry pending orders, but got %v", err)
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatalf("Expected run to end once every unit broke down")
+	}
+
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatalf("Not expected error when reading report, error: %v", readErr)
+	}
+	var runReport report.Report
+	if err := json.Unmarshal(data, &runReport); err != nil {
+		t.Fatalf("Not expected error when decoding report, error: %v", err)
+	}
+
+	var stranded uint64
+	for _, totals := range runReport.Orders {
+		stranded += totals.Stranded
+	}
+	if stranded != uint64(cfg.CargoUnits) {
+		t.Errorf("Expected an order stranded on each of %d broken units, but got %+v", cfg.CargoUnits, runReport.Orders)
+	}
+}
+
+func TestRunFailsWhenUnitsBreakDown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	srv := fakeserver.New()
+	cfg := newTestConfig(3)
+	cfg.ReportFormat = "json"
+	cfg.ReportFile = path
+	app := newTestApp(t, srv, cfg)
+
+	// Units break down on their first step, so none of them reaches its warehouse
+	for _, unit := range app.globalOperator.GetDeliveryUnit() {
+		unit.CargoUnit().Fuel = model.FuelPerStep
+	}
+
+	if err := app.Run(); err == nil || !strings.Contains(err.Error(), "broke down") {
+		t.Fatalf("Expected error when units broke down, but got %v", err)
+	}
+
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatalf("Not expected error when reading report, error: %v", readErr)
+	}
+	var runReport report.Report
+	if err := json.Unmarshal(data, &runReport); err != nil {
+		t.Fatalf("Not expected error when decoding report, error: %v", err)
+	}
+	if runReport.BrokenUnits == 0 || runReport.BrokenUnits > int(cfg.CargoUnits) {
+		t.Errorf("Expected broken units in report, but got %d", runReport.BrokenUnits)
+	}
+}
+
+func TestRunRoutesOrders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	srv := fakeserver.New()
+	cfg := newTestConfig(9)
+	cfg.CargoUnits = 3
+	cfg.Orders = 30
+	cfg.OrderRate = 3
+	cfg.OrderDeadline = time.Hour
+	cfg.Routing = config.RoutingVRP
+	cfg.UnitMaxWeight = 3000
+	cfg.UnitMaxVolume = 30
+	cfg.ReportFormat = "json"
+	cfg.ReportFile = path
+	if err := newTestApp(t, srv, cfg).Run(); err != nil {
+		t.Fatalf("Not expected error when running App, error: %v", err)
+	}
+
+	pickedUp := make(map[int64]bool)
+	for _, req := range srv.PickUps() {
+		pickedUp[req.ShipmentId] = true
+	}
+	delivered := make(map[int64]bool)
+	for _, req := range srv.Deliveries() {
+		if !pickedUp[req.ShipmentId] {
+			t.Errorf("Expected order %d picked up before it is delivered", req.ShipmentId)
+		}
+		delivered[req.ShipmentId] = true
+	}
+	if len(pickedUp) != int(cfg.Orders) || len(delivered) != int(cfg.Orders) {
+		t.Errorf("Expected %d orders picked up and delivered, but got %d and %d", cfg.Orders, len(pickedUp), len(delivered))
+	}
+
+	assertEveryRequestSequenced(t, srv)
+
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatalf("Not expected error when reading report, error: %v", readErr)
+	}
+	var runReport report.Report
+	if err := json.Unmarshal(data, &runReport); err != nil {
+		t.Fatalf("Not expected error when decoding report, error: %v", err)
+	}
+
+	routing := runReport.Routing
+	if routing == nil || routing.Orders != int(cfg.Orders) || routing.Distance > routing.GreedyDistance {
+		t.Errorf("Expected routes of %d orders no longer than greedy dispatch, but got %+v", cfg.Orders, routing)
+	}
+}
+
+func TestNewRejectsUnitsTooSmallForOrders(t *testing.T) {
+	cfg := newTestConfig(1)
+	cfg.Orders = 1
+	cfg.UnitMaxWeight = 10
+	cfg.UnitMaxVolume = 30
+
+	rnd := rand.New(rand.NewSource(cfg.Seed))
+	if _, err := New(transport.NewNoop(), operator.New(rnd), rnd, metrics.New(), cfg); err == nil {
+		t.Errorf("Expected error when units can't carry the heaviest order")
+	}
+}
+
+func TestRunStreamMovesRejectedUnit(t *testing.T) {
+	srv := fakeserver.New()
+	var once sync.Once
+	srv.SetErrorInjector(func(method string, req proto.Message) (bool, error) {
+		var err error
+		if method == logistics_v1.LogisticsEngineAPI_StreamMoveUnits_FullMethodName {
+			once.Do(func() { err = status.Error(codes.ResourceExhausted, "slow down") })
+		}
+
+		return false, err
+	})
+
+	cfg := newTestConfig(3)
+	cfg.StreamMoves = true
+	app := newTestApp(t, srv, cfg)
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Not expected error when running App, error: %v", err)
+	}
+
+	assertEveryUnitReached(t, srv, cfg)
+	assertSequencesWithoutGaps(t, srv)
+	if app.statistics.Operation[opMoveUnit].B != 1 {
+		t.Errorf("Expected 1 rejected move, but got %d", app.statistics.Operation[opMoveUnit].B)
+	}
+}
+
+// failEveryFifthMoveAndFirstAnnouncement fails without recording every fifth MoveUnit call
+// and the first UnitReachedWarehouse call of every unit
+func failEveryFifthMoveAndFirstAnnouncement() fakeserver.ErrorInjector {
+	failedReach := make(map[int64]bool)
+	var calls int
+
+	return func(method string, req proto.Message) (bool, error) {
+		switch method {
+		case logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName:
+			calls++
+			if calls%5 == 0 {
+				return false, status.Error(codes.Unavailable, "try again")
+			}
+		case logistics_v1.LogisticsEngineAPI_UnitReachedWarehouse_FullMethodName:
+			unitID := req.(*logistics_v1.UnitReachedWarehouseRequest).GetAnnouncement().GetCargoUnitId()
+			if !failedReach[unitID] {
+				failedReach[unitID] = true
+				return false, status.Error(codes.Unavailable, "try again")
+			}
+		}
+
+		return false, nil
+	}
+}
+
+func TestRunRetriesFailedCalls(t *testing.T) {
+	srv := fakeserver.New()
+	srv.SetErrorInjector(failEveryFifthMoveAndFirstAnnouncement())
+
+	cfg := newTestConfig(4)
+	app := newTestApp(t, srv, cfg)
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Not expected error when running App, error: %v", err)
+	}
+
+	assertEveryUnitReached(t, srv, cfg)
+	assertSequencesWithoutGaps(t, srv)
+	if app.statistics.Operation[opMoveUnit].B != 0 || app.statistics.Operation[opUnitReachedWarehouse].B != 0 {
+		t.Errorf("Expected failed calls to be retried by the client, but got %d failed moves and %d failed announcements",
+			app.statistics.Operation[opMoveUnit].B, app.statistics.Operation[opUnitReachedWarehouse].B)
+	}
+}
+
+func TestRunResendsFailedCallsWithoutRetries(t *testing.T) {
+	srv := fakeserver.New()
+	srv.SetErrorInjector(failEveryFifthMoveAndFirstAnnouncement())
+
+	cfg := newTestConfig(4)
+	app := newTestApp(t, srv, cfg, grpc_client.WithRetryPolicy(grpc_client.RetryPolicy{MaxAttempts: 1}))
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Not expected error when running App, error: %v", err)
+	}
+
+	assertEveryUnitReached(t, srv, cfg)
+	assertSequencesWithoutGaps(t, srv)
+	if app.statistics.Operation[opMoveUnit].B == 0 {
+		t.Errorf("Expected failed MoveUnit calls to be counted")
+	}
+	if app.statistics.Operation[opUnitReachedWarehouse].B != uint64(cfg.CargoUnits) {
+		t.Errorf("Expected %d failed UnitReachedWarehouse calls, but got %d", cfg.CargoUnits, app.statistics.Operation[opUnitReachedWarehouse].B)
+	}
+}
+
+func TestRunDeduplicatedLostResponse(t *testing.T) {
+	srv := fakeserver.New()
+	srv.SetDeduplication(true)
+	srv.SetErrorInjector(lostFirstAnnouncementResponse())
+
+	cfg := newTestConfig(5)
+	if err := newTestApp(t, srv, cfg).Run(); err != nil {
+		t.Errorf("Not expected error when API drops the resent announcement, error: %v", err)
+	}
+
+	if len(srv.Reached()) != int(cfg.CargoUnits) {
+		t.Errorf("Expected %d recorded announcements, but got %d", cfg.CargoUnits, len(srv.Reached()))
+	}
+}
+
+// lostFirstAnnouncementResponse lets API handle the first announcement but the response is lost
+func lostFirstAnnouncementResponse() fakeserver.ErrorInjector {
+	var once sync.Once
+
+	return func(method string, req proto.Message) (bool, error) {
+		var err error
+		if method == logistics_v1.LogisticsEngineAPI_UnitReachedWarehouse_FullMethodName {
+			once.Do(func() { err = status.Error(codes.DeadlineExceeded, "response lost") })
+		}
+
+		return true, err
+	}
+}
+
+func TestRunReportsMetricsMismatch(t *testing.T) {
+	// API without deduplication records the announcement resent after the lost response twice
+	srv := fakeserver.New()
+	srv.SetErrorInjector(lostFirstAnnouncementResponse())
+
+	app := newTestApp(t, srv, newTestConfig(5))
+
+	if err := app.Run(); !errors.Is(err, errMetricsMismatch) {
+		t.Errorf("Expected metrics mismatch error, but got %v", err)
+	}
+}
+
+func TestRunIsReproducibleFromSeed(t *testing.T) {
+	first := fakeserver.New()
+	if err := newTestApp(t, first, newTestConfig(6)).Run(); err != nil {
+		t.Fatalf("Not expected error when running first App, error: %v", err)
+	}
+
+	second := fakeserver.New()
+	if err := newTestApp(t, second, newTestConfig(6)).Run(); err != nil {
+		t.Fatalf("Not expected error when running second App, error: %v", err)
+	}
+
+	if !reflect.DeepEqual(unitTrajectories(first.Moves()), unitTrajectories(second.Moves())) {
+		t.Errorf("Expected the same unit trajectories for the same seed")
+	}
+}
+
+func TestRunFromExportedScenario(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "world.yaml")
+
+	random := fakeserver.New()
+	randomCfg := newTestConfig(7)
+	randomCfg.ExportScenario = path
+	if err := newTestApp(t, random, randomCfg).Run(); err != nil {
+		t.Fatalf("Not expected error when running random App, error: %v", err)
+	}
+
+	loaded := fakeserver.New()
+	loadedCfg := newTestConfig(8)
+	loadedCfg.Scenario = path
+	if err := newTestApp(t, loaded, loadedCfg).Run(); err != nil {
+		t.Fatalf("Not expected error when running App from scenario, error: %v", err)
+	}
+
+	if !reflect.DeepEqual(unitTrajectories(random.Moves()), unitTrajectories(loaded.Moves())) {
+		t.Errorf("Expected the same unit trajectories in the exported world")
+	}
+}
+
+func TestRunExposesMetrics(t *testing.T) {
+	srv := fakeserver.New()
+	srv.SetErrorInjector(failEveryFifthMoveAndFirstAnnouncement())
+
+	cfg := newTestConfig(9)
+	app := newTestApp(t, srv, cfg)
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Not expected error when running App, error: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	app.metrics.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	exposition := recorder.Body.String()
+
+	for _, expected := range []string{
+		fmt.Sprintf(`logistics_client_requests_total{method="UnitReachedWarehouse"} %d`, 2*cfg.CargoUnits),
+		fmt.Sprintf(`logistics_client_request_errors_total{code="Unavailable",method="UnitReachedWarehouse"} %d`, cfg.CargoUnits),
+		`logistics_client_request_duration_seconds_count{method="MoveUnit"}`,
+		fmt.Sprintf("logistics_client_units_delivered_total %d", cfg.CargoUnits),
+		"logistics_client_units_in_flight 0",
+		`logistics_client_warehouse_deliveries_total{warehouse_id="0"}`,
+	} {
+		if !strings.Contains(exposition, expected) {
+			t.Errorf("Expected metrics to contain %s", expected)
+		}
+	}
+}
+
+func TestRunWritesReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	srv := fakeserver.New()
+	cfg := newTestConfig(10)
+	cfg.ReportFormat = "json"
+	cfg.ReportFile = path
+	if err := newTestApp(t, srv, cfg).Run(); err != nil {
+		t.Fatalf("Not expected error when running App, error: %v", err)
+	}
+
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatalf("Not expected error when reading report, error: %v", readErr)
+	}
+
+	var runReport report.Report
+	if err := json.Unmarshal(data, &runReport); err != nil {
+		t.Fatalf("Not expected error when decoding report, error: %v", err)
+	}
+
+	if runReport.Seed != cfg.Seed || runReport.World.CargoUnits != int(cfg.CargoUnits) || runReport.World.Warehouses != int(cfg.Warehouses) {
+		t.Errorf("Expected seed %d with %d warehouses and %d units, but got %+v", cfg.Seed, cfg.Warehouses, cfg.CargoUnits, runReport.World)
+	}
+	if len(runReport.Operations) != 7 || runReport.Operations[opMoveUnit].Count == 0 || runReport.Operations[opMoveUnit].Latency.Count == 0 {
+		t.Errorf("Expected statistics of every operation, but got %+v", runReport.Operations)
+	}
+
+	var delivered uint64
+	for _, warehouse := range runReport.Warehouses {
+		delivered += warehouse.Delivered
+	}
+	if delivered != uint64(cfg.CargoUnits) || runReport.Mismatches != 0 {
+		t.Errorf("Expected %d delivered units without mismatches, but got %d and %d mismatches", cfg.CargoUnits, delivered, runReport.Mismatches)
+	}
+}
+
+func TestRunOverHTTPGateway(t *testing.T) {
+	srv := fakeserver.New()
+	addr, stop := srv.ListenHTTP()
+	t.Cleanup(stop)
+
+	cfg := newTestConfig(9)
+	cfg.Host, cfg.Port, _ = strings.Cut(addr, ":")
+	cfg.Transport = config.TransportHTTP
+	m := metrics.New()
+	httpTransport, closeTransport, transportErr := provideTransport(validConfig{cfg}, m, nil)
+	if transportErr != nil {
+		t.Fatalf("Not expected error when connecting, error: %v", transportErr)
+	}
+	t.Cleanup(closeTransport)
+
+	rnd := rand.New(rand.NewSource(cfg.Seed))
+	app, err := New(httpTransport, operator.New(rnd), rnd, m, cfg)
+	if err != nil {
+		t.Fatalf("Not expected error when creating App, error: %v", err)
+	}
+	t.Cleanup(func() { _ = app.Close() })
+
+	if runErr := app.Run(); runErr != nil {
+		t.Fatalf("Not expected error when running App, error: %v", runErr)
+	}
+
+	assertEveryUnitReached(t, srv, cfg)
+	assertSequencesWithoutGaps(t, srv)
+}
+
+func TestInitializeRejectsConfigBeforeConnecting(t *testing.T) {
+	cfg := newTestConfig(1)
+	cfg.ClockMode = "fast"
+	cfg.ConnectTimeout = time.Minute
+	cfg.RecordFile = filepath.Join(t.TempDir(), "run.jsonl")
+
+	started := time.Now()
+	if _, _, initErr := initializeApp(cfg); initErr == nil || !strings.Contains(initErr.Error(), "clock mode") {
+		t.Fatalf("Expected unknown clock mode error, but got %v", initErr)
+	}
+	if elapsed := time.Since(started); elapsed > 10*time.Second {
+		t.Errorf("Expected config to be rejected without dialing API, but it took %s", elapsed)
+	}
+	if _, statErr := os.Stat(cfg.RecordFile); !os.IsNotExist(statErr) {
+		t.Errorf("Expected no recording created for a rejected config, but got %v", statErr)
+	}
+}
+
+func TestValidateConfigChecksRetrySettings(t *testing.T) {
+	cfg := newTestConfig(1)
+	cfg.RetryJitter = 0.5
+	cfg.RetryCodes = "UNAVAILABLE,ABORTED"
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("Not expected error when validating retry settings, error: %v", err)
+	}
+
+	cfg.RetryJitter = 1.5
+	if err := validateConfig(cfg); err == nil {
+		t.Errorf("Expected error when retry jitter is above 1")
+	}
+
+	cfg.RetryJitter = 0.2
+	cfg.RetryCodes = "UNAVAILABLE,LATER"
+	if err := validateConfig(cfg); err == nil {
+		t.Errorf("Expected error when a retry code is unknown")
+	}
+}
+
+func TestNewClientRejectsStreamMovesOverHTTP(t *testing.T) {
+	cfg := newTestConfig(1)
+	cfg.Transport = config.TransportHTTP
+	cfg.StreamMoves = true
+
+	if _, clientErr := NewClient(cfg); clientErr == nil {
+		t.Errorf("Expected error when streaming moves over HTTP transport")
+	}
+}
+
+func TestRunOverNoopTransport(t *testing.T) {
+	cfg := newTestConfig(10)
+	cfg.StreamMoves = true
+	rnd := rand.New(rand.NewSource(cfg.Seed))
+	app, err := New(transport.NewNoop(), operator.New(rnd), rnd, metrics.New(), cfg)
+	if err != nil {
+		t.Fatalf("Not expected error when creating App, error: %v", err)
+	}
+	t.Cleanup(func() { _ = app.Close() })
+
+	if runErr := app.Run(); runErr != nil {
+		t.Fatalf("Not expected error when running App, error: %v", runErr)
+	}
+
+	var delivered uint64
+	for _, total := range app.statistics.WarehouseDeliveries {
+		delivered += total
+	}
+	if delivered != uint64(cfg.CargoUnits) {
+		t.Errorf("Expected %d units delivered, but got %d", cfg.CargoUnits, delivered)
+	}
+}
+
+func TestDryRunRecordsRequestsWithoutAPI(t *testing.T) {
+	dir := t.TempDir()
+	cfg := newTestConfig(11)
+	cfg.DryRun = true
+	cfg.RecordFile = filepath.Join(dir, "run.jsonl")
+	cfg.ReportFormat = "json"
+	cfg.ReportFile = filepath.Join(dir, "report.json")
+
+	app, cleanup, initErr := initializeApp(cfg)
+	if initErr != nil {
+		t.Fatalf("Not expected error when initializing dry run, error: %v", initErr)
+	}
+	if runErr := app.Run(); runErr != nil {
+		t.Fatalf("Not expected error when running App, error: %v", runErr)
+	}
+	if closeErr := app.Close(); closeErr != nil {
+		t.Fatalf("Not expected error when closing App, error: %v", closeErr)
+	}
+	cleanup()
+
+	entries, loadErr := recorder.Load(cfg.RecordFile)
+	if loadErr != nil {
+		t.Fatalf("Not expected error when loading recording, error: %v", loadErr)
+	}
+	reachedUnits := make(map[int64]bool)
+	for _, entry := range entries {
+		if entry.Method != logistics_v1.LogisticsEngineAPI_UnitReachedWarehouse_FullMethodName {
+			continue
+		}
+		msg, messageErr := entry.Message()
+		if messageErr != nil {
+			t.Fatalf("Not expected error when decoding entry, error: %v", messageErr)
+		}
+		reachedUnits[msg.(*logistics_v1.UnitReachedWarehouseRequest).GetAnnouncement().GetCargoUnitId()] = true
+	}
+	if len(reachedUnits) != int(cfg.CargoUnits) {
+		t.Errorf("Expected announcements of %d units recorded, but got %d", cfg.CargoUnits, len(reachedUnits))
+	}
+
+	data, readErr := os.ReadFile(cfg.ReportFile)
+	if readErr != nil {
+		t.Fatalf("Not expected error when reading report, error: %v", readErr)
+	}
+	var runReport report.Report
+	if err := json.Unmarshal(data, &runReport); err != nil {
+		t.Fatalf("Not expected error when decoding report, error: %v", err)
+	}
+	if runReport.Mismatches != 0 {
+		t.Errorf("Expected no mismatches in dry run, but got %d", runReport.Mismatches)
+	}
+}
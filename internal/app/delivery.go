@@ -0,0 +1,374 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/operator"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/pkg/workerpool"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/transport"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// moveUnits one step each, moves are sent over unary MoveUnit by a bounded pool of workers.
+// It returns units that are already at their warehouse, ordered by ID.
+func (a *App) moveUnits(units []*model.GraphNode) []*model.GraphNode {
+	var arrivedMu sync.Mutex
+	var arrived []*model.GraphNode
+
+	workerpool.Run(a.workers, units, func(unit *model.GraphNode) {
+		if a.processDelivery(unit) {
+			arrivedMu.Lock()
+			arrived = append(arrived, unit)
+			arrivedMu.Unlock()
+		}
+	})
+
+	sortByID(arrived)
+
+	return arrived
+}
+
+// processDelivery moves the unit one step and reports if the unit is at its warehouse.
+// A move API did not accept is sent again unchanged instead of making a new one.
+func (a *App) processDelivery(unit *model.GraphNode) (arrived bool) {
+	move, atWarehouse := a.nextMove(unit)
+	unitMessage := moveMessage(unit, move)
+
+	log.Println(unitMessage)
+
+	a.statistics.Operation[opMoveUnit].AddA()
+	started := time.Now()
+	moveErr := a.logisticsTransport.MoveUnit(a.ctx, move)
+	a.statistics.Operation[opMoveUnit].AddLatency(time.Since(started))
+	if moveErr != nil {
+		log.Printf("filed to send MoveUnit %s, API error: %v\n", unitMessage, moveErr)
+		a.statistics.Operation[opMoveUnit].AddB()
+
+		return false
+	}
+
+	a.requests[unit.ID].pendingMove = nil
+
+	return atWarehouse
+}
+
+// processDeliveryBatch moves every unit one step and sends all moves of the tick as batches over StreamMoveUnits.
+// It returns units that are already at their warehouse, ordered by ID.
+func (a *App) processDeliveryBatch(units []*model.GraphNode) []*model.GraphNode {
+	moves := make([]*logistics_v1.MoveUnitRequest, 0, len(units))
+	atWarehouse := make(map[uint]bool)
+	unitMessages := make(map[uint]string)
+
+	for _, unit := range units {
+		move, unitAtWarehouse := a.nextMove(unit)
+		unitMessages[unit.ID] = moveMessage(unit, move)
+		atWarehouse[unit.ID] = unitAtWarehouse
+
+		log.Println(unitMessages[unit.ID])
+
+		moves = append(moves, move)
+	}
+
+	a.statistics.Operation[opStreamMoveUnits].AddA()
+	started := time.Now()
+	unitErrs, streamErr := a.sendMoves(moves)
+	a.statistics.Operation[opStreamMoveUnits].AddLatency(time.Since(started))
+	if streamErr != nil {
+		log.Printf("filed to send StreamMoveUnits batch of %d moves, API error: %v\n", len(moves), streamErr)
+		a.statistics.Operation[opStreamMoveUnits].AddB()
+		a.closeMoveStream()
+	}
+
+	var arrived []*model.GraphNode
+	for _, unit := range units {
+		a.statistics.Operation[opMoveUnit].AddA()
+
+		moveErr := streamErr
+		if moveErr == nil {
+			moveErr = unitErrs[int64(unit.ID)]
+		}
+		if moveErr != nil {
+			log.Printf("filed to send MoveUnit %s, API error: %v\n", unitMessages[unit.ID], moveErr)
+			a.statistics.Operation[opMoveUnit].AddB()
+			continue
+		}
+
+		a.requests[unit.ID].pendingMove = nil
+		if atWarehouse[unit.ID] {
+			arrived = append(arrived, unit)
+		}
+	}
+
+	sortByID(arrived)
+
+	return arrived
+}
+
+// sendMoves over the move stream, opening a new one if there is none
+func (a *App) sendMoves(moves []*logistics_v1.MoveUnitRequest) (map[int64]error, error) {
+	if a.moveStream == nil {
+		stream, openErr := a.logisticsTransport.(transport.MoveStreamer).OpenMoveUnitsStream(a.ctx, a.moveBatchSize)
+		if openErr != nil {
+			return nil, openErr
+		}
+
+		a.moveStream = stream
+	}
+
+	return a.moveStream.Send(moves)
+}
+
+func (a *App) closeMoveStream() {
+	if a.moveStream == nil {
+		return
+	}
+
+	if closeErr := a.moveStream.Close(); closeErr != nil {
+		log.Printf("%s, failed to close StreamMoveUnits: %v\n", appName, closeErr)
+	}
+	a.moveStream = nil
+}
+
+// announceDeliveries of all pending deliveries by a bounded pool of workers,
+// deliveries that failed stay pending for the next tick
+func (a *App) announceDeliveries(units map[uint]*model.GraphNode) {
+	var failedMu sync.Mutex
+	var failed []operator.Delivery
+
+	workerpool.Run(a.workers, a.pendingDeliveries, func(delivery operator.Delivery) {
+		if !a.reachWarehouse(units[delivery.UnitID], delivery) {
+			failedMu.Lock()
+			failed = append(failed, delivery)
+			failedMu.Unlock()
+		}
+	})
+
+	sort.Slice(failed, func(i, j int) bool { return failed[i].UnitID < failed[j].UnitID })
+	a.pendingDeliveries = failed
+}
+
+// reachWarehouse announces that the unit was unloaded at the warehouse and, when the unit carries a shipment,
+// that goods were picked up or delivered there. It reports if every announcement was accepted, an accepted
+// UnitReachedWarehouse is not sent again when only the shipment event has to be resent.
+func (a *App) reachWarehouse(unit *model.GraphNode, delivery operator.Delivery) bool {
+	requests := a.requests[unit.ID]
+	if !requests.reachedAnnounced {
+		if !a.announceReached(unit, delivery) {
+			return false
+		}
+		requests.reachedAnnounced = true
+	}
+
+	shipment, hasShipment := a.globalOperator.Shipment(unit.ID)
+	if !hasShipment {
+		requests.reachedAnnounced = false
+		requests.stops++
+		unit.CargoUnit().Status = model.CargoUnitIdle // Unit reached Warehouse
+
+		return true
+	}
+
+	if !a.announceShipmentStop(unit, delivery, shipment) {
+		return false
+	}
+
+	shipment, completeErr := a.globalOperator.CompleteStop(unit.ID)
+	if completeErr != nil {
+		log.Printf("%s, failed to complete stop of %s: %v\n", appName, unit.Name, completeErr)
+		return false
+	}
+
+	requests.reachedAnnounced = false
+	requests.stops++
+	if shipment.Status == model.ShipmentDelivered && a.orders != nil {
+		a.deliverOrder(shipment.ID, requests.unloadedAt.AsTime())
+	}
+
+	return true
+}
+
+// announceReached sends UnitReachedWarehouse of the unit, it reports if the announcement was accepted
+func (a *App) announceReached(unit *model.GraphNode, delivery operator.Delivery) bool {
+	coordinate := *unit.Coordinate
+	unitMessage := fmt.Sprintf("%s moving to - Latitude:%d, Longitude:%d", unit.Name, coordinate.X, coordinate.Y)
+	announcement := fmt.Sprintf("%s - Reached Objective.", unitMessage)
+	requests := a.requests[unit.ID]
+	sequence := requests.sequence + 1 // Kept until accepted, so every resend carries the same sequence
+
+	a.statistics.Operation[opUnitReachedWarehouse].AddA()
+	started := time.Now()
+	reachErr := a.logisticsTransport.UnitReachedWarehouse(
+		a.ctx,
+		&logistics_v1.UnitReachedWarehouseRequest{
+			Location: &logistics_v1.Location{Latitude: uint32(coordinate.X), Longitude: uint32(coordinate.Y)},
+			Announcement: &logistics_v1.WarehouseAnnouncement{
+				CargoUnitId: int64(unit.ID),
+				WarehouseId: int64(delivery.WarehouseID),
+				Message:     announcement,
+				QueueLength: delivery.QueueLength,
+				WaitTicks:   delivery.WaitTicks,
+			},
+			IdempotencyKey: fmt.Sprintf("%s/reached/%d/%d", a.runID, unit.ID, requests.stops+1),
+			Sequence:       sequence,
+			SimulatedTime:  requests.unloadedAt,
+		},
+	)
+	a.statistics.Operation[opUnitReachedWarehouse].AddLatency(time.Since(started))
+	if reachErr != nil {
+		log.Printf("filed to send UnitReachedWarehouse %s, API error: %v\n", unitMessage, reachErr)
+		a.statistics.Operation[opUnitReachedWarehouse].AddB()
+		return false
+	}
+
+	log.Println(announcement)
+	requests.sequence = sequence
+	a.statistics.AddDelivery(delivery.WarehouseID)
+	a.metrics.AddDelivery(delivery.WarehouseID)
+
+	return true
+}
+
+// announceShipmentStop sends PickedUp at the first stop of the shipment and Delivered at every drop-off,
+// it reports if the event was accepted
+func (a *App) announceShipmentStop(unit *model.GraphNode, delivery operator.Delivery, shipment model.Shipment) bool {
+	coordinate := *unit.Coordinate
+	location := &logistics_v1.Location{Latitude: uint32(coordinate.X), Longitude: uint32(coordinate.Y)}
+	requests := a.requests[unit.ID]
+	sequence := requests.sequence + 1 // Kept until accepted, so every resend carries the same sequence
+
+	operation := opDelivered
+	var send func() error
+	var event string
+	if shipment.Next == 0 {
+		operation = opPickedUp
+		event = fmt.Sprintf("%s - Picked up shipment %d at warehouse %d.", unit.Name, shipment.ID, delivery.WarehouseID)
+		send = func() error {
+			return a.logisticsTransport.PickedUp(a.ctx, &logistics_v1.PickedUpRequest{
+				ShipmentId:          int64(shipment.ID),
+				CargoUnitId:         int64(unit.ID),
+				WarehouseId:         int64(delivery.WarehouseID),
+				Location:            location,
+				DropOffWarehouseIds: warehouseIDs(shipment.DropOffs()),
+				IdempotencyKey:      fmt.Sprintf("%s/picked_up/%d", a.runID, shipment.ID),
+				Sequence:            sequence,
+				SimulatedTime:       requests.unloadedAt,
+			})
+		}
+	} else {
+		final := shipment.Next == len(shipment.Stops)-1
+		event = fmt.Sprintf("%s - Delivered shipment %d at warehouse %d, drop-off %d of %d.",
+			unit.Name, shipment.ID, delivery.WarehouseID, shipment.Next, len(shipment.DropOffs()))
+		send = func() error {
+			return a.logisticsTransport.Delivered(a.ctx, &logistics_v1.DeliveredRequest{
+				ShipmentId:     int64(shipment.ID),
+				CargoUnitId:    int64(unit.ID),
+				WarehouseId:    int64(delivery.WarehouseID),
+				Location:       location,
+				DropOff:        uint32(shipment.Next),
+				Final:          final,
+				IdempotencyKey: fmt.Sprintf("%s/delivered/%d/%d", a.runID, shipment.ID, shipment.Next),
+				Sequence:       sequence,
+				SimulatedTime:  requests.unloadedAt,
+			})
+		}
+	}
+
+	a.statistics.Operation[operation].AddA()
+	started := time.Now()
+	sendErr := send()
+	a.statistics.Operation[operation].AddLatency(time.Since(started))
+	if sendErr != nil {
+		log.Printf("filed to send %s of shipment %d, API error: %v\n", a.statistics.Operation[operation].Name, shipment.ID, sendErr)
+		a.statistics.Operation[operation].AddB()
+		return false
+	}
+
+	log.Println(event)
+	requests.sequence = sequence
+
+	return true
+}
+
+func warehouseIDs(ids []uint) []int64 {
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, int64(id))
+	}
+
+	return result
+}
+
+// nextMove of the unit, it is the move API did not accept yet or a new one when there is none.
+// atWarehouse reports if the move leaves the unit at its warehouse.
+func (a *App) nextMove(unit *model.GraphNode) (move *logistics_v1.MoveUnitRequest, atWarehouse bool) {
+	requests := a.requests[unit.ID]
+	if requests.pendingMove != nil {
+		return requests.pendingMove, requests.pendingAtWarehouse
+	}
+
+	oldCoordinate := *unit.Coordinate
+	newCoordinate := a.globalOperator.MoveDeliveryUnitToNearestWarehouse(unit.ID)
+	state := unit.CargoUnit()
+	if state.Status == model.CargoUnitBroken {
+		log.Printf("%s - Ran out of fuel, broken down.\n", unit.Name)
+	}
+
+	requests.sequence++
+	requests.pendingMove = &logistics_v1.MoveUnitRequest{
+		CargoUnitId: int64(unit.ID),
+		Location: &logistics_v1.Location{
+			Latitude:  uint32(newCoordinate.X),
+			Longitude: uint32(newCoordinate.Y),
+		},
+		IdempotencyKey: fmt.Sprintf("%s/move/%d/%d", a.runID, unit.ID, requests.sequence),
+		Sequence:       requests.sequence,
+		SimulatedTime:  timestamppb.New(a.clock.Now()),
+		State:          cargoUnitState(state),
+	}
+	requests.pendingAtWarehouse = newCoordinate == oldCoordinate
+
+	return requests.pendingMove, requests.pendingAtWarehouse
+}
+
+func cargoUnitState(unit *model.CargoUnit) *logistics_v1.CargoUnitState {
+	return &logistics_v1.CargoUnitState{
+		MaxWeight:  unit.MaxWeight,
+		MaxVolume:  unit.MaxVolume,
+		LoadWeight: unit.Weight,
+		LoadVolume: unit.Volume,
+		Speed:      uint32(unit.Speed),
+		Fuel:       unit.Fuel,
+		Status:     cargoUnitStatus(unit.Status),
+	}
+}
+
+func cargoUnitStatus(status model.CargoUnitStatus) logistics_v1.CargoUnitStatus {
+	switch status {
+	case model.CargoUnitIdle:
+		return logistics_v1.CargoUnitStatus_CARGO_UNIT_STATUS_IDLE
+	case model.CargoUnitEnRoute:
+		return logistics_v1.CargoUnitStatus_CARGO_UNIT_STATUS_EN_ROUTE
+	case model.CargoUnitLoading:
+		return logistics_v1.CargoUnitStatus_CARGO_UNIT_STATUS_LOADING
+	case model.CargoUnitUnloading:
+		return logistics_v1.CargoUnitStatus_CARGO_UNIT_STATUS_UNLOADING
+	case model.CargoUnitBroken:
+		return logistics_v1.CargoUnitStatus_CARGO_UNIT_STATUS_BROKEN
+	}
+
+	return logistics_v1.CargoUnitStatus_CARGO_UNIT_STATUS_UNSPECIFIED
+}
+
+func moveMessage(unit *model.GraphNode, move *logistics_v1.MoveUnitRequest) string {
+	return fmt.Sprintf("%s moving to - Latitude:%d, Longitude:%d", unit.Name, move.GetLocation().GetLatitude(), move.GetLocation().GetLongitude())
+}
+
+func sortByID(units []*model.GraphNode) {
+	sort.Slice(units, func(i, j int) bool { return units[i].ID < units[j].ID })
+}
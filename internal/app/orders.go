@@ -0,0 +1,192 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/config"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/generator"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/operator"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/pkg/workerpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// orderBook of a run with orders. Every order is created at API before it is dispatched to a unit,
+// orders API did not accept yet are sent again on the next tick.
+type orderBook struct {
+	generator *generator.OrderGenerator
+
+	mu sync.Mutex
+	// orders generated so far by ID
+	orders map[uint]*model.Order
+	// unsent orders API did not accept yet
+	unsent []model.Order
+	// pending orders accepted by API and waiting for a free unit
+	pending []model.Order
+	// plans of vrp routing, see operator.DispatchRoutes
+	plans []operator.RoutePlan
+}
+
+// done reports if every order was generated, created at API and dispatched
+func (b *orderBook) done() bool {
+	return b.generator.Done() && len(b.unsent) == 0 && len(b.pending) == 0
+}
+
+// list of every order ordered by ID
+func (b *orderBook) list() []model.Order {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	orders := make([]model.Order, 0, len(b.orders))
+	for _, order := range b.orders {
+		orders = append(orders, *order)
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].ID < orders[j].ID })
+
+	return orders
+}
+
+// takeOrders arriving during the tick, creates them at API and dispatches them to free units,
+// units that got an order are on their way again
+func (a *App) takeOrders(units map[uint]*model.GraphNode) {
+	a.orders.mu.Lock()
+	for _, order := range a.orders.generator.Next(a.clock.Now()) {
+		a.orders.orders[order.ID] = &order
+		a.orders.unsent = append(a.orders.unsent, order)
+	}
+	a.orders.mu.Unlock()
+
+	var failedMu sync.Mutex
+	var failed []model.Order
+	var created []model.Order
+	workerpool.Run(a.workers, a.orders.unsent, func(order model.Order) {
+		ok := a.createOrder(order)
+
+		failedMu.Lock()
+		defer failedMu.Unlock()
+		if !ok {
+			failed = append(failed, order)
+			return
+		}
+		created = append(created, order)
+	})
+	sort.Slice(failed, func(i, j int) bool { return failed[i].ID < failed[j].ID })
+	a.orders.unsent = failed
+
+	assigned, pending := a.dispatch(append(a.orders.pending, created...))
+	a.orders.pending = pending
+
+	a.orders.mu.Lock()
+	defer a.orders.mu.Unlock()
+	for _, order := range assigned {
+		*a.orders.orders[order.ID] = order
+		log.Printf("Order %d dispatched to %s.\n", order.ID, units[order.UnitID].Name)
+	}
+}
+
+// undeliverable pending orders no unit that is not broken can carry, they would wait for a free unit forever
+func (a *App) undeliverable() []uint {
+	var ids []uint
+	for _, order := range a.orders.pending {
+		if !a.globalOperator.Carriable(order.Weight, order.Volume) {
+			ids = append(ids, order.ID)
+		}
+	}
+
+	return ids
+}
+
+// strandOrders assigned to units that broke down before dropping their goods, their orders are never delivered
+func (a *App) strandOrders(units map[uint]*model.GraphNode) {
+	a.orders.mu.Lock()
+	defer a.orders.mu.Unlock()
+
+	var stranded []uint
+	for _, order := range a.orders.orders {
+		if order.Status == model.OrderAssigned && units[order.UnitID].CargoUnit().Status == model.CargoUnitBroken {
+			order.Status = model.OrderStranded
+			stranded = append(stranded, order.ID)
+		}
+	}
+	if len(stranded) == 0 {
+		return
+	}
+
+	sort.Slice(stranded, func(i, j int) bool { return stranded[i] < stranded[j] })
+	log.Printf("Orders %v are stranded on units that broke down.\n", stranded)
+}
+
+// dispatch orders to free units by the configured routing
+func (a *App) dispatch(orders []model.Order) (assigned, pending []model.Order) {
+	if a.cfg.Routing != config.RoutingVRP {
+		return a.globalOperator.Dispatch(orders)
+	}
+
+	assigned, pending, plan := a.globalOperator.DispatchRoutes(orders, a.clock.Now(), a.cfg.TickDuration)
+	if plan.Orders > 0 {
+		a.orders.plans = append(a.orders.plans, plan)
+		log.Printf("Routes of %d orders planned, %d steps against %d steps of greedy dispatch.\n", plan.Orders, plan.Distance, plan.GreedyDistance)
+		if len(plan.Late) > 0 {
+			log.Printf("Orders %v can't be delivered by their deadline, they are planned late.\n", plan.Late)
+		}
+	}
+
+	return assigned, pending
+}
+
+// createOrder at API, it reports if the order was accepted
+func (a *App) createOrder(order model.Order) bool {
+	a.statistics.Operation[opCreateOrder].AddA()
+	started := time.Now()
+	createErr := a.logisticsTransport.CreateOrder(a.ctx, &logistics_v1.CreateOrderRequest{
+		OrderId:                int64(order.ID),
+		OriginWarehouseId:      int64(order.Origin),
+		DestinationWarehouseId: int64(order.Destination),
+		Weight:                 order.Weight,
+		Volume:                 order.Volume,
+		Priority:               orderPriority(order.Priority),
+		Deadline:               timestamppb.New(order.Deadline),
+		IdempotencyKey:         fmt.Sprintf("%s/order/%d", a.runID, order.ID),
+		SimulatedTime:          timestamppb.New(order.CreatedAt),
+	})
+	a.statistics.Operation[opCreateOrder].AddLatency(time.Since(started))
+	if createErr != nil {
+		log.Printf("filed to send CreateOrder of order %d, API error: %v\n", order.ID, createErr)
+		a.statistics.Operation[opCreateOrder].AddB()
+		return false
+	}
+
+	return true
+}
+
+// deliverOrder of the shipment with the same ID at simulated time deliveredAt
+func (a *App) deliverOrder(shipmentID uint, deliveredAt time.Time) {
+	a.orders.mu.Lock()
+	defer a.orders.mu.Unlock()
+
+	order, ok := a.orders.orders[shipmentID]
+	if !ok {
+		return
+	}
+
+	order.Status = model.OrderDelivered
+	order.DeliveredAt = deliveredAt
+}
+
+func orderPriority(priority model.OrderPriority) logistics_v1.OrderPriority {
+	switch priority {
+	case model.OrderPriorityLow:
+		return logistics_v1.OrderPriority_ORDER_PRIORITY_LOW
+	case model.OrderPriorityNormal:
+		return logistics_v1.OrderPriority_ORDER_PRIORITY_NORMAL
+	case model.OrderPriorityHigh:
+		return logistics_v1.OrderPriority_ORDER_PRIORITY_HIGH
+	}
+
+	return logistics_v1.OrderPriority_ORDER_PRIORITY_UNSPECIFIED
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"log"
+	"math/rand"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/grpc_client"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/config"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/metrics"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/recorder"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/transport"
+)
+
+// Providers of initializeApp, see wire.go
+
+// provideRand seeded from cfg, the world operator and App share it, so a run is reproduced from its seed
+func provideRand(cfg *config.ClientAppConfig) *rand.Rand {
+	return rand.New(rand.NewSource(cfg.Seed))
+}
+
+// validConfig is a run config accepted by validateConfig. Providers that open files or dial API take it,
+// so a mistyped flag is reported before anything is connected.
+type validConfig struct {
+	*config.ClientAppConfig
+}
+
+// provideValidConfig from cfg, see validateConfig
+func provideValidConfig(cfg *config.ClientAppConfig) (validConfig, error) {
+	if validateErr := validateConfig(cfg); validateErr != nil {
+		return validConfig{}, validateErr
+	}
+
+	return validConfig{cfg}, nil
+}
+
+// provideRecorder of requests to cfg.RecordFile, nil when recording is disabled. Cleanup flushes and closes the file.
+func provideRecorder(cfg validConfig) (*recorder.Recorder, func(), error) {
+	if len(cfg.RecordFile) == 0 {
+		return nil, func() {}, nil
+	}
+
+	requestRecorder, recorderErr := recorder.Create(cfg.RecordFile)
+	if recorderErr != nil {
+		return nil, nil, recorderErr
+	}
+
+	log.Printf("%s, recording requests to %s\n", appName, cfg.RecordFile)
+
+	cleanup := func() {
+		if closeErr := requestRecorder.Close(); closeErr != nil {
+			log.Printf("%s, failed to write recording %s, error: %v\n", appName, cfg.RecordFile, closeErr)
+		}
+	}
+
+	return requestRecorder, cleanup, nil
+}
+
+// provideTransport connected to API over cfg.Transport with calls measured by m, or a local one logging
+// requests in dry run. Requests are recorded to r when it is set. Cleanup closes the transport.
+func provideTransport(cfg validConfig, m *metrics.Metrics, r *recorder.Recorder) (transport.LogisticsTransport, func(), error) {
+	var t transport.LogisticsTransport
+	if cfg.DryRun {
+		log.Printf("%s, dry run, requests are logged instead of sent to API\n", appName)
+		t = transport.NewLogging(transport.NewNoop(), log.Default())
+	} else {
+		apiTransport, connErr := connectTransport(cfg.ClientAppConfig, m)
+		if connErr != nil {
+			return nil, nil, connErr
+		}
+		t = apiTransport
+	}
+
+	if r != nil {
+		t = transport.NewRecording(t, r)
+	}
+
+	cleanup := func() {
+		if closeErr := t.Close(); closeErr != nil {
+			log.Printf("%s, failed to close transport, error: %v\n", appName, closeErr)
+		}
+	}
+
+	return t, cleanup, nil
+}
+
+// connectTransport to API over cfg.Transport
+func connectTransport(cfg *config.ClientAppConfig, m *metrics.Metrics) (transport.LogisticsTransport, error) {
+	lc, clientErr := NewClient(cfg, grpc_client.WithInterceptors(m.Interceptors()))
+	if clientErr != nil {
+		return nil, clientErr
+	}
+
+	log.Printf("%s, trying to connect to API - %s...\n", appName, cfg.GetCombinedAddress())
+	if connErr := Connect(context.Background(), lc, cfg); connErr != nil {
+		return nil, connErr
+	}
+
+	if cfg.Transport == config.TransportHTTP {
+		return transport.NewHTTP(lc), nil
+	}
+
+	return transport.NewGRPC(lc), nil
+}
@@ -0,0 +1,155 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/config"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/report"
+)
+
+// errMetricsMismatch is returned when API metrics disagree with what the client delivered
+var errMetricsMismatch = errors.New("API metrics report does not match client deliveries")
+
+// fetchMetricsReport from API, counting the call in statistics
+func (a *App) fetchMetricsReport() (*logistics_v1.MetricsReportResponse, error) {
+	a.statistics.Operation[opMetricsReport].AddA()
+	started := time.Now()
+	report, reportErr := a.logisticsTransport.MetricsReport(a.ctx)
+	a.statistics.Operation[opMetricsReport].AddLatency(time.Since(started))
+	if reportErr != nil {
+		a.statistics.Operation[opMetricsReport].AddB()
+		return nil, reportErr
+	}
+
+	return report, nil
+}
+
+// checkMetricsReport fetches the final API report and compares per-warehouse received totals with deliveries
+// made by the client during this run. The API may keep counters of earlier runs, so totals are compared
+// as a difference from the report fetched before the run started.
+func (a *App) checkMetricsReport() ([]report.WarehouseTotals, int, error) {
+	metricsReport, reportErr := a.fetchMetricsReport()
+	if reportErr != nil {
+		return nil, 0, fmt.Errorf("%s, failed to fetch MetricsReport: %w", appName, reportErr)
+	}
+
+	received := warehouseTotals(metricsReport)
+	for warehouseID, total := range warehouseTotals(a.baselineReport) {
+		received[warehouseID] -= total
+	}
+
+	warehouseIDs := make([]uint, 0, len(received))
+	for warehouseID := range received {
+		warehouseIDs = append(warehouseIDs, warehouseID)
+	}
+	for warehouseID := range a.statistics.WarehouseDeliveries {
+		if _, ok := received[warehouseID]; !ok {
+			warehouseIDs = append(warehouseIDs, warehouseID)
+		}
+	}
+	sort.Slice(warehouseIDs, func(i, j int) bool { return warehouseIDs[i] < warehouseIDs[j] })
+
+	var totals []report.WarehouseTotals
+	var mismatches int
+	for _, warehouseID := range warehouseIDs {
+		apiReceived := received[warehouseID]
+		clientDelivered := a.statistics.WarehouseDeliveries[warehouseID]
+		if apiReceived == 0 && clientDelivered == 0 {
+			continue
+		}
+
+		status := report.StatusOK
+		if apiReceived != int64(clientDelivered) {
+			status = report.StatusMismatch
+			mismatches++
+		}
+
+		totals = append(totals, report.WarehouseTotals{
+			WarehouseID: warehouseID,
+			Received:    apiReceived,
+			Delivered:   clientDelivered,
+			Status:      status,
+		})
+	}
+
+	if mismatches > 0 {
+		log.Printf("%s, %d warehouse(s) differ between API report and client deliveries\n", appName, mismatches)
+		return totals, mismatches, errMetricsMismatch
+	}
+
+	return totals, 0, nil
+}
+
+// writeReport of the run in the configured format
+func (a *App) writeReport(warehouses []report.WarehouseTotals, mismatches int) error {
+	runReport := &report.Report{
+		Seed:   a.cfg.Seed,
+		Config: a.cfg.Settings(),
+		World: report.World{
+			Warehouses: len(a.globalOperator.GetWarehouses()),
+			CargoUnits: len(a.globalOperator.GetDeliveryUnit()),
+			GridWidth:  a.globalOperator.GridWidth(),
+			GridHeight: a.globalOperator.GridHeight(),
+		},
+		ExecutionTime:   time.Since(a.statistics.ExecTime),
+		SimulatedTime:   a.clock.Elapsed(),
+		Ticks:           a.clock.Tick(),
+		BrokenUnits:     brokenUnits(a.globalOperator.GetDeliveryUnit()),
+		Operations:      report.NewOperations(a.statistics.Operation),
+		WarehouseQueues: report.NewWarehouseQueues(a.statistics.WarehouseQueues),
+		Warehouses:      warehouses,
+		Mismatches:      mismatches,
+	}
+	if a.orders != nil {
+		runReport.Orders = report.NewOrderTotals(a.orders.list())
+		if a.cfg.Routing == config.RoutingVRP {
+			runReport.Routing = &report.Routing{Plans: len(a.orders.plans)}
+			for _, plan := range a.orders.plans {
+				runReport.Routing.Orders += plan.Orders
+				runReport.Routing.Distance += plan.Distance
+				runReport.Routing.GreedyDistance += plan.GreedyDistance
+				runReport.Routing.Late += len(plan.Late)
+			}
+		}
+	} else {
+		runReport.Shipments = report.NewShipments(a.globalOperator.Shipments())
+	}
+
+	if writeErr := report.WriteFile(a.cfg.ReportFile, report.Format(a.cfg.ReportFormat), runReport); writeErr != nil {
+		return fmt.Errorf("%s, failed to write report: %w", appName, writeErr)
+	}
+
+	if len(a.cfg.ReportFile) > 0 {
+		log.Printf("%s, %s report written to %s\n", appName, a.cfg.ReportFormat, a.cfg.ReportFile)
+	}
+
+	return nil
+}
+
+// warehouseTotals of units received by each warehouse according to the report
+func warehouseTotals(report *logistics_v1.MetricsReportResponse) map[uint]int64 {
+	totals := make(map[uint]int64)
+	for _, warehouse := range report.GetDeliveryUnitsEachWarehouseReceivedTotalNumber() {
+		totals[uint(warehouse.GetWarehouseId())] += warehouse.GetDeliveryUnitsNumber()
+	}
+
+	return totals
+}
+
+// brokenUnits of the world, they ran out of fuel and never reached their warehouse
+func brokenUnits(units []*model.GraphNode) int {
+	broken := 0
+	for _, unit := range units {
+		if unit.CargoUnit().Status == model.CargoUnitBroken {
+			broken++
+		}
+	}
+
+	return broken
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/report"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/transport"
+)
+
+// reportTransport answers MetricsReport with a fixed report, other calls are not expected
+type reportTransport struct {
+	transport.LogisticsTransport
+	report *logistics_v1.MetricsReportResponse
+}
+
+func (t *reportTransport) MetricsReport(context.Context) (*logistics_v1.MetricsReportResponse, error) {
+	return t.report, nil
+}
+
+// newReportTestApp over API answering MetricsReport with report, delivered are units the client delivered
+func newReportTestApp(report *logistics_v1.MetricsReportResponse, delivered map[uint]uint64) *App {
+	return &App{
+		ctx:                context.Background(),
+		logisticsTransport: &reportTransport{report: report},
+		statistics: &model.Statistics{
+			Operation:           []*model.Operation{{Name: "MoveUnit"}, {Name: "UnitReachedWarehouse"}, {Name: "StreamMoveUnits"}, {Name: "MetricsReport"}},
+			WarehouseDeliveries: delivered,
+		},
+	}
+}
+
+// receivedReport with units received by each warehouse
+func receivedReport(received map[int64]int64) *logistics_v1.MetricsReportResponse {
+	metricsReport := &logistics_v1.MetricsReportResponse{}
+	for warehouseID, total := range received {
+		metricsReport.DeliveryUnitsEachWarehouseReceivedTotalNumber = append(
+			metricsReport.DeliveryUnitsEachWarehouseReceivedTotalNumber,
+			&logistics_v1.DeliveryUnitsWarehouseReceivedTotalNumber{WarehouseId: warehouseID, DeliveryUnitsNumber: total},
+		)
+	}
+
+	return metricsReport
+}
+
+func TestCheckMetricsReportComparesRunWithBaseline(t *testing.T) {
+	// API already counted 5 units of an earlier run at warehouse 0
+	a := newReportTestApp(receivedReport(map[int64]int64{0: 7, 1: 1}), map[uint]uint64{0: 2, 1: 1})
+	a.baselineReport = receivedReport(map[int64]int64{0: 5})
+
+	totals, mismatches, checkErr := a.checkMetricsReport()
+	if checkErr != nil || mismatches != 0 {
+		t.Errorf("Not expected error when API counted every delivery, %d mismatches, error: %v", mismatches, checkErr)
+	}
+
+	expected := []report.WarehouseTotals{
+		{WarehouseID: 0, Received: 2, Delivered: 2, Status: report.StatusOK},
+		{WarehouseID: 1, Received: 1, Delivered: 1, Status: report.StatusOK},
+	}
+	if !reflect.DeepEqual(totals, expected) {
+		t.Errorf("Expected totals %+v, but got %+v", expected, totals)
+	}
+	if a.statistics.Operation[opMetricsReport].A != 1 {
+		t.Errorf("Expected MetricsReport call to be counted once, but got %d", a.statistics.Operation[opMetricsReport].A)
+	}
+}
+
+func TestCheckMetricsReportDetectsMismatch(t *testing.T) {
+	tests := map[string]struct {
+		received  map[int64]int64
+		delivered map[uint]uint64
+	}{
+		"API counted fewer units":     {received: map[int64]int64{0: 1}, delivered: map[uint]uint64{0: 2}},
+		"API counted more units":      {received: map[int64]int64{0: 3}, delivered: map[uint]uint64{0: 2}},
+		"warehouse missing in report": {received: map[int64]int64{0: 2}, delivered: map[uint]uint64{0: 2, 4: 1}},
+		"warehouse unknown to client": {received: map[int64]int64{0: 2, 3: 1}, delivered: map[uint]uint64{0: 2}},
+		"nothing delivered by client": {received: map[int64]int64{1: 1}, delivered: nil},
+		"nothing counted by API":      {received: nil, delivered: map[uint]uint64{1: 1}},
+	}
+	for name, tt := range tests {
+		a := newReportTestApp(receivedReport(tt.received), tt.delivered)
+
+		_, mismatches, checkErr := a.checkMetricsReport()
+		if !errors.Is(checkErr, errMetricsMismatch) || mismatches != 1 {
+			t.Errorf("Expected %v with 1 mismatch when %s, but got %d mismatches and %v", errMetricsMismatch, name, mismatches, checkErr)
+		}
+	}
+}
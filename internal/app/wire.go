@@ -0,0 +1,25 @@
+//go:build wireinject
+
+package app
+
+import (
+	"github.com/google/wire"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/config"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/operator"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/metrics"
+)
+
+// initializeApp of a run configured by cfg, run `go generate ./internal/app` after changing providers
+// Cleanup closes the transport and the recording, it is called after App is closed.
+func initializeApp(cfg *config.ClientAppConfig) (*App, func(), error) {
+	wire.Build(
+		provideValidConfig,
+		provideRand,
+		operator.New,
+		metrics.New,
+		provideRecorder,
+		provideTransport,
+		New,
+	)
+	return nil, nil, nil
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const programName = "logistics"
+
+// command of the CLI, run gets arguments after the command name
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) error
+}
+
+var commands = []command{
+	{name: "run", summary: "run the simulation against API", run: runCommand},
+	{name: "generate", summary: "generate a world and write it as a scenario file", run: generateCommand},
+	{name: "report", summary: "print MetricsReport of API", run: reportCommand},
+	{name: "replay", summary: "resend requests of a recorded run", run: replayCommand},
+	{name: "ping", summary: "check that API is reachable and answers", run: pingCommand},
+}
+
+// errUsage is returned for invalid command lines after usage was printed
+var errUsage = errors.New("invalid usage")
+
+// Main runs the command named by the first argument and returns the process exit code.
+// Settings are loaded from environment first, flags of the command override them.
+func Main(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return 2
+	}
+
+	name := args[0]
+	switch name {
+	case "help", "-h", "-help", "--help":
+		printUsage(stdout)
+		return 0
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+
+		err := cmd.run(args[1:], stdout, stderr)
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			return 2
+		}
+
+		fmt.Fprintf(stderr, "%s %s: %v\n", programName, name, err)
+
+		return 1
+	}
+
+	fmt.Fprintf(stderr, "%s: unknown command %q\n\n", programName, name)
+	printUsage(stderr)
+
+	return 2
+}
+
+func printUsage(w io.Writer) {
+	var builder strings.Builder
+	builder.WriteString("Usage: " + programName + " <command> [flags]\n\nCommands:\n")
+	for _, cmd := range commands {
+		builder.WriteString(fmt.Sprintf("  %-10s %s\n", cmd.name, cmd.summary))
+	}
+	builder.WriteString("\nRun '" + programName + " <command> --help' for flags of the command.\n")
+
+	_, _ = io.WriteString(w, builder.String())
+}
+
+// newFlagSet of the command that prints its usage to stderr and returns parsing errors instead of exiting
+func newFlagSet(name, summary string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(programName+" "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: %s %s [flags]\n\n%s\n\nFlags:\n", programName, name, summary)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+// parseFlags of the command, extra positional arguments are rejected
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if parseErr := fs.Parse(args); parseErr != nil {
+		if errors.Is(parseErr, flag.ErrHelp) {
+			return parseErr
+		}
+
+		return errUsage
+	}
+
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		fs.Usage()
+
+		return errUsage
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/fakeserver"
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/grpc_client"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/scenario"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/recorder"
+)
+
+// listenFakeServer makes commands connect to srv over an in-memory connection
+func listenFakeServer(t *testing.T, srv *fakeserver.Server) {
+	t.Helper()
+
+	dialOption, stop := srv.Listen()
+	dialOptions = []grpc_client.Option{grpc_client.WithDialOptions(dialOption)}
+	t.Cleanup(func() {
+		stop()
+		dialOptions = nil
+	})
+}
+
+func runMain(args ...string) (code int, stdout, stderr string) {
+	var stdoutBuf, stderrBuf bytes.Buffer
+	code = Main(args, &stdoutBuf, &stderrBuf)
+
+	return code, stdoutBuf.String(), stderrBuf.String()
+}
+
+func TestMainUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		contains string
+	}{
+		{name: "no command", args: nil, code: 2, contains: "Commands:"},
+		{name: "help", args: []string{"--help"}, code: 0, contains: "generate"},
+		{name: "unknown command", args: []string{"fly"}, code: 2, contains: `unknown command "fly"`},
+		{name: "command help", args: []string{"ping", "--help"}, code: 0, contains: "-connect-timeout"},
+		{name: "unknown flag", args: []string{"report", "-colour"}, code: 2, contains: "-colour"},
+		{name: "extra argument", args: []string{"generate", "world.yaml"}, code: 2, contains: "unexpected arguments: world.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := runMain(tt.args...)
+			if code != tt.code {
+				t.Errorf("Expected exit code %d, but got %d", tt.code, code)
+			}
+			if !strings.Contains(stdout+stderr, tt.contains) {
+				t.Errorf("Expected output to contain %q, but got:\n%s%s", tt.contains, stdout, stderr)
+			}
+		})
+	}
+}
+
+func TestGenerateIsReproducibleFromSeed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "world.json")
+
+	code, _, stderr := runMain("generate", "-seed", "42", "-warehouses", "3", "-cargo-units", "8", "-output", path)
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, but got %d: %s", code, stderr)
+	}
+
+	world, loadErr := scenario.Load(path)
+	if loadErr != nil {
+		t.Fatalf("Not expected error when loading generated scenario, error: %v", loadErr)
+	}
+	if len(world.Warehouses) != 3 || len(world.CargoUnits) != 8 {
+		t.Errorf("Expected 3 warehouses and 8 cargo units, but got %d and %d", len(world.Warehouses), len(world.CargoUnits))
+	}
+
+	encoded, encodeErr := scenario.Encode(world, scenario.FormatJSON)
+	if encodeErr != nil {
+		t.Fatalf("Not expected error when encoding scenario, error: %v", encodeErr)
+	}
+
+	code, stdout, stderr := runMain("generate", "-seed", "42", "-warehouses", "3", "-cargo-units", "8", "-format", "json")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, but got %d: %s", code, stderr)
+	}
+	if stdout != string(encoded) {
+		t.Errorf("Expected the same world for the same seed")
+	}
+}
+
+func TestReport(t *testing.T) {
+	srv := fakeserver.New()
+	listenFakeServer(t, srv)
+
+	for _, unitID := range []int64{1, 2, 3} {
+		_, _ = srv.UnitReachedWarehouse(context.Background(), &logistics_v1.UnitReachedWarehouseRequest{
+			Announcement: &logistics_v1.WarehouseAnnouncement{CargoUnitId: unitID, WarehouseId: 7 + unitID%2},
+		})
+	}
+
+	code, stdout, stderr := runMain("report", "-host", "bufnet", "-port", "0", "-format", "json")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, but got %d: %s", code, stderr)
+	}
+
+	var metricsReport struct {
+		DeliveryUnitsNumber string `json:"deliveryUnitsNumber"`
+	}
+	if unmarshalErr := json.Unmarshal([]byte(stdout), &metricsReport); unmarshalErr != nil {
+		t.Fatalf("Not expected error when decoding report, error: %v", unmarshalErr)
+	}
+	if metricsReport.DeliveryUnitsNumber != "3" {
+		t.Errorf("Expected 3 delivery units, but got %s", metricsReport.DeliveryUnitsNumber)
+	}
+
+	code, stdout, stderr = runMain("report", "-host", "bufnet", "-port", "0", "-format", "csv")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, but got %d: %s", code, stderr)
+	}
+	for _, line := range []string{"Warehouses,7,Received,1", "Warehouses,8,Received,2"} {
+		if !strings.Contains(stdout, line) {
+			t.Errorf("Expected CSV report to contain %q, but got:\n%s", line, stdout)
+		}
+	}
+}
+
+func TestPing(t *testing.T) {
+	code, _, stderr := runMain("ping", "-port", "1", "-connect-timeout", "50ms")
+	if code != 1 {
+		t.Errorf("Expected exit code 1 when API is down, but got %d", code)
+	}
+	if !strings.Contains(stderr, "failed to connect to API") {
+		t.Errorf("Expected connection error, but got: %s", stderr)
+	}
+
+	srv := fakeserver.New()
+	listenFakeServer(t, srv)
+
+	code, stdout, stderr := runMain("ping", "-host", "bufnet", "-port", "0")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, but got %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "bufnet:0 is up") {
+		t.Errorf("Expected ping to report API is up, but got: %s", stdout)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	code, _, stderr := runMain("replay")
+	if code != 2 || !strings.Contains(stderr, "-input is required") {
+		t.Errorf("Expected usage error without -input, but got exit code %d: %s", code, stderr)
+	}
+
+	path := filepath.Join(t.TempDir(), "run.jsonl")
+	requestRecorder, createErr := recorder.Create(path)
+	if createErr != nil {
+		t.Fatalf("Not expected error when creating recording, error: %v", createErr)
+	}
+	requestRecorder.Record(logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName, &logistics_v1.MoveUnitRequest{CargoUnitId: 4, Sequence: 1})
+	requestRecorder.Record(logistics_v1.LogisticsEngineAPI_UnitReachedWarehouse_FullMethodName, &logistics_v1.UnitReachedWarehouseRequest{
+		Announcement: &logistics_v1.WarehouseAnnouncement{CargoUnitId: 4, WarehouseId: 2},
+		Sequence:     2,
+	})
+	if closeErr := requestRecorder.Close(); closeErr != nil {
+		t.Fatalf("Not expected error when closing recording, error: %v", closeErr)
+	}
+
+	srv := fakeserver.New()
+	listenFakeServer(t, srv)
+
+	code, stdout, stderr := runMain("replay", "-host", "bufnet", "-port", "0", "-input", path, "-pacing", "max")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, but got %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Replayed 2 requests") || !strings.Contains(stdout, "0 failed") {
+		t.Errorf("Expected replay summary of 2 requests, but got: %s", stdout)
+	}
+	if len(srv.Moves()) != 1 || len(srv.Reached()) != 1 {
+		t.Errorf("Expected 1 move and 1 announcement replayed, but got %d and %d", len(srv.Moves()), len(srv.Reached()))
+	}
+}
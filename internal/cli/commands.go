@@ -0,0 +1,258 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/app"
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/grpc_client"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/config"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/scenario"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/operator"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/recorder"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/report"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// dialOptions added to every API connection of the commands, tests use them to connect to an in-memory server
+var dialOptions []grpc_client.Option
+
+func runCommand(args []string, _, stderr io.Writer) error {
+	cfg := &config.ClientAppConfig{}
+	cfg.LoadFromEnv()
+
+	fs := newFlagSet("run", "Run the simulation: move every cargo unit to its warehouse and print the run report.", stderr)
+	cfg.RegisterFlags(fs)
+	if parseErr := parseFlags(fs, args); parseErr != nil {
+		return parseErr
+	}
+
+	return app.Start(cfg)
+}
+
+func generateCommand(args []string, stdout, stderr io.Writer) error {
+	cfg := &config.ClientAppConfig{}
+	cfg.LoadFromEnv()
+
+	var output, format string
+	fs := newFlagSet("generate", "Generate a world the way run does for the same seed and counts, and write it as a scenario.", stderr)
+	cfg.RegisterWorldFlags(fs)
+	fs.StringVar(&output, "output", "", "scenario file to write, empty writes to stdout")
+	fs.StringVar(&format, "format", "", "scenario format: yaml or json, empty takes it from the output extension or yaml for stdout")
+	if parseErr := parseFlags(fs, args); parseErr != nil {
+		return parseErr
+	}
+
+	scenarioFormat := scenario.Format(format)
+	if len(format) == 0 {
+		scenarioFormat = scenario.FormatYAML
+		if len(output) > 0 {
+			pathFormat, formatErr := scenario.FormatFromPath(output)
+			if formatErr != nil {
+				return formatErr
+			}
+			scenarioFormat = pathFormat
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(cfg.Seed))
+	worldOperator := operator.New(rnd)
+	if populationErr := app.PopulateWorld(worldOperator, rnd, cfg); populationErr != nil {
+		return populationErr
+	}
+
+	world, scenarioErr := worldOperator.Scenario()
+	if scenarioErr != nil {
+		return scenarioErr
+	}
+	world.Name = fmt.Sprintf("seed %d", cfg.Seed)
+
+	data, encodeErr := scenario.Encode(world, scenarioFormat)
+	if encodeErr != nil {
+		return encodeErr
+	}
+
+	return writeOutput(output, stdout, func(w io.Writer) error {
+		_, writeErr := w.Write(data)
+		return writeErr
+	})
+}
+
+func reportCommand(args []string, stdout, stderr io.Writer) error {
+	cfg := &config.ClientAppConfig{}
+	cfg.LoadFromEnv()
+
+	var output string
+	format := string(report.FormatASCII)
+	fs := newFlagSet("report", "Print MetricsReport with deliveries API counted so far.", stderr)
+	cfg.RegisterConnectionFlags(fs)
+	fs.StringVar(&format, "format", format, "output format: ascii, json, csv or markdown")
+	fs.StringVar(&output, "output", "", "file to write the report to, empty writes to stdout")
+	if parseErr := parseFlags(fs, args); parseErr != nil {
+		return parseErr
+	}
+
+	reportFormat := report.Format(format)
+	if _, rendererErr := report.NewRenderer(reportFormat); rendererErr != nil {
+		return rendererErr
+	}
+
+	metricsReport, _, reportErr := fetchMetricsReport(cfg)
+	if reportErr != nil {
+		return reportErr
+	}
+
+	return writeOutput(output, stdout, func(w io.Writer) error {
+		if reportFormat == report.FormatJSON {
+			data, marshalErr := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(metricsReport)
+			if marshalErr != nil {
+				return marshalErr
+			}
+
+			_, writeErr := fmt.Fprintf(w, "%s\n", data)
+			return writeErr
+		}
+
+		return report.WriteTables(w, reportFormat, "Metrics report", metricsReportTables(metricsReport))
+	})
+}
+
+func replayCommand(args []string, stdout, stderr io.Writer) error {
+	cfg := &config.ClientAppConfig{}
+	cfg.LoadFromEnv()
+
+	var input string
+	pacing := string(recorder.PacingOriginal)
+	fs := newFlagSet("replay", "Resend requests of a run recorded with run -record to API, so the same traffic hits it again.", stderr)
+	cfg.RegisterConnectionFlags(fs)
+	fs.StringVar(&input, "input", "", "JSONL recording of a run to resend")
+	fs.StringVar(&pacing, "pacing", pacing, "original keeps delays between requests of the recording, max sends them as fast as possible")
+	if parseErr := parseFlags(fs, args); parseErr != nil {
+		return parseErr
+	}
+
+	if len(input) == 0 {
+		fmt.Fprintln(stderr, "-input is required")
+		fs.Usage()
+
+		return errUsage
+	}
+
+	replayPacing, pacingErr := recorder.ParsePacing(pacing)
+	if pacingErr != nil {
+		return pacingErr
+	}
+
+	entries, loadErr := recorder.Load(input)
+	if loadErr != nil {
+		return loadErr
+	}
+
+	// Every call of the recorded run is in the recording once however many attempts it took,
+	// so calls are retried by the retry policy like they were in the run
+	lc, clientErr := app.NewClient(cfg, dialOptions...)
+	if clientErr != nil {
+		return clientErr
+	}
+	if connErr := app.Connect(context.Background(), lc, cfg); connErr != nil {
+		return connErr
+	}
+	defer lc.Disconnect()
+
+	started := time.Now()
+	result, replayErr := recorder.Replay(context.Background(), lc, entries, replayPacing)
+	if replayErr != nil {
+		return replayErr
+	}
+
+	_, writeErr := fmt.Fprintf(stdout, "Replayed %d requests in %s, %d failed\n", result.Sent, time.Since(started).Round(time.Millisecond), result.Failed)
+
+	return writeErr
+}
+
+func pingCommand(args []string, stdout, stderr io.Writer) error {
+	cfg := &config.ClientAppConfig{}
+	cfg.LoadFromEnv()
+
+	fs := newFlagSet("ping", "Connect to API and call MetricsReport to check that it answers.", stderr)
+	cfg.RegisterConnectionFlags(fs)
+	if parseErr := parseFlags(fs, args); parseErr != nil {
+		return parseErr
+	}
+
+	_, latency, reportErr := fetchMetricsReport(cfg)
+	if reportErr != nil {
+		return reportErr
+	}
+
+	_, writeErr := fmt.Fprintf(stdout, "API %s is up, MetricsReport answered in %s\n", cfg.GetCombinedAddress(), latency.Round(time.Microsecond))
+
+	return writeErr
+}
+
+// fetchMetricsReport from API of cfg over a new connection, latency is the time the call took
+func fetchMetricsReport(cfg *config.ClientAppConfig) (*logistics_v1.MetricsReportResponse, time.Duration, error) {
+	lc, clientErr := app.NewClient(cfg, dialOptions...)
+	if clientErr != nil {
+		return nil, 0, clientErr
+	}
+	if connErr := app.Connect(context.Background(), lc, cfg); connErr != nil {
+		return nil, 0, connErr
+	}
+	defer lc.Disconnect()
+
+	started := time.Now()
+	metricsReport, reportErr := lc.MetricsReport(context.Background())
+	if reportErr != nil {
+		return nil, 0, fmt.Errorf("failed to call MetricsReport: %w", reportErr)
+	}
+
+	return metricsReport, time.Since(started), nil
+}
+
+func metricsReportTables(metricsReport *logistics_v1.MetricsReportResponse) []report.Table {
+	summary := report.Table{
+		Title:   "Summary",
+		Headers: []string{"Name", "Value"},
+		Rows: [][]string{
+			{"Delivery units", strconv.FormatInt(metricsReport.GetDeliveryUnitsNumber(), 10)},
+			{"Units reached destination", strconv.Itoa(len(metricsReport.GetDeliveryUnitsReachedDestination()))},
+			{"Warehouses received supplies", strconv.Itoa(len(metricsReport.GetWarehousesReceivedSuppliesList()))},
+		},
+	}
+
+	warehouses := report.Table{Title: "Warehouses", Headers: []string{"Warehouse", "Received"}}
+	for _, warehouse := range metricsReport.GetDeliveryUnitsEachWarehouseReceivedTotalNumber() {
+		warehouses.Rows = append(warehouses.Rows, []string{
+			strconv.FormatInt(warehouse.GetWarehouseId(), 10),
+			strconv.FormatInt(warehouse.GetDeliveryUnitsNumber(), 10),
+		})
+	}
+
+	return []report.Table{summary, warehouses}
+}
+
+// writeOutput to the file, an empty path writes to stdout
+func writeOutput(path string, stdout io.Writer, write func(w io.Writer) error) error {
+	if len(path) == 0 {
+		return write(stdout)
+	}
+
+	file, createErr := os.Create(path)
+	if createErr != nil {
+		return createErr
+	}
+
+	if writeErr := write(file); writeErr != nil {
+		_ = file.Close()
+		return writeErr
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,311 @@
+package fakeserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/metricsreport"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+const bufSize = 1024 * 1024
+
+// ErrorInjector decides if a call of method with req must fail with err. When record is true the request is
+// recorded although the call fails, which simulates a response lost after API already handled the request.
+// Moves of StreamMoveUnits are checked one by one with LogisticsEngineAPI_StreamMoveUnits_FullMethodName.
+type ErrorInjector func(method string, req proto.Message) (record bool, err error)
+
+// Server is in-memory logistics_v1.LogisticsEngineAPIServer, it records every received request
+// and computes MetricsReport from them
+type Server struct {
+	mu         sync.Mutex
+	moves      []*logistics_v1.MoveUnitRequest
+	reached    []*logistics_v1.UnitReachedWarehouseRequest
+	pickUps    []*logistics_v1.PickedUpRequest
+	deliveries []*logistics_v1.DeliveredRequest
+	orders     []*logistics_v1.CreateOrderRequest
+
+	injector ErrorInjector
+	latency  time.Duration
+
+	deduplicate bool
+	handledKeys map[string]bool
+}
+
+// New fake server instance
+func New() *Server {
+	return &Server{handledKeys: make(map[string]bool)}
+}
+
+// SetDeduplication makes the server acknowledge requests with an already recorded idempotency key
+// without recording them again
+func (s *Server) SetDeduplication(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deduplicate = enabled
+}
+
+// SetErrorInjector used for every following call, nil disables injection.
+// The injector is called while the server is locked and must not call the server back.
+func (s *Server) SetErrorInjector(injector ErrorInjector) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.injector = injector
+}
+
+// SetLatency added to every following call (and every batch of a stream) before it is handled
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = latency
+}
+
+// Listen serves the server on in-memory bufconn listener with opts, e.g. TLS credentials.
+// Returned dial option connects a client to it, stop shuts the server down.
+func (s *Server) Listen(opts ...grpc.ServerOption) (dialOption grpc.DialOption, stop func()) {
+	listener := bufconn.Listen(bufSize)
+	grpcServer := grpc.NewServer(opts...)
+	logistics_v1.RegisterLogisticsEngineAPIServer(grpcServer, s)
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+
+	dialOption = grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	})
+
+	return dialOption, grpcServer.Stop
+}
+
+// ListenHTTP serves the server behind the HTTP/JSON gateway of API on a local port with mux opts.
+// Returned addr is host:port of the gateway, stop shuts it down.
+func (s *Server) ListenHTTP(opts ...runtime.ServeMuxOption) (addr string, stop func()) {
+	mux := runtime.NewServeMux(opts...)
+	if registerErr := logistics_v1.RegisterLogisticsEngineAPIHandlerServer(context.Background(), mux, s); registerErr != nil {
+		panic(registerErr)
+	}
+
+	httpServer := httptest.NewServer(mux)
+
+	return httpServer.Listener.Addr().String(), httpServer.Close
+}
+
+// Moves received by MoveUnit and StreamMoveUnits in order of arrival
+func (s *Server) Moves() []*logistics_v1.MoveUnitRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*logistics_v1.MoveUnitRequest(nil), s.moves...)
+}
+
+// Reached announcements received by UnitReachedWarehouse in order of arrival
+func (s *Server) Reached() []*logistics_v1.UnitReachedWarehouseRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*logistics_v1.UnitReachedWarehouseRequest(nil), s.reached...)
+}
+
+// PickUps received by PickedUp in order of arrival
+func (s *Server) PickUps() []*logistics_v1.PickedUpRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*logistics_v1.PickedUpRequest(nil), s.pickUps...)
+}
+
+// Deliveries received by Delivered in order of arrival
+func (s *Server) Deliveries() []*logistics_v1.DeliveredRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*logistics_v1.DeliveredRequest(nil), s.deliveries...)
+}
+
+// Orders received by CreateOrder in order of arrival
+func (s *Server) Orders() []*logistics_v1.CreateOrderRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*logistics_v1.CreateOrderRequest(nil), s.orders...)
+}
+
+// MoveUnit records the move
+func (s *Server) MoveUnit(_ context.Context, req *logistics_v1.MoveUnitRequest) (*logistics_v1.DefaultResponse, error) {
+	s.delay()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.handle(logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName, req, func() {
+		s.moves = append(s.moves, req)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &logistics_v1.DefaultResponse{}, nil
+}
+
+// UnitReachedWarehouse records the announcement
+func (s *Server) UnitReachedWarehouse(_ context.Context, req *logistics_v1.UnitReachedWarehouseRequest) (*logistics_v1.DefaultResponse, error) {
+	s.delay()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.handle(logistics_v1.LogisticsEngineAPI_UnitReachedWarehouse_FullMethodName, req, func() {
+		s.reached = append(s.reached, req)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &logistics_v1.DefaultResponse{}, nil
+}
+
+// PickedUp records the pickup event
+func (s *Server) PickedUp(_ context.Context, req *logistics_v1.PickedUpRequest) (*logistics_v1.DefaultResponse, error) {
+	s.delay()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.handle(logistics_v1.LogisticsEngineAPI_PickedUp_FullMethodName, req, func() {
+		s.pickUps = append(s.pickUps, req)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &logistics_v1.DefaultResponse{}, nil
+}
+
+// Delivered records the drop-off event
+func (s *Server) Delivered(_ context.Context, req *logistics_v1.DeliveredRequest) (*logistics_v1.DefaultResponse, error) {
+	s.delay()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.handle(logistics_v1.LogisticsEngineAPI_Delivered_FullMethodName, req, func() {
+		s.deliveries = append(s.deliveries, req)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &logistics_v1.DefaultResponse{}, nil
+}
+
+// CreateOrder records the order
+func (s *Server) CreateOrder(_ context.Context, req *logistics_v1.CreateOrderRequest) (*logistics_v1.DefaultResponse, error) {
+	s.delay()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.handle(logistics_v1.LogisticsEngineAPI_CreateOrder_FullMethodName, req, func() {
+		s.orders = append(s.orders, req)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &logistics_v1.DefaultResponse{}, nil
+}
+
+// MetricsReport computed from recorded announcements
+func (s *Server) MetricsReport(_ context.Context, req *logistics_v1.DefaultRequest) (*logistics_v1.MetricsReportResponse, error) {
+	s.delay()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.handle(logistics_v1.LogisticsEngineAPI_MetricsReport_FullMethodName, req, func() {}); err != nil {
+		return nil, err
+	}
+
+	return metricsreport.Compute(s.reached), nil
+}
+
+// StreamMoveUnits records moves of every batch and acks it with per-unit results
+func (s *Server) StreamMoveUnits(stream logistics_v1.LogisticsEngineAPI_StreamMoveUnitsServer) error {
+	for {
+		batch, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			return nil
+		} else if recvErr != nil {
+			return recvErr
+		}
+
+		s.delay()
+
+		ack := &logistics_v1.MoveUnitsAck{BatchId: batch.BatchId}
+
+		s.mu.Lock()
+		for _, move := range batch.Moves {
+			result := &logistics_v1.MoveUnitResult{CargoUnitId: move.CargoUnitId}
+			if err := s.handle(logistics_v1.LogisticsEngineAPI_StreamMoveUnits_FullMethodName, move, func() {
+				s.moves = append(s.moves, move)
+			}); err != nil {
+				result.Code = int32(status.Code(err))
+				result.Message = status.Convert(err).Message()
+			}
+
+			ack.Results = append(ack.Results, result)
+		}
+		s.mu.Unlock()
+
+		if sendErr := stream.Send(ack); sendErr != nil {
+			return sendErr
+		}
+	}
+}
+
+// handle runs record unless injected error says otherwise or the request is a duplicate, s.mu must be held
+func (s *Server) handle(method string, req proto.Message, record func()) error {
+	key := ""
+	if keyed, ok := req.(interface{ GetIdempotencyKey() string }); ok {
+		key = keyed.GetIdempotencyKey()
+	}
+	if s.deduplicate && len(key) > 0 && s.handledKeys[key] {
+		return nil
+	}
+
+	recordKey := func() {
+		record()
+		if len(key) > 0 {
+			s.handledKeys[key] = true
+		}
+	}
+
+	if s.injector == nil {
+		recordKey()
+		return nil
+	}
+
+	shouldRecord, err := s.injector(method, req)
+	if err == nil || shouldRecord {
+		recordKey()
+	}
+
+	return err
+}
+
+func (s *Server) delay() {
+	s.mu.Lock()
+	latency := s.latency
+	s.mu.Unlock()
+
+	time.Sleep(latency)
+}
@@ -0,0 +1,172 @@
+package fakeserver
+
+import (
+	"context"
+	"testing"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestClient(t *testing.T, srv *Server) logistics_v1.LogisticsEngineAPIClient {
+	t.Helper()
+
+	dialOption, stop := srv.Listen()
+	t.Cleanup(stop)
+
+	conn, dialErr := grpc.DialContext(context.Background(), "bufnet", dialOption, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if dialErr != nil {
+		t.Fatalf("Not expected error when dialing fake server, error: %v", dialErr)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return logistics_v1.NewLogisticsEngineAPIClient(conn)
+}
+
+func reachedRequest(unitID, warehouseID int64) *logistics_v1.UnitReachedWarehouseRequest {
+	return &logistics_v1.UnitReachedWarehouseRequest{
+		Announcement: &logistics_v1.WarehouseAnnouncement{CargoUnitId: unitID, WarehouseId: warehouseID},
+	}
+}
+
+func TestInjectedErrorFailsCallWithoutRecording(t *testing.T) {
+	srv := New()
+	client := newTestClient(t, srv)
+
+	srv.SetErrorInjector(func(method string, _ proto.Message) (bool, error) {
+		if method == logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName {
+			return false, status.Error(codes.Unavailable, "injected")
+		}
+		return false, nil
+	})
+
+	_, moveErr := client.MoveUnit(context.Background(), &logistics_v1.MoveUnitRequest{CargoUnitId: 1})
+	if status.Code(moveErr) != codes.Unavailable {
+		t.Errorf("Expected %s error, but got %v", codes.Unavailable, moveErr)
+	}
+	if len(srv.Moves()) != 0 {
+		t.Errorf("Expected failed move not to be recorded, but got %d moves", len(srv.Moves()))
+	}
+
+	if _, reachErr := client.UnitReachedWarehouse(context.Background(), reachedRequest(1, 0)); reachErr != nil {
+		t.Errorf("Not expected error when calling method without injected error, error: %v", reachErr)
+	}
+
+	srv.SetErrorInjector(nil)
+	if _, moveErr := client.MoveUnit(context.Background(), &logistics_v1.MoveUnitRequest{CargoUnitId: 1}); moveErr != nil {
+		t.Errorf("Not expected error when injection is disabled, error: %v", moveErr)
+	}
+	if len(srv.Moves()) != 1 {
+		t.Errorf("Expected 1 move, but got %d", len(srv.Moves()))
+	}
+}
+
+func TestInjectedErrorWithRecordKeepsRequest(t *testing.T) {
+	srv := New()
+	client := newTestClient(t, srv)
+
+	// Response is lost after API counted the announcement
+	srv.SetErrorInjector(func(string, proto.Message) (bool, error) {
+		return true, status.Error(codes.DeadlineExceeded, "injected")
+	})
+
+	_, reachErr := client.UnitReachedWarehouse(context.Background(), reachedRequest(1, 3))
+	if status.Code(reachErr) != codes.DeadlineExceeded {
+		t.Errorf("Expected %s error, but got %v", codes.DeadlineExceeded, reachErr)
+	}
+
+	srv.SetErrorInjector(nil)
+	report, reportErr := client.MetricsReport(context.Background(), &logistics_v1.DefaultRequest{})
+	if reportErr != nil {
+		t.Fatalf("Not expected error when calling MetricsReport, error: %v", reportErr)
+	}
+	if len(srv.Reached()) != 1 || report.GetDeliveryUnitsNumber() != 1 {
+		t.Errorf("Expected failed announcement to be counted, but got %d announcements and report %v", len(srv.Reached()), report)
+	}
+}
+
+func TestDeduplicationDropsResentRequests(t *testing.T) {
+	srv := New()
+	client := newTestClient(t, srv)
+
+	// Response of the first attempt is lost, so the client sends the same request again
+	srv.SetErrorInjector(func(string, proto.Message) (bool, error) {
+		return true, status.Error(codes.Unavailable, "injected")
+	})
+	move := &logistics_v1.MoveUnitRequest{CargoUnitId: 1, IdempotencyKey: "move-1-1"}
+	if _, moveErr := client.MoveUnit(context.Background(), move); status.Code(moveErr) != codes.Unavailable {
+		t.Errorf("Expected %s error, but got %v", codes.Unavailable, moveErr)
+	}
+
+	srv.SetErrorInjector(nil)
+	srv.SetDeduplication(true)
+	for i := 0; i < 2; i++ {
+		if _, moveErr := client.MoveUnit(context.Background(), move); moveErr != nil {
+			t.Errorf("Not expected error when resending move, error: %v", moveErr)
+		}
+	}
+	if _, moveErr := client.MoveUnit(context.Background(), &logistics_v1.MoveUnitRequest{CargoUnitId: 1, IdempotencyKey: "move-1-2"}); moveErr != nil {
+		t.Errorf("Not expected error when sending next move, error: %v", moveErr)
+	}
+
+	if len(srv.Moves()) != 2 {
+		t.Errorf("Expected resent move to be recorded once, but got %d moves", len(srv.Moves()))
+	}
+
+	srv.SetDeduplication(false)
+	if _, moveErr := client.MoveUnit(context.Background(), move); moveErr != nil {
+		t.Errorf("Not expected error when resending move, error: %v", moveErr)
+	}
+	if len(srv.Moves()) != 3 {
+		t.Errorf("Expected resent move to be recorded without deduplication, but got %d moves", len(srv.Moves()))
+	}
+}
+
+func TestStreamMoveUnitsAcksInjectedErrorsPerMove(t *testing.T) {
+	srv := New()
+	client := newTestClient(t, srv)
+
+	srv.SetErrorInjector(func(method string, req proto.Message) (bool, error) {
+		move := req.(*logistics_v1.MoveUnitRequest)
+		if method == logistics_v1.LogisticsEngineAPI_StreamMoveUnits_FullMethodName && move.GetCargoUnitId() == 2 {
+			return false, status.Error(codes.ResourceExhausted, "injected")
+		}
+		return false, nil
+	})
+
+	stream, streamErr := client.StreamMoveUnits(context.Background())
+	if streamErr != nil {
+		t.Fatalf("Not expected error when opening stream, error: %v", streamErr)
+	}
+
+	batch := &logistics_v1.MoveUnitsBatch{BatchId: 7, Moves: []*logistics_v1.MoveUnitRequest{{CargoUnitId: 1}, {CargoUnitId: 2}}}
+	if sendErr := stream.Send(batch); sendErr != nil {
+		t.Fatalf("Not expected error when sending batch, error: %v", sendErr)
+	}
+	ack, recvErr := stream.Recv()
+	if recvErr != nil {
+		t.Fatalf("Not expected error when receiving ack, error: %v", recvErr)
+	}
+	if closeErr := stream.CloseSend(); closeErr != nil {
+		t.Errorf("Not expected error when closing stream, error: %v", closeErr)
+	}
+
+	if ack.GetBatchId() != 7 || len(ack.GetResults()) != 2 {
+		t.Fatalf("Expected ack of batch 7 with 2 results, but got %v", ack)
+	}
+	if code := codes.Code(ack.GetResults()[0].GetCode()); code != codes.OK {
+		t.Errorf("Expected move of unit 1 to succeed, but got %s", code)
+	}
+	if code := codes.Code(ack.GetResults()[1].GetCode()); code != codes.ResourceExhausted {
+		t.Errorf("Expected move of unit 2 to fail with %s, but got %s", codes.ResourceExhausted, code)
+	}
+
+	moves := srv.Moves()
+	if len(moves) != 1 || moves[0].GetCargoUnitId() != 1 {
+		t.Errorf("Expected only move of unit 1 to be recorded, but got %v", moves)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -21,6 +22,118 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// OrderPriority of an order, higher priority orders are dispatched first
+type OrderPriority int32
+
+const (
+	OrderPriority_ORDER_PRIORITY_UNSPECIFIED OrderPriority = 0
+	OrderPriority_ORDER_PRIORITY_LOW         OrderPriority = 1
+	OrderPriority_ORDER_PRIORITY_NORMAL      OrderPriority = 2
+	OrderPriority_ORDER_PRIORITY_HIGH        OrderPriority = 3
+)
+
+// Enum value maps for OrderPriority.
+var (
+	OrderPriority_name = map[int32]string{
+		0: "ORDER_PRIORITY_UNSPECIFIED",
+		1: "ORDER_PRIORITY_LOW",
+		2: "ORDER_PRIORITY_NORMAL",
+		3: "ORDER_PRIORITY_HIGH",
+	}
+	OrderPriority_value = map[string]int32{
+		"ORDER_PRIORITY_UNSPECIFIED": 0,
+		"ORDER_PRIORITY_LOW":         1,
+		"ORDER_PRIORITY_NORMAL":      2,
+		"ORDER_PRIORITY_HIGH":        3,
+	}
+)
+
+func (x OrderPriority) Enum() *OrderPriority {
+	p := new(OrderPriority)
+	*p = x
+	return p
+}
+
+func (x OrderPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_logistics_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderPriority) Type() protoreflect.EnumType {
+	return &file_api_v1_logistics_proto_enumTypes[0]
+}
+
+func (x OrderPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderPriority.Descriptor instead.
+func (OrderPriority) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{0}
+}
+
+// CargoUnitStatus of a cargo unit
+type CargoUnitStatus int32
+
+const (
+	CargoUnitStatus_CARGO_UNIT_STATUS_UNSPECIFIED CargoUnitStatus = 0
+	CargoUnitStatus_CARGO_UNIT_STATUS_IDLE        CargoUnitStatus = 1
+	CargoUnitStatus_CARGO_UNIT_STATUS_EN_ROUTE    CargoUnitStatus = 2
+	CargoUnitStatus_CARGO_UNIT_STATUS_LOADING     CargoUnitStatus = 3
+	CargoUnitStatus_CARGO_UNIT_STATUS_UNLOADING   CargoUnitStatus = 4
+	CargoUnitStatus_CARGO_UNIT_STATUS_BROKEN      CargoUnitStatus = 5
+)
+
+// Enum value maps for CargoUnitStatus.
+var (
+	CargoUnitStatus_name = map[int32]string{
+		0: "CARGO_UNIT_STATUS_UNSPECIFIED",
+		1: "CARGO_UNIT_STATUS_IDLE",
+		2: "CARGO_UNIT_STATUS_EN_ROUTE",
+		3: "CARGO_UNIT_STATUS_LOADING",
+		4: "CARGO_UNIT_STATUS_UNLOADING",
+		5: "CARGO_UNIT_STATUS_BROKEN",
+	}
+	CargoUnitStatus_value = map[string]int32{
+		"CARGO_UNIT_STATUS_UNSPECIFIED": 0,
+		"CARGO_UNIT_STATUS_IDLE":        1,
+		"CARGO_UNIT_STATUS_EN_ROUTE":    2,
+		"CARGO_UNIT_STATUS_LOADING":     3,
+		"CARGO_UNIT_STATUS_UNLOADING":   4,
+		"CARGO_UNIT_STATUS_BROKEN":      5,
+	}
+)
+
+func (x CargoUnitStatus) Enum() *CargoUnitStatus {
+	p := new(CargoUnitStatus)
+	*p = x
+	return p
+}
+
+func (x CargoUnitStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CargoUnitStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_logistics_proto_enumTypes[1].Descriptor()
+}
+
+func (CargoUnitStatus) Type() protoreflect.EnumType {
+	return &file_api_v1_logistics_proto_enumTypes[1]
+}
+
+func (x CargoUnitStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CargoUnitStatus.Descriptor instead.
+func (CargoUnitStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{1}
+}
+
 // MoveUnitRequest
 type MoveUnitRequest struct {
 	state         protoimpl.MessageState
@@ -29,6 +142,14 @@ type MoveUnitRequest struct {
 
 	CargoUnitId int64     `protobuf:"varint,1,opt,name=cargo_unit_id,json=cargoUnitId,proto3" json:"cargo_unit_id,omitempty"`
 	Location    *Location `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	// idempotency_key is the same for every resend of the same move, so API can drop duplicates
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// sequence numbers requests of the cargo unit starting at 1, without gaps
+	Sequence uint64 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// simulated_time of the tick the unit moved on
+	SimulatedTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=simulated_time,json=simulatedTime,proto3" json:"simulated_time,omitempty"`
+	// state of the unit after the move
+	State *CargoUnitState `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
 }
 
 func (x *MoveUnitRequest) Reset() {
@@ -70,40 +191,636 @@ func (x *MoveUnitRequest) GetCargoUnitId() int64 {
 	return 0
 }
 
-func (x *MoveUnitRequest) GetLocation() *Location {
-	if x != nil {
-		return x.Location
-	}
-	return nil
+func (x *MoveUnitRequest) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *MoveUnitRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *MoveUnitRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *MoveUnitRequest) GetSimulatedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SimulatedTime
+	}
+	return nil
+}
+
+func (x *MoveUnitRequest) GetState() *CargoUnitState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+// MoveUnitsBatch groups moves of many units made during the same tick
+type MoveUnitsBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// batch_id is unique within the stream and is echoed back in MoveUnitsAck
+	BatchId uint64             `protobuf:"varint,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Moves   []*MoveUnitRequest `protobuf:"bytes,2,rep,name=moves,proto3" json:"moves,omitempty"`
+}
+
+func (x *MoveUnitsBatch) Reset() {
+	*x = MoveUnitsBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_logistics_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveUnitsBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveUnitsBatch) ProtoMessage() {}
+
+func (x *MoveUnitsBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_logistics_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveUnitsBatch.ProtoReflect.Descriptor instead.
+func (*MoveUnitsBatch) Descriptor() ([]byte, []int) {
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MoveUnitsBatch) GetBatchId() uint64 {
+	if x != nil {
+		return x.BatchId
+	}
+	return 0
+}
+
+func (x *MoveUnitsBatch) GetMoves() []*MoveUnitRequest {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+// UnitReachedWarehouseRequest contains WarehouseAnnouncement with Location
+type UnitReachedWarehouseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location     *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Announcement *WarehouseAnnouncement `protobuf:"bytes,2,opt,name=announcement,proto3" json:"announcement,omitempty"`
+	// idempotency_key is the same for every resend of the same announcement, so API can drop duplicates
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// sequence continues the sequence of MoveUnitRequest of the same cargo unit
+	Sequence uint64 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// simulated_time of the tick the unit was unloaded on
+	SimulatedTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=simulated_time,json=simulatedTime,proto3" json:"simulated_time,omitempty"`
+}
+
+func (x *UnitReachedWarehouseRequest) Reset() {
+	*x = UnitReachedWarehouseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_logistics_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnitReachedWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnitReachedWarehouseRequest) ProtoMessage() {}
+
+func (x *UnitReachedWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_logistics_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnitReachedWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*UnitReachedWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UnitReachedWarehouseRequest) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *UnitReachedWarehouseRequest) GetAnnouncement() *WarehouseAnnouncement {
+	if x != nil {
+		return x.Announcement
+	}
+	return nil
+}
+
+func (x *UnitReachedWarehouseRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *UnitReachedWarehouseRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *UnitReachedWarehouseRequest) GetSimulatedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SimulatedTime
+	}
+	return nil
+}
+
+// PickedUpRequest is sent once the unit is loaded at the first stop of its shipment
+type PickedUpRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// shipment_id is unique id
+	ShipmentId  int64 `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	CargoUnitId int64 `protobuf:"varint,2,opt,name=cargo_unit_id,json=cargoUnitId,proto3" json:"cargo_unit_id,omitempty"`
+	// warehouse_id of the pickup warehouse
+	WarehouseId int64     `protobuf:"varint,3,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Location    *Location `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	// drop_off_warehouse_ids the goods are carried to, in order
+	DropOffWarehouseIds []int64 `protobuf:"varint,5,rep,packed,name=drop_off_warehouse_ids,json=dropOffWarehouseIds,proto3" json:"drop_off_warehouse_ids,omitempty"`
+	// idempotency_key is the same for every resend of the same event, so API can drop duplicates
+	IdempotencyKey string `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// sequence continues the sequence of MoveUnitRequest of the same cargo unit
+	Sequence uint64 `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// simulated_time of the tick the unit was loaded on
+	SimulatedTime *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=simulated_time,json=simulatedTime,proto3" json:"simulated_time,omitempty"`
+}
+
+func (x *PickedUpRequest) Reset() {
+	*x = PickedUpRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_logistics_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PickedUpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickedUpRequest) ProtoMessage() {}
+
+func (x *PickedUpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_logistics_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickedUpRequest.ProtoReflect.Descriptor instead.
+func (*PickedUpRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PickedUpRequest) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+func (x *PickedUpRequest) GetCargoUnitId() int64 {
+	if x != nil {
+		return x.CargoUnitId
+	}
+	return 0
+}
+
+func (x *PickedUpRequest) GetWarehouseId() int64 {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return 0
+}
+
+func (x *PickedUpRequest) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *PickedUpRequest) GetDropOffWarehouseIds() []int64 {
+	if x != nil {
+		return x.DropOffWarehouseIds
+	}
+	return nil
+}
+
+func (x *PickedUpRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PickedUpRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *PickedUpRequest) GetSimulatedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SimulatedTime
+	}
+	return nil
+}
+
+// DeliveredRequest is sent once the unit is unloaded at a drop-off warehouse of its shipment
+type DeliveredRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// shipment_id is unique id
+	ShipmentId  int64 `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	CargoUnitId int64 `protobuf:"varint,2,opt,name=cargo_unit_id,json=cargoUnitId,proto3" json:"cargo_unit_id,omitempty"`
+	// warehouse_id of the drop-off warehouse
+	WarehouseId int64     `protobuf:"varint,3,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Location    *Location `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	// drop_off is the number of the drop-off within the shipment starting at 1
+	DropOff uint32 `protobuf:"varint,5,opt,name=drop_off,json=dropOff,proto3" json:"drop_off,omitempty"`
+	// final is set on the last drop-off, the shipment is complete
+	Final bool `protobuf:"varint,6,opt,name=final,proto3" json:"final,omitempty"`
+	// idempotency_key is the same for every resend of the same event, so API can drop duplicates
+	IdempotencyKey string `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// sequence continues the sequence of MoveUnitRequest of the same cargo unit
+	Sequence uint64 `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// simulated_time of the tick the unit was unloaded on
+	SimulatedTime *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=simulated_time,json=simulatedTime,proto3" json:"simulated_time,omitempty"`
+}
+
+func (x *DeliveredRequest) Reset() {
+	*x = DeliveredRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_logistics_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveredRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveredRequest) ProtoMessage() {}
+
+func (x *DeliveredRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_logistics_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveredRequest.ProtoReflect.Descriptor instead.
+func (*DeliveredRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeliveredRequest) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+func (x *DeliveredRequest) GetCargoUnitId() int64 {
+	if x != nil {
+		return x.CargoUnitId
+	}
+	return 0
+}
+
+func (x *DeliveredRequest) GetWarehouseId() int64 {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return 0
+}
+
+func (x *DeliveredRequest) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *DeliveredRequest) GetDropOff() uint32 {
+	if x != nil {
+		return x.DropOff
+	}
+	return 0
+}
+
+func (x *DeliveredRequest) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+func (x *DeliveredRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *DeliveredRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *DeliveredRequest) GetSimulatedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SimulatedTime
+	}
+	return nil
+}
+
+// CreateOrderRequest is sent once an order arrives, its shipment later carries the same id
+type CreateOrderRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// order_id is unique id, PickedUpRequest and DeliveredRequest of the order carry it as shipment_id
+	OrderId int64 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// origin_warehouse_id goods are picked up at
+	OriginWarehouseId int64 `protobuf:"varint,2,opt,name=origin_warehouse_id,json=originWarehouseId,proto3" json:"origin_warehouse_id,omitempty"`
+	// destination_warehouse_id goods are dropped at
+	DestinationWarehouseId int64 `protobuf:"varint,3,opt,name=destination_warehouse_id,json=destinationWarehouseId,proto3" json:"destination_warehouse_id,omitempty"`
+	// weight of goods in kilograms
+	Weight float64 `protobuf:"fixed64,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	// volume of goods in cubic meters
+	Volume   float64       `protobuf:"fixed64,5,opt,name=volume,proto3" json:"volume,omitempty"`
+	Priority OrderPriority `protobuf:"varint,6,opt,name=priority,proto3,enum=logistics.api.v1.OrderPriority" json:"priority,omitempty"`
+	// deadline in simulated time goods must be delivered by
+	Deadline *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// idempotency_key is the same for every resend of the same order, so API can drop duplicates
+	IdempotencyKey string `protobuf:"bytes,8,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// simulated_time of the tick the order arrived on
+	SimulatedTime *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=simulated_time,json=simulatedTime,proto3" json:"simulated_time,omitempty"`
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_logistics_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_logistics_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateOrderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetOriginWarehouseId() int64 {
+	if x != nil {
+		return x.OriginWarehouseId
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetDestinationWarehouseId() int64 {
+	if x != nil {
+		return x.DestinationWarehouseId
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetPriority() OrderPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return OrderPriority_ORDER_PRIORITY_UNSPECIFIED
+}
+
+func (x *CreateOrderRequest) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetSimulatedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SimulatedTime
+	}
+	return nil
+}
+
+// DefaultResponse
+type DefaultResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DefaultResponse) Reset() {
+	*x = DefaultResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_logistics_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DefaultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DefaultResponse) ProtoMessage() {}
+
+func (x *DefaultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_logistics_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DefaultResponse.ProtoReflect.Descriptor instead.
+func (*DefaultResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{6}
+}
+
+// DefaultRequest
+type DefaultRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DefaultRequest) Reset() {
+	*x = DefaultRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_logistics_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DefaultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DefaultRequest) ProtoMessage() {}
+
+func (x *DefaultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_logistics_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DefaultRequest.ProtoReflect.Descriptor instead.
+func (*DefaultRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{7}
 }
 
-// UnitReachedWarehouseRequest contains WarehouseAnnouncement with Location
-type UnitReachedWarehouseRequest struct {
+// MoveUnitsAck acknowledges the MoveUnitsBatch with the same batch_id
+type MoveUnitsAck struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Location     *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
-	Announcement *WarehouseAnnouncement `protobuf:"bytes,2,opt,name=announcement,proto3" json:"announcement,omitempty"`
+	BatchId uint64 `protobuf:"varint,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	// results contains one entry per move of the batch, in the same order
+	Results []*MoveUnitResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *UnitReachedWarehouseRequest) Reset() {
-	*x = UnitReachedWarehouseRequest{}
+func (x *MoveUnitsAck) Reset() {
+	*x = MoveUnitsAck{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_logistics_proto_msgTypes[1]
+		mi := &file_api_v1_logistics_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UnitReachedWarehouseRequest) String() string {
+func (x *MoveUnitsAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UnitReachedWarehouseRequest) ProtoMessage() {}
+func (*MoveUnitsAck) ProtoMessage() {}
 
-func (x *UnitReachedWarehouseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_logistics_proto_msgTypes[1]
+func (x *MoveUnitsAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_logistics_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -114,49 +831,54 @@ func (x *UnitReachedWarehouseRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UnitReachedWarehouseRequest.ProtoReflect.Descriptor instead.
-func (*UnitReachedWarehouseRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_logistics_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use MoveUnitsAck.ProtoReflect.Descriptor instead.
+func (*MoveUnitsAck) Descriptor() ([]byte, []int) {
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *UnitReachedWarehouseRequest) GetLocation() *Location {
+func (x *MoveUnitsAck) GetBatchId() uint64 {
 	if x != nil {
-		return x.Location
+		return x.BatchId
 	}
-	return nil
+	return 0
 }
 
-func (x *UnitReachedWarehouseRequest) GetAnnouncement() *WarehouseAnnouncement {
+func (x *MoveUnitsAck) GetResults() []*MoveUnitResult {
 	if x != nil {
-		return x.Announcement
+		return x.Results
 	}
 	return nil
 }
 
-// DefaultResponse
-type DefaultResponse struct {
+// MoveUnitResult of a single move in a batch
+type MoveUnitResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	CargoUnitId int64 `protobuf:"varint,1,opt,name=cargo_unit_id,json=cargoUnitId,proto3" json:"cargo_unit_id,omitempty"`
+	// code is a gRPC status code, 0 (OK) means the move was accepted
+	Code    int32  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (x *DefaultResponse) Reset() {
-	*x = DefaultResponse{}
+func (x *MoveUnitResult) Reset() {
+	*x = MoveUnitResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_logistics_proto_msgTypes[2]
+		mi := &file_api_v1_logistics_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DefaultResponse) String() string {
+func (x *MoveUnitResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DefaultResponse) ProtoMessage() {}
+func (*MoveUnitResult) ProtoMessage() {}
 
-func (x *DefaultResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_logistics_proto_msgTypes[2]
+func (x *MoveUnitResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_logistics_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -167,48 +889,30 @@ func (x *DefaultResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DefaultResponse.ProtoReflect.Descriptor instead.
-func (*DefaultResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_logistics_proto_rawDescGZIP(), []int{2}
-}
-
-// DefaultRequest
-type DefaultRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+// Deprecated: Use MoveUnitResult.ProtoReflect.Descriptor instead.
+func (*MoveUnitResult) Descriptor() ([]byte, []int) {
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *DefaultRequest) Reset() {
-	*x = DefaultRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_logistics_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *MoveUnitResult) GetCargoUnitId() int64 {
+	if x != nil {
+		return x.CargoUnitId
 	}
+	return 0
 }
 
-func (x *DefaultRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*DefaultRequest) ProtoMessage() {}
-
-func (x *DefaultRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_logistics_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *MoveUnitResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use DefaultRequest.ProtoReflect.Descriptor instead.
-func (*DefaultRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_logistics_proto_rawDescGZIP(), []int{3}
+func (x *MoveUnitResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
 }
 
 type DeliveryUnitsWarehouseReceivedTotalNumber struct {
@@ -223,7 +927,7 @@ type DeliveryUnitsWarehouseReceivedTotalNumber struct {
 func (x *DeliveryUnitsWarehouseReceivedTotalNumber) Reset() {
 	*x = DeliveryUnitsWarehouseReceivedTotalNumber{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_logistics_proto_msgTypes[4]
+		mi := &file_api_v1_logistics_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -236,7 +940,7 @@ func (x *DeliveryUnitsWarehouseReceivedTotalNumber) String() string {
 func (*DeliveryUnitsWarehouseReceivedTotalNumber) ProtoMessage() {}
 
 func (x *DeliveryUnitsWarehouseReceivedTotalNumber) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_logistics_proto_msgTypes[4]
+	mi := &file_api_v1_logistics_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,7 +953,7 @@ func (x *DeliveryUnitsWarehouseReceivedTotalNumber) ProtoReflect() protoreflect.
 
 // Deprecated: Use DeliveryUnitsWarehouseReceivedTotalNumber.ProtoReflect.Descriptor instead.
 func (*DeliveryUnitsWarehouseReceivedTotalNumber) Descriptor() ([]byte, []int) {
-	return file_api_v1_logistics_proto_rawDescGZIP(), []int{4}
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeliveryUnitsWarehouseReceivedTotalNumber) GetWarehouseId() int64 {
@@ -281,7 +985,7 @@ type MetricsReportResponse struct {
 func (x *MetricsReportResponse) Reset() {
 	*x = MetricsReportResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_logistics_proto_msgTypes[5]
+		mi := &file_api_v1_logistics_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -294,7 +998,7 @@ func (x *MetricsReportResponse) String() string {
 func (*MetricsReportResponse) ProtoMessage() {}
 
 func (x *MetricsReportResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_logistics_proto_msgTypes[5]
+	mi := &file_api_v1_logistics_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -307,7 +1011,7 @@ func (x *MetricsReportResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsReportResponse.ProtoReflect.Descriptor instead.
 func (*MetricsReportResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_logistics_proto_rawDescGZIP(), []int{5}
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *MetricsReportResponse) GetDeliveryUnitsNumber() int64 {
@@ -350,12 +1054,16 @@ type WarehouseAnnouncement struct {
 	WarehouseId int64 `protobuf:"varint,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
 	// the message contains information about the announcement
 	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// queue_length is the number of units that were waiting for a dock when the unit arrived
+	QueueLength uint32 `protobuf:"varint,4,opt,name=queue_length,json=queueLength,proto3" json:"queue_length,omitempty"`
+	// wait_ticks is how many simulation ticks the unit waited in the queue before it was docked
+	WaitTicks uint64 `protobuf:"varint,5,opt,name=wait_ticks,json=waitTicks,proto3" json:"wait_ticks,omitempty"`
 }
 
 func (x *WarehouseAnnouncement) Reset() {
 	*x = WarehouseAnnouncement{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_logistics_proto_msgTypes[6]
+		mi := &file_api_v1_logistics_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -368,7 +1076,7 @@ func (x *WarehouseAnnouncement) String() string {
 func (*WarehouseAnnouncement) ProtoMessage() {}
 
 func (x *WarehouseAnnouncement) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_logistics_proto_msgTypes[6]
+	mi := &file_api_v1_logistics_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -381,7 +1089,7 @@ func (x *WarehouseAnnouncement) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WarehouseAnnouncement.ProtoReflect.Descriptor instead.
 func (*WarehouseAnnouncement) Descriptor() ([]byte, []int) {
-	return file_api_v1_logistics_proto_rawDescGZIP(), []int{6}
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *WarehouseAnnouncement) GetCargoUnitId() int64 {
@@ -405,6 +1113,122 @@ func (x *WarehouseAnnouncement) GetMessage() string {
 	return ""
 }
 
+func (x *WarehouseAnnouncement) GetQueueLength() uint32 {
+	if x != nil {
+		return x.QueueLength
+	}
+	return 0
+}
+
+func (x *WarehouseAnnouncement) GetWaitTicks() uint64 {
+	if x != nil {
+		return x.WaitTicks
+	}
+	return 0
+}
+
+// CargoUnitState of a cargo unit, its capacity and what it carries
+type CargoUnitState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// max_weight the unit can carry in kilograms
+	MaxWeight float64 `protobuf:"fixed64,1,opt,name=max_weight,json=maxWeight,proto3" json:"max_weight,omitempty"`
+	// max_volume the unit can carry in cubic meters
+	MaxVolume float64 `protobuf:"fixed64,2,opt,name=max_volume,json=maxVolume,proto3" json:"max_volume,omitempty"`
+	// load_weight of goods carried in kilograms
+	LoadWeight float64 `protobuf:"fixed64,3,opt,name=load_weight,json=loadWeight,proto3" json:"load_weight,omitempty"`
+	// load_volume of goods carried in cubic meters
+	LoadVolume float64 `protobuf:"fixed64,4,opt,name=load_volume,json=loadVolume,proto3" json:"load_volume,omitempty"`
+	// speed in grid steps per tick
+	Speed uint32 `protobuf:"varint,5,opt,name=speed,proto3" json:"speed,omitempty"`
+	// fuel left in percent of a full tank or battery
+	Fuel   float64         `protobuf:"fixed64,6,opt,name=fuel,proto3" json:"fuel,omitempty"`
+	Status CargoUnitStatus `protobuf:"varint,7,opt,name=status,proto3,enum=logistics.api.v1.CargoUnitStatus" json:"status,omitempty"`
+}
+
+func (x *CargoUnitState) Reset() {
+	*x = CargoUnitState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_logistics_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CargoUnitState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CargoUnitState) ProtoMessage() {}
+
+func (x *CargoUnitState) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_logistics_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CargoUnitState.ProtoReflect.Descriptor instead.
+func (*CargoUnitState) Descriptor() ([]byte, []int) {
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CargoUnitState) GetMaxWeight() float64 {
+	if x != nil {
+		return x.MaxWeight
+	}
+	return 0
+}
+
+func (x *CargoUnitState) GetMaxVolume() float64 {
+	if x != nil {
+		return x.MaxVolume
+	}
+	return 0
+}
+
+func (x *CargoUnitState) GetLoadWeight() float64 {
+	if x != nil {
+		return x.LoadWeight
+	}
+	return 0
+}
+
+func (x *CargoUnitState) GetLoadVolume() float64 {
+	if x != nil {
+		return x.LoadVolume
+	}
+	return 0
+}
+
+func (x *CargoUnitState) GetSpeed() uint32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *CargoUnitState) GetFuel() float64 {
+	if x != nil {
+		return x.Fuel
+	}
+	return 0
+}
+
+func (x *CargoUnitState) GetStatus() CargoUnitStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CargoUnitStatus_CARGO_UNIT_STATUS_UNSPECIFIED
+}
+
 // Location where entity now located in X,Y Axis
 type Location struct {
 	state         protoimpl.MessageState
@@ -418,7 +1242,7 @@ type Location struct {
 func (x *Location) Reset() {
 	*x = Location{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_logistics_proto_msgTypes[7]
+		mi := &file_api_v1_logistics_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -431,7 +1255,7 @@ func (x *Location) String() string {
 func (*Location) ProtoMessage() {}
 
 func (x *Location) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_logistics_proto_msgTypes[7]
+	mi := &file_api_v1_logistics_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,7 +1268,7 @@ func (x *Location) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Location.ProtoReflect.Descriptor instead.
 func (*Location) Descriptor() ([]byte, []int) {
-	return file_api_v1_logistics_proto_rawDescGZIP(), []int{7}
+	return file_api_v1_logistics_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Location) GetLatitude() uint32 {
@@ -468,72 +1292,227 @@ var file_api_v1_logistics_proto_rawDesc = []byte{
 	0x63, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74,
 	0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x6d, 0x0a, 0x0f, 0x4d, 0x6f, 0x76, 0x65,
-	0x55, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x63,
-	0x61, 0x72, 0x67, 0x6f, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0b, 0x63, 0x61, 0x72, 0x67, 0x6f, 0x55, 0x6e, 0x69, 0x74, 0x49, 0x64, 0x12,
-	0x36, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xad, 0x02, 0x0a, 0x0f, 0x4d, 0x6f,
+	0x76, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a,
+	0x0d, 0x63, 0x61, 0x72, 0x67, 0x6f, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x61, 0x72, 0x67, 0x6f, 0x55, 0x6e, 0x69, 0x74, 0x49,
+	0x64, 0x12, 0x36, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65,
+	0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b,
+	0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x41,
+	0x0a, 0x0e, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0d, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x36, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x67, 0x6f, 0x55, 0x6e, 0x69, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x64, 0x0a, 0x0e, 0x4d, 0x6f, 0x76,
+	0x65, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x37, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x55, 0x6e, 0x69,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x73, 0x22,
+	0xaa, 0x02, 0x0a, 0x1b, 0x55, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x61, 0x63, 0x68, 0x65, 0x64, 0x57,
+	0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x36, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x1a, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70,
 	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c,
-	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xa2, 0x01, 0x0a, 0x1b, 0x55, 0x6e, 0x69, 0x74,
-	0x52, 0x65, 0x61, 0x63, 0x68, 0x65, 0x64, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6c, 0x6f, 0x67, 0x69,
-	0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x4b, 0x0a, 0x0c, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63,
-	0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75,
-	0x73, 0x65, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0c,
-	0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x11, 0x0a, 0x0f,
-	0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x10, 0x0a, 0x0e, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x82, 0x01, 0x0a, 0x29, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x55, 0x6e,
-	0x69, 0x74, 0x73, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x52, 0x65, 0x63, 0x65,
-	0x69, 0x76, 0x65, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
-	0x21, 0x0a, 0x0c, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65,
-	0x49, 0x64, 0x12, 0x32, 0x0a, 0x15, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x75,
-	0x6e, 0x69, 0x74, 0x73, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x13, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x55, 0x6e, 0x69, 0x74, 0x73,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x8d, 0x03, 0x0a, 0x15, 0x4d, 0x65, 0x74, 0x72, 0x69,
-	0x63, 0x73, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x32, 0x0a, 0x15, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x75, 0x6e, 0x69,
-	0x74, 0x73, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x13, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x4e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x12, 0x49, 0x0a, 0x21, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73,
-	0x65, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x73, 0x75, 0x70, 0x70,
-	0x6c, 0x69, 0x65, 0x73, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52,
-	0x1e, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69,
-	0x76, 0x65, 0x64, 0x53, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12,
-	0x4b, 0x0a, 0x22, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x75, 0x6e, 0x69, 0x74,
-	0x73, 0x5f, 0x72, 0x65, 0x61, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x03, 0x28, 0x03, 0x52, 0x1f, 0x64, 0x65, 0x6c,
-	0x69, 0x76, 0x65, 0x72, 0x79, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x65, 0x61, 0x63, 0x68, 0x65,
-	0x64, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0xa7, 0x01, 0x0a,
-	0x33, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x5f,
-	0x65, 0x61, 0x63, 0x68, 0x5f, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x72,
-	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x6c, 0x6f, 0x67,
-	0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
-	0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x57, 0x61, 0x72, 0x65, 0x68,
-	0x6f, 0x75, 0x73, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x54, 0x6f, 0x74, 0x61,
-	0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x2d, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72,
-	0x79, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x45, 0x61, 0x63, 0x68, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4b, 0x0a, 0x0c, 0x61, 0x6e, 0x6e, 0x6f, 0x75,
+	0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e,
+	0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e,
+	0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0c, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69,
+	0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x73, 0x69, 0x6d,
+	0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x73,
+	0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xee, 0x02, 0x0a,
+	0x0f, 0x50, 0x69, 0x63, 0x6b, 0x65, 0x64, 0x55, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x68, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x68, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x61, 0x72, 0x67, 0x6f, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x61, 0x72, 0x67, 0x6f, 0x55,
+	0x6e, 0x69, 0x74, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75,
+	0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x77, 0x61, 0x72,
+	0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x33, 0x0a, 0x16, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x6f, 0x66, 0x66, 0x5f, 0x77, 0x61, 0x72,
+	0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x03,
+	0x52, 0x13, 0x64, 0x72, 0x6f, 0x70, 0x4f, 0x66, 0x66, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75,
+	0x73, 0x65, 0x49, 0x64, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x1a,
+	0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x73, 0x69,
+	0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d,
+	0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xeb, 0x02,
+	0x0a, 0x10, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x68, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x68, 0x69, 0x70, 0x6d, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x61, 0x72, 0x67, 0x6f, 0x5f, 0x75, 0x6e, 0x69,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x61, 0x72, 0x67,
+	0x6f, 0x55, 0x6e, 0x69, 0x74, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x61, 0x72, 0x65, 0x68,
+	0x6f, 0x75, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x77,
+	0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6c,
+	0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x6f, 0x66, 0x66, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x4f, 0x66, 0x66, 0x12, 0x14, 0x0a,
+	0x05, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x69,
+	0x6e, 0x61, 0x6c, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64,
+	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08,
+	0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x73, 0x69, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x73, 0x69,
+	0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xaa, 0x03, 0x0a, 0x12,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2e, 0x0a,
+	0x13, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x49, 0x64, 0x12, 0x38, 0x0a,
+	0x18, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x77, 0x61, 0x72,
+	0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x16, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x61, 0x72, 0x65,
+	0x68, 0x6f, 0x75, 0x73, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x6c, 0x6f, 0x67, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f,
+	0x72, 0x69, 0x74, 0x79, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x27, 0x0a, 0x0f,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x41, 0x0a, 0x0e, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x73, 0x69, 0x6d, 0x75, 0x6c,
+	0x61, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x11, 0x0a, 0x0f, 0x44, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x10, 0x0a, 0x0e, 0x44,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x65, 0x0a,
+	0x0c, 0x4d, 0x6f, 0x76, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x41, 0x63, 0x6b, 0x12, 0x19, 0x0a,
+	0x08, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x62, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x3a, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6c, 0x6f, 0x67, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76,
+	0x65, 0x55, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x22, 0x62, 0x0a, 0x0e, 0x4d, 0x6f, 0x76, 0x65, 0x55, 0x6e, 0x69, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x61, 0x72, 0x67, 0x6f, 0x5f,
+	0x75, 0x6e, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63,
+	0x61, 0x72, 0x67, 0x6f, 0x55, 0x6e, 0x69, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x82, 0x01, 0x0a, 0x29, 0x44, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x79, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f,
 	0x75, 0x73, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x78, 0x0a, 0x15, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f,
-	0x75, 0x73, 0x65, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12,
-	0x22, 0x0a, 0x0d, 0x63, 0x61, 0x72, 0x67, 0x6f, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x61, 0x72, 0x67, 0x6f, 0x55, 0x6e, 0x69,
-	0x74, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x77, 0x61, 0x72, 0x65, 0x68,
-	0x6f, 0x75, 0x73, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x22, 0x44, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
-	0x4c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
-	0x4c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4c, 0x6f, 0x6e, 0x67,
-	0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x4c, 0x6f, 0x6e,
-	0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x32, 0x88, 0x03, 0x0a, 0x12, 0x4c, 0x6f, 0x67, 0x69, 0x73,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f,
+	0x75, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x77, 0x61,
+	0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x15, 0x64, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x79, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x8d, 0x03,
+	0x0a, 0x15, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x64, 0x65, 0x6c, 0x69, 0x76,
+	0x65, 0x72, 0x79, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x55, 0x6e, 0x69, 0x74, 0x73, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x49, 0x0a, 0x21, 0x77,
+	0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x64, 0x5f, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x5f, 0x6c, 0x69, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x1e, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73,
+	0x65, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x53, 0x75, 0x70, 0x70, 0x6c, 0x69,
+	0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x22, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x79, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x5f, 0x72, 0x65, 0x61, 0x63, 0x68, 0x65, 0x64,
+	0x5f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x03, 0x52, 0x1f, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x55, 0x6e, 0x69, 0x74,
+	0x73, 0x52, 0x65, 0x61, 0x63, 0x68, 0x65, 0x64, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0xa7, 0x01, 0x0a, 0x33, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x5f, 0x65, 0x61, 0x63, 0x68, 0x5f, 0x77, 0x61, 0x72, 0x65,
+	0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x3b, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x55, 0x6e, 0x69,
+	0x74, 0x73, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x2d,
+	0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x45, 0x61, 0x63,
+	0x68, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xba, 0x01,
+	0x0a, 0x15, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x41, 0x6e, 0x6e, 0x6f, 0x75,
+	0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x61, 0x72, 0x67, 0x6f,
+	0x5f, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x63, 0x61, 0x72, 0x67, 0x6f, 0x55, 0x6e, 0x69, 0x74, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x77,
+	0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x71, 0x75, 0x65, 0x75, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x77,
+	0x61, 0x69, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x77, 0x61, 0x69, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x22, 0xf5, 0x01, 0x0a, 0x0e, 0x43,
+	0x61, 0x72, 0x67, 0x6f, 0x55, 0x6e, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6d, 0x61, 0x78, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x6d, 0x61, 0x78, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6c,
+	0x6f, 0x61, 0x64, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0a, 0x6c, 0x6f, 0x61, 0x64, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0a, 0x6c, 0x6f, 0x61, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x70,
+	0x65, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x75, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x04, 0x66, 0x75, 0x65, 0x6c, 0x12, 0x39, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x67, 0x6f,
+	0x55, 0x6e, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x22, 0x44, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a,
+	0x0a, 0x08, 0x4c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x4c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4c, 0x6f,
+	0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x4c,
+	0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x2a, 0x7b, 0x0a, 0x0d, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x1e, 0x0a, 0x1a, 0x4f, 0x52, 0x44,
+	0x45, 0x52, 0x5f, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x4f, 0x52, 0x44,
+	0x45, 0x52, 0x5f, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4c, 0x4f, 0x57, 0x10,
+	0x01, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x50, 0x52, 0x49, 0x4f, 0x52,
+	0x49, 0x54, 0x59, 0x5f, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13,
+	0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x48,
+	0x49, 0x47, 0x48, 0x10, 0x03, 0x2a, 0xce, 0x01, 0x0a, 0x0f, 0x43, 0x61, 0x72, 0x67, 0x6f, 0x55,
+	0x6e, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x41, 0x52,
+	0x47, 0x4f, 0x5f, 0x55, 0x4e, 0x49, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16,
+	0x43, 0x41, 0x52, 0x47, 0x4f, 0x5f, 0x55, 0x4e, 0x49, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x49, 0x44, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x41, 0x52, 0x47,
+	0x4f, 0x5f, 0x55, 0x4e, 0x49, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x45, 0x4e,
+	0x5f, 0x52, 0x4f, 0x55, 0x54, 0x45, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x43, 0x41, 0x52, 0x47,
+	0x4f, 0x5f, 0x55, 0x4e, 0x49, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4c, 0x4f,
+	0x41, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x41, 0x52, 0x47, 0x4f,
+	0x5f, 0x55, 0x4e, 0x49, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x4c,
+	0x4f, 0x41, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x04, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x41, 0x52, 0x47,
+	0x4f, 0x5f, 0x55, 0x4e, 0x49, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x42, 0x52,
+	0x4f, 0x4b, 0x45, 0x4e, 0x10, 0x05, 0x32, 0xb2, 0x06, 0x0a, 0x12, 0x4c, 0x6f, 0x67, 0x69, 0x73,
 	0x74, 0x69, 0x63, 0x73, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x41, 0x50, 0x49, 0x12, 0x6d, 0x0a,
 	0x08, 0x4d, 0x6f, 0x76, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x21, 0x2e, 0x6c, 0x6f, 0x67, 0x69,
 	0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76,
@@ -558,10 +1537,37 @@ var file_api_v1_logistics_proto_rawDesc = []byte{
 	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52,
 	0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82,
 	0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x22, 0x0a, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x72,
-	0x74, 0x42, 0x32, 0x5a, 0x30, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x65,
-	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63,
-	0x73, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69,
-	0x63, 0x73, 0x5f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x12, 0x70, 0x0a, 0x08, 0x50, 0x69, 0x63, 0x6b, 0x65, 0x64, 0x55, 0x70, 0x12, 0x21, 0x2e,
+	0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x69, 0x63, 0x6b, 0x65, 0x64, 0x55, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x21, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x22, 0x16, 0x2f, 0x76, 0x31,
+	0x2f, 0x73, 0x68, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x64,
+	0x5f, 0x75, 0x70, 0x12, 0x72, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64,
+	0x12, 0x22, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x22,
+	0x16, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x68, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x2f, 0x64, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x12, 0x69, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x24, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6c,
+	0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x11, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x22, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x12, 0x57, 0x0a, 0x0f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4d, 0x6f, 0x76, 0x65,
+	0x55, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x20, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x55, 0x6e, 0x69,
+	0x74, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x1a, 0x1e, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x55,
+	0x6e, 0x69, 0x74, 0x73, 0x41, 0x63, 0x6b, 0x28, 0x01, 0x30, 0x01, 0x42, 0x32, 0x5a, 0x30, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x64, 0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x76, 0x31, 0x3b, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x5f, 0x76, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -576,33 +1582,65 @@ func file_api_v1_logistics_proto_rawDescGZIP() []byte {
 	return file_api_v1_logistics_proto_rawDescData
 }
 
-var file_api_v1_logistics_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_v1_logistics_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_v1_logistics_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_api_v1_logistics_proto_goTypes = []interface{}{
-	(*MoveUnitRequest)(nil),                           // 0: logistics.api.v1.MoveUnitRequest
-	(*UnitReachedWarehouseRequest)(nil),               // 1: logistics.api.v1.UnitReachedWarehouseRequest
-	(*DefaultResponse)(nil),                           // 2: logistics.api.v1.DefaultResponse
-	(*DefaultRequest)(nil),                            // 3: logistics.api.v1.DefaultRequest
-	(*DeliveryUnitsWarehouseReceivedTotalNumber)(nil), // 4: logistics.api.v1.DeliveryUnitsWarehouseReceivedTotalNumber
-	(*MetricsReportResponse)(nil),                     // 5: logistics.api.v1.MetricsReportResponse
-	(*WarehouseAnnouncement)(nil),                     // 6: logistics.api.v1.WarehouseAnnouncement
-	(*Location)(nil),                                  // 7: logistics.api.v1.Location
+	(OrderPriority)(0),                                // 0: logistics.api.v1.OrderPriority
+	(CargoUnitStatus)(0),                              // 1: logistics.api.v1.CargoUnitStatus
+	(*MoveUnitRequest)(nil),                           // 2: logistics.api.v1.MoveUnitRequest
+	(*MoveUnitsBatch)(nil),                            // 3: logistics.api.v1.MoveUnitsBatch
+	(*UnitReachedWarehouseRequest)(nil),               // 4: logistics.api.v1.UnitReachedWarehouseRequest
+	(*PickedUpRequest)(nil),                           // 5: logistics.api.v1.PickedUpRequest
+	(*DeliveredRequest)(nil),                          // 6: logistics.api.v1.DeliveredRequest
+	(*CreateOrderRequest)(nil),                        // 7: logistics.api.v1.CreateOrderRequest
+	(*DefaultResponse)(nil),                           // 8: logistics.api.v1.DefaultResponse
+	(*DefaultRequest)(nil),                            // 9: logistics.api.v1.DefaultRequest
+	(*MoveUnitsAck)(nil),                              // 10: logistics.api.v1.MoveUnitsAck
+	(*MoveUnitResult)(nil),                            // 11: logistics.api.v1.MoveUnitResult
+	(*DeliveryUnitsWarehouseReceivedTotalNumber)(nil), // 12: logistics.api.v1.DeliveryUnitsWarehouseReceivedTotalNumber
+	(*MetricsReportResponse)(nil),                     // 13: logistics.api.v1.MetricsReportResponse
+	(*WarehouseAnnouncement)(nil),                     // 14: logistics.api.v1.WarehouseAnnouncement
+	(*CargoUnitState)(nil),                            // 15: logistics.api.v1.CargoUnitState
+	(*Location)(nil),                                  // 16: logistics.api.v1.Location
+	(*timestamppb.Timestamp)(nil),                     // 17: google.protobuf.Timestamp
 }
 var file_api_v1_logistics_proto_depIdxs = []int32{
-	7, // 0: logistics.api.v1.MoveUnitRequest.location:type_name -> logistics.api.v1.Location
-	7, // 1: logistics.api.v1.UnitReachedWarehouseRequest.location:type_name -> logistics.api.v1.Location
-	6, // 2: logistics.api.v1.UnitReachedWarehouseRequest.announcement:type_name -> logistics.api.v1.WarehouseAnnouncement
-	4, // 3: logistics.api.v1.MetricsReportResponse.delivery_units_each_warehouse_received_total_number:type_name -> logistics.api.v1.DeliveryUnitsWarehouseReceivedTotalNumber
-	0, // 4: logistics.api.v1.LogisticsEngineAPI.MoveUnit:input_type -> logistics.api.v1.MoveUnitRequest
-	1, // 5: logistics.api.v1.LogisticsEngineAPI.UnitReachedWarehouse:input_type -> logistics.api.v1.UnitReachedWarehouseRequest
-	3, // 6: logistics.api.v1.LogisticsEngineAPI.MetricsReport:input_type -> logistics.api.v1.DefaultRequest
-	2, // 7: logistics.api.v1.LogisticsEngineAPI.MoveUnit:output_type -> logistics.api.v1.DefaultResponse
-	2, // 8: logistics.api.v1.LogisticsEngineAPI.UnitReachedWarehouse:output_type -> logistics.api.v1.DefaultResponse
-	5, // 9: logistics.api.v1.LogisticsEngineAPI.MetricsReport:output_type -> logistics.api.v1.MetricsReportResponse
-	7, // [7:10] is the sub-list for method output_type
-	4, // [4:7] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	16, // 0: logistics.api.v1.MoveUnitRequest.location:type_name -> logistics.api.v1.Location
+	17, // 1: logistics.api.v1.MoveUnitRequest.simulated_time:type_name -> google.protobuf.Timestamp
+	15, // 2: logistics.api.v1.MoveUnitRequest.state:type_name -> logistics.api.v1.CargoUnitState
+	2,  // 3: logistics.api.v1.MoveUnitsBatch.moves:type_name -> logistics.api.v1.MoveUnitRequest
+	16, // 4: logistics.api.v1.UnitReachedWarehouseRequest.location:type_name -> logistics.api.v1.Location
+	14, // 5: logistics.api.v1.UnitReachedWarehouseRequest.announcement:type_name -> logistics.api.v1.WarehouseAnnouncement
+	17, // 6: logistics.api.v1.UnitReachedWarehouseRequest.simulated_time:type_name -> google.protobuf.Timestamp
+	16, // 7: logistics.api.v1.PickedUpRequest.location:type_name -> logistics.api.v1.Location
+	17, // 8: logistics.api.v1.PickedUpRequest.simulated_time:type_name -> google.protobuf.Timestamp
+	16, // 9: logistics.api.v1.DeliveredRequest.location:type_name -> logistics.api.v1.Location
+	17, // 10: logistics.api.v1.DeliveredRequest.simulated_time:type_name -> google.protobuf.Timestamp
+	0,  // 11: logistics.api.v1.CreateOrderRequest.priority:type_name -> logistics.api.v1.OrderPriority
+	17, // 12: logistics.api.v1.CreateOrderRequest.deadline:type_name -> google.protobuf.Timestamp
+	17, // 13: logistics.api.v1.CreateOrderRequest.simulated_time:type_name -> google.protobuf.Timestamp
+	11, // 14: logistics.api.v1.MoveUnitsAck.results:type_name -> logistics.api.v1.MoveUnitResult
+	12, // 15: logistics.api.v1.MetricsReportResponse.delivery_units_each_warehouse_received_total_number:type_name -> logistics.api.v1.DeliveryUnitsWarehouseReceivedTotalNumber
+	1,  // 16: logistics.api.v1.CargoUnitState.status:type_name -> logistics.api.v1.CargoUnitStatus
+	2,  // 17: logistics.api.v1.LogisticsEngineAPI.MoveUnit:input_type -> logistics.api.v1.MoveUnitRequest
+	4,  // 18: logistics.api.v1.LogisticsEngineAPI.UnitReachedWarehouse:input_type -> logistics.api.v1.UnitReachedWarehouseRequest
+	9,  // 19: logistics.api.v1.LogisticsEngineAPI.MetricsReport:input_type -> logistics.api.v1.DefaultRequest
+	5,  // 20: logistics.api.v1.LogisticsEngineAPI.PickedUp:input_type -> logistics.api.v1.PickedUpRequest
+	6,  // 21: logistics.api.v1.LogisticsEngineAPI.Delivered:input_type -> logistics.api.v1.DeliveredRequest
+	7,  // 22: logistics.api.v1.LogisticsEngineAPI.CreateOrder:input_type -> logistics.api.v1.CreateOrderRequest
+	3,  // 23: logistics.api.v1.LogisticsEngineAPI.StreamMoveUnits:input_type -> logistics.api.v1.MoveUnitsBatch
+	8,  // 24: logistics.api.v1.LogisticsEngineAPI.MoveUnit:output_type -> logistics.api.v1.DefaultResponse
+	8,  // 25: logistics.api.v1.LogisticsEngineAPI.UnitReachedWarehouse:output_type -> logistics.api.v1.DefaultResponse
+	13, // 26: logistics.api.v1.LogisticsEngineAPI.MetricsReport:output_type -> logistics.api.v1.MetricsReportResponse
+	8,  // 27: logistics.api.v1.LogisticsEngineAPI.PickedUp:output_type -> logistics.api.v1.DefaultResponse
+	8,  // 28: logistics.api.v1.LogisticsEngineAPI.Delivered:output_type -> logistics.api.v1.DefaultResponse
+	8,  // 29: logistics.api.v1.LogisticsEngineAPI.CreateOrder:output_type -> logistics.api.v1.DefaultResponse
+	10, // 30: logistics.api.v1.LogisticsEngineAPI.StreamMoveUnits:output_type -> logistics.api.v1.MoveUnitsAck
+	24, // [24:31] is the sub-list for method output_type
+	17, // [17:24] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_logistics_proto_init() }
@@ -624,7 +1662,7 @@ func file_api_v1_logistics_proto_init() {
 			}
 		}
 		file_api_v1_logistics_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnitReachedWarehouseRequest); i {
+			switch v := v.(*MoveUnitsBatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -636,7 +1674,7 @@ func file_api_v1_logistics_proto_init() {
 			}
 		}
 		file_api_v1_logistics_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DefaultResponse); i {
+			switch v := v.(*UnitReachedWarehouseRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -648,7 +1686,7 @@ func file_api_v1_logistics_proto_init() {
 			}
 		}
 		file_api_v1_logistics_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DefaultRequest); i {
+			switch v := v.(*PickedUpRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -660,7 +1698,7 @@ func file_api_v1_logistics_proto_init() {
 			}
 		}
 		file_api_v1_logistics_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeliveryUnitsWarehouseReceivedTotalNumber); i {
+			switch v := v.(*DeliveredRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -672,7 +1710,7 @@ func file_api_v1_logistics_proto_init() {
 			}
 		}
 		file_api_v1_logistics_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MetricsReportResponse); i {
+			switch v := v.(*CreateOrderRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -684,7 +1722,7 @@ func file_api_v1_logistics_proto_init() {
 			}
 		}
 		file_api_v1_logistics_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WarehouseAnnouncement); i {
+			switch v := v.(*DefaultResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -696,6 +1734,90 @@ func file_api_v1_logistics_proto_init() {
 			}
 		}
 		file_api_v1_logistics_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DefaultRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_logistics_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveUnitsAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_logistics_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveUnitResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_logistics_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryUnitsWarehouseReceivedTotalNumber); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_logistics_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MetricsReportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_logistics_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WarehouseAnnouncement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_logistics_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CargoUnitState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_logistics_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Location); i {
 			case 0:
 				return &v.state
@@ -713,13 +1835,14 @@ func file_api_v1_logistics_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_v1_logistics_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      2,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_v1_logistics_proto_goTypes,
 		DependencyIndexes: file_api_v1_logistics_proto_depIdxs,
+		EnumInfos:         file_api_v1_logistics_proto_enumTypes,
 		MessageInfos:      file_api_v1_logistics_proto_msgTypes,
 	}.Build()
 	File_api_v1_logistics_proto = out.File
@@ -22,6 +22,10 @@ const (
 	LogisticsEngineAPI_MoveUnit_FullMethodName             = "/logistics.api.v1.LogisticsEngineAPI/MoveUnit"
 	LogisticsEngineAPI_UnitReachedWarehouse_FullMethodName = "/logistics.api.v1.LogisticsEngineAPI/UnitReachedWarehouse"
 	LogisticsEngineAPI_MetricsReport_FullMethodName        = "/logistics.api.v1.LogisticsEngineAPI/MetricsReport"
+	LogisticsEngineAPI_PickedUp_FullMethodName             = "/logistics.api.v1.LogisticsEngineAPI/PickedUp"
+	LogisticsEngineAPI_Delivered_FullMethodName            = "/logistics.api.v1.LogisticsEngineAPI/Delivered"
+	LogisticsEngineAPI_CreateOrder_FullMethodName          = "/logistics.api.v1.LogisticsEngineAPI/CreateOrder"
+	LogisticsEngineAPI_StreamMoveUnits_FullMethodName      = "/logistics.api.v1.LogisticsEngineAPI/StreamMoveUnits"
 )
 
 // LogisticsEngineAPIClient is the grpc_client API for LogisticsEngineAPI service.
@@ -34,6 +38,14 @@ type LogisticsEngineAPIClient interface {
 	UnitReachedWarehouse(ctx context.Context, in *UnitReachedWarehouseRequest, opts ...grpc.CallOption) (*DefaultResponse, error)
 	// MetricsReport reports when .
 	MetricsReport(ctx context.Context, in *DefaultRequest, opts ...grpc.CallOption) (*MetricsReportResponse, error)
+	// PickedUp reports when unit loaded goods of its shipment at the pickup warehouse.
+	PickedUp(ctx context.Context, in *PickedUpRequest, opts ...grpc.CallOption) (*DefaultResponse, error)
+	// Delivered reports when unit dropped goods of its shipment at a drop-off warehouse.
+	Delivered(ctx context.Context, in *DeliveredRequest, opts ...grpc.CallOption) (*DefaultResponse, error)
+	// CreateOrder registers an order of goods to be carried between two warehouses before it is dispatched to a unit.
+	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*DefaultResponse, error)
+	// StreamMoveUnits carries unit moves in batches, every batch is answered with an ack holding per-unit results.
+	StreamMoveUnits(ctx context.Context, opts ...grpc.CallOption) (LogisticsEngineAPI_StreamMoveUnitsClient, error)
 }
 
 type logisticsEngineAPIClient struct {
@@ -71,6 +83,64 @@ func (c *logisticsEngineAPIClient) MetricsReport(ctx context.Context, in *Defaul
 	return out, nil
 }
 
+func (c *logisticsEngineAPIClient) PickedUp(ctx context.Context, in *PickedUpRequest, opts ...grpc.CallOption) (*DefaultResponse, error) {
+	out := new(DefaultResponse)
+	err := c.cc.Invoke(ctx, LogisticsEngineAPI_PickedUp_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logisticsEngineAPIClient) Delivered(ctx context.Context, in *DeliveredRequest, opts ...grpc.CallOption) (*DefaultResponse, error) {
+	out := new(DefaultResponse)
+	err := c.cc.Invoke(ctx, LogisticsEngineAPI_Delivered_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logisticsEngineAPIClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*DefaultResponse, error) {
+	out := new(DefaultResponse)
+	err := c.cc.Invoke(ctx, LogisticsEngineAPI_CreateOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logisticsEngineAPIClient) StreamMoveUnits(ctx context.Context, opts ...grpc.CallOption) (LogisticsEngineAPI_StreamMoveUnitsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LogisticsEngineAPI_ServiceDesc.Streams[0], LogisticsEngineAPI_StreamMoveUnits_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &logisticsEngineAPIStreamMoveUnitsClient{stream}
+	return x, nil
+}
+
+type LogisticsEngineAPI_StreamMoveUnitsClient interface {
+	Send(*MoveUnitsBatch) error
+	Recv() (*MoveUnitsAck, error)
+	grpc.ClientStream
+}
+
+type logisticsEngineAPIStreamMoveUnitsClient struct {
+	grpc.ClientStream
+}
+
+func (x *logisticsEngineAPIStreamMoveUnitsClient) Send(m *MoveUnitsBatch) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *logisticsEngineAPIStreamMoveUnitsClient) Recv() (*MoveUnitsAck, error) {
+	m := new(MoveUnitsAck)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // LogisticsEngineAPIServer is the server API for LogisticsEngineAPI service.
 // All implementations should embed UnimplementedLogisticsEngineAPIServer
 // for forward compatibility
@@ -81,6 +151,14 @@ type LogisticsEngineAPIServer interface {
 	UnitReachedWarehouse(context.Context, *UnitReachedWarehouseRequest) (*DefaultResponse, error)
 	// MetricsReport reports when .
 	MetricsReport(context.Context, *DefaultRequest) (*MetricsReportResponse, error)
+	// PickedUp reports when unit loaded goods of its shipment at the pickup warehouse.
+	PickedUp(context.Context, *PickedUpRequest) (*DefaultResponse, error)
+	// Delivered reports when unit dropped goods of its shipment at a drop-off warehouse.
+	Delivered(context.Context, *DeliveredRequest) (*DefaultResponse, error)
+	// CreateOrder registers an order of goods to be carried between two warehouses before it is dispatched to a unit.
+	CreateOrder(context.Context, *CreateOrderRequest) (*DefaultResponse, error)
+	// StreamMoveUnits carries unit moves in batches, every batch is answered with an ack holding per-unit results.
+	StreamMoveUnits(LogisticsEngineAPI_StreamMoveUnitsServer) error
 }
 
 // UnimplementedLogisticsEngineAPIServer should be embedded to have forward compatible implementations.
@@ -96,6 +174,18 @@ func (UnimplementedLogisticsEngineAPIServer) UnitReachedWarehouse(context.Contex
 func (UnimplementedLogisticsEngineAPIServer) MetricsReport(context.Context, *DefaultRequest) (*MetricsReportResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MetricsReport not implemented")
 }
+func (UnimplementedLogisticsEngineAPIServer) PickedUp(context.Context, *PickedUpRequest) (*DefaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PickedUp not implemented")
+}
+func (UnimplementedLogisticsEngineAPIServer) Delivered(context.Context, *DeliveredRequest) (*DefaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delivered not implemented")
+}
+func (UnimplementedLogisticsEngineAPIServer) CreateOrder(context.Context, *CreateOrderRequest) (*DefaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (UnimplementedLogisticsEngineAPIServer) StreamMoveUnits(LogisticsEngineAPI_StreamMoveUnitsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMoveUnits not implemented")
+}
 
 // UnsafeLogisticsEngineAPIServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to LogisticsEngineAPIServer will
@@ -162,6 +252,86 @@ func _LogisticsEngineAPI_MetricsReport_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LogisticsEngineAPI_PickedUp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PickedUpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogisticsEngineAPIServer).PickedUp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogisticsEngineAPI_PickedUp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogisticsEngineAPIServer).PickedUp(ctx, req.(*PickedUpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogisticsEngineAPI_Delivered_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveredRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogisticsEngineAPIServer).Delivered(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogisticsEngineAPI_Delivered_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogisticsEngineAPIServer).Delivered(ctx, req.(*DeliveredRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogisticsEngineAPI_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogisticsEngineAPIServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogisticsEngineAPI_CreateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogisticsEngineAPIServer).CreateOrder(ctx, req.(*CreateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogisticsEngineAPI_StreamMoveUnits_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LogisticsEngineAPIServer).StreamMoveUnits(&logisticsEngineAPIStreamMoveUnitsServer{stream})
+}
+
+type LogisticsEngineAPI_StreamMoveUnitsServer interface {
+	Send(*MoveUnitsAck) error
+	Recv() (*MoveUnitsBatch, error)
+	grpc.ServerStream
+}
+
+type logisticsEngineAPIStreamMoveUnitsServer struct {
+	grpc.ServerStream
+}
+
+func (x *logisticsEngineAPIStreamMoveUnitsServer) Send(m *MoveUnitsAck) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *logisticsEngineAPIStreamMoveUnitsServer) Recv() (*MoveUnitsBatch, error) {
+	m := new(MoveUnitsBatch)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // LogisticsEngineAPI_ServiceDesc is the grpc.ServiceDesc for LogisticsEngineAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -181,7 +351,26 @@ var LogisticsEngineAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MetricsReport",
 			Handler:    _LogisticsEngineAPI_MetricsReport_Handler,
 		},
+		{
+			MethodName: "PickedUp",
+			Handler:    _LogisticsEngineAPI_PickedUp_Handler,
+		},
+		{
+			MethodName: "Delivered",
+			Handler:    _LogisticsEngineAPI_Delivered_Handler,
+		},
+		{
+			MethodName: "CreateOrder",
+			Handler:    _LogisticsEngineAPI_CreateOrder_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMoveUnits",
+			Handler:       _LogisticsEngineAPI_StreamMoveUnits_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/logistics.proto",
 }
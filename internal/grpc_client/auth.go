@@ -0,0 +1,132 @@
+package grpc_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// DefaultAPIKeyHeader is metadata key APIKey is sent in when none is set
+const DefaultAPIKeyHeader = "x-api-key"
+
+// WithPerRPCCredentials attached as metadata to every call, unary and streaming ones
+func WithPerRPCCredentials(creds credentials.PerRPCCredentials) Option {
+	return func(lc *APILogisticsClient) {
+		lc.perRPCCredentials = append(lc.perRPCCredentials, creds)
+	}
+}
+
+// BearerToken sends a static token in the authorization header
+type BearerToken struct {
+	Token string
+	// AllowInsecure lets the token be sent over a plaintext connection, e.g. to a local server
+	AllowInsecure bool
+}
+
+func (t BearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.Token}, nil
+}
+
+func (t BearerToken) RequireTransportSecurity() bool {
+	return !t.AllowInsecure
+}
+
+// APIKey sends a static key in its own header
+type APIKey struct {
+	// Header is the metadata key, DefaultAPIKeyHeader when empty
+	Header string
+	Key    string
+	// AllowInsecure lets the key be sent over a plaintext connection, e.g. to a local server
+	AllowInsecure bool
+}
+
+func (k APIKey) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	header := k.Header
+	if len(header) == 0 {
+		header = DefaultAPIKeyHeader
+	}
+
+	return map[string]string{strings.ToLower(header): k.Key}, nil
+}
+
+func (k APIKey) RequireTransportSecurity() bool {
+	return !k.AllowInsecure
+}
+
+// TokenFile sends the token stored in a file in the authorization header. The file is read again when it changes,
+// so a token rotated by another process is used from the next call on.
+type TokenFile struct {
+	path          string
+	allowInsecure bool
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// NewTokenFile reads the token from path, allowInsecure lets it be sent over a plaintext connection
+func NewTokenFile(path string, allowInsecure bool) (*TokenFile, error) {
+	tokenFile := &TokenFile{path: path, allowInsecure: allowInsecure}
+	if _, readErr := tokenFile.Token(); readErr != nil {
+		return nil, readErr
+	}
+
+	return tokenFile, nil
+}
+
+// Token currently stored in the file, the last one read is kept while the file can't be read
+func (f *TokenFile) Token() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, statErr := os.Stat(f.path)
+	if statErr != nil {
+		if len(f.token) > 0 {
+			return f.token, nil
+		}
+		return "", fmt.Errorf("failed to read token file: %w", statErr)
+	}
+	if len(f.token) > 0 && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.token, nil
+	}
+
+	data, readErr := os.ReadFile(f.path)
+	if readErr != nil {
+		if len(f.token) > 0 {
+			return f.token, nil
+		}
+		return "", fmt.Errorf("failed to read token file: %w", readErr)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if len(token) == 0 {
+		if len(f.token) > 0 { // The file may be caught while it is rewritten
+			return f.token, nil
+		}
+		return "", errors.New("token file is empty")
+	}
+
+	f.token, f.modTime, f.size = token, info.ModTime(), info.Size()
+
+	return f.token, nil
+}
+
+func (f *TokenFile) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	token, tokenErr := f.Token()
+	if tokenErr != nil {
+		return nil, tokenErr
+	}
+
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+func (f *TokenFile) RequireTransportSecurity() bool {
+	return !f.allowInsecure
+}
@@ -0,0 +1,173 @@
+package grpc_client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/fakeserver"
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// metadataRecorder keeps metadata of every call a server received, keyed by method
+type metadataRecorder struct {
+	mu       sync.Mutex
+	received map[string][]metadata.MD
+}
+
+func (r *metadataRecorder) record(ctx context.Context, method string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.received[method] = append(r.received[method], md)
+}
+
+func (r *metadataRecorder) last(method string) metadata.MD {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	calls := r.received[method]
+	if len(calls) == 0 {
+		return nil
+	}
+
+	return calls[len(calls)-1]
+}
+
+// connectRecordingMetadata connects a client with creds to a fake server that records metadata of every call
+func connectRecordingMetadata(t *testing.T, creds ...credentials.PerRPCCredentials) (*APILogisticsClient, *metadataRecorder) {
+	t.Helper()
+
+	recorder := &metadataRecorder{received: make(map[string][]metadata.MD)}
+	dialOption, stop := fakeserver.New().Listen(
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			recorder.record(ctx, info.FullMethod)
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			recorder.record(ss.Context(), info.FullMethod)
+			return handler(srv, ss)
+		}),
+	)
+	t.Cleanup(stop)
+
+	opts := []Option{WithDialOptions(dialOption)}
+	for _, c := range creds {
+		opts = append(opts, WithPerRPCCredentials(c))
+	}
+	lc := NewLogisticsClient(opts...)
+	if connErr := lc.Connect("bufnet", context.Background()); connErr != nil {
+		t.Fatalf("Not expected error when connecting, error: %v", connErr)
+	}
+	t.Cleanup(func() { _ = lc.Disconnect() })
+
+	return lc, recorder
+}
+
+func TestPerRPCCredentialsOnEveryCall(t *testing.T) {
+	lc, recorder := connectRecordingMetadata(t,
+		BearerToken{Token: "secret", AllowInsecure: true},
+		APIKey{Header: "X-Tenant-Key", Key: "tenant", AllowInsecure: true},
+	)
+
+	ctx := context.Background()
+	if moveErr := lc.MoveUnit(ctx, &logistics_v1.MoveUnitRequest{CargoUnitId: 1}); moveErr != nil {
+		t.Fatalf("Not expected error when moving unit, error: %v", moveErr)
+	}
+	stream, openErr := lc.OpenMoveUnitsStream(ctx, 10)
+	if openErr != nil {
+		t.Fatalf("Not expected error when opening stream, error: %v", openErr)
+	}
+	if _, sendErr := stream.Send([]*logistics_v1.MoveUnitRequest{{CargoUnitId: 2}}); sendErr != nil {
+		t.Fatalf("Not expected error when sending moves, error: %v", sendErr)
+	}
+	_ = stream.Close()
+
+	for _, method := range []string{
+		logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName,
+		logistics_v1.LogisticsEngineAPI_StreamMoveUnits_FullMethodName,
+	} {
+		md := recorder.last(method)
+		if got := md.Get("authorization"); len(got) != 1 || got[0] != "Bearer secret" {
+			t.Errorf("Expected bearer token in %s, but got %v", method, got)
+		}
+		if got := md.Get("x-tenant-key"); len(got) != 1 || got[0] != "tenant" {
+			t.Errorf("Expected API key in %s, but got %v", method, got)
+		}
+	}
+}
+
+func TestTokenFileIsReadAgainWhenChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if writeErr := os.WriteFile(path, []byte("first\n"), 0o600); writeErr != nil {
+		t.Fatalf("Not expected error when writing token, error: %v", writeErr)
+	}
+
+	tokenFile, tokenErr := NewTokenFile(path, true)
+	if tokenErr != nil {
+		t.Fatalf("Not expected error when reading token file, error: %v", tokenErr)
+	}
+	lc, recorder := connectRecordingMetadata(t, tokenFile)
+
+	assertToken := func(expected string) {
+		t.Helper()
+
+		if _, reportErr := lc.MetricsReport(context.Background()); reportErr != nil {
+			t.Fatalf("Not expected error when requesting report, error: %v", reportErr)
+		}
+		md := recorder.last(logistics_v1.LogisticsEngineAPI_MetricsReport_FullMethodName)
+		if got := md.Get("authorization"); len(got) != 1 || got[0] != "Bearer "+expected {
+			t.Errorf("Expected token %q, but got %v", expected, got)
+		}
+	}
+
+	assertToken("first")
+
+	if writeErr := os.WriteFile(path, []byte("rotated\n"), 0o600); writeErr != nil {
+		t.Fatalf("Not expected error when writing token, error: %v", writeErr)
+	}
+	// Modification time may have a coarse resolution, so it is moved explicitly
+	later := time.Now().Add(time.Minute)
+	if chtimesErr := os.Chtimes(path, later, later); chtimesErr != nil {
+		t.Fatalf("Not expected error when touching token file, error: %v", chtimesErr)
+	}
+	assertToken("rotated")
+
+	if removeErr := os.Remove(path); removeErr != nil {
+		t.Fatalf("Not expected error when removing token file, error: %v", removeErr)
+	}
+	assertToken("rotated")
+}
+
+func TestNewTokenFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	emptyPath := filepath.Join(dir, "empty")
+	if writeErr := os.WriteFile(emptyPath, []byte("\n"), 0o600); writeErr != nil {
+		t.Fatalf("Not expected error when writing token, error: %v", writeErr)
+	}
+
+	for _, path := range []string{filepath.Join(dir, "missing"), emptyPath} {
+		if _, tokenErr := NewTokenFile(path, true); tokenErr == nil {
+			t.Errorf("Expected error when reading token file %s", path)
+		}
+	}
+}
+
+func TestCredentialsRequireTLS(t *testing.T) {
+	dialOption, stop := fakeserver.New().Listen()
+	t.Cleanup(stop)
+
+	lc := NewLogisticsClient(WithDialOptions(dialOption), WithPerRPCCredentials(BearerToken{Token: "secret"}))
+	if connErr := lc.Connect("bufnet", context.Background()); connErr == nil {
+		_ = lc.Disconnect()
+		t.Errorf("Expected error when connecting with credentials without TLS")
+	}
+}
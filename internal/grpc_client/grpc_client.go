@@ -2,31 +2,99 @@ package grpc_client
 
 import (
 	"context"
+	"crypto/tls"
 	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"net/http"
 )
 
+// Transport carries calls of APILogisticsClient to API, *grpc.ClientConn is one
+type Transport interface {
+	grpc.ClientConnInterface
+	Close() error
+}
+
 // APILogisticsClient to send requests about cargo unit movements
 type APILogisticsClient struct {
 	apiClientGRPC logistics_v1.LogisticsEngineAPIClient
 
-	conn *grpc.ClientConn
+	conn                 Transport
+	dialOptions          []grpc.DialOption
+	retryPolicy          RetryPolicy
+	transportCredentials credentials.TransportCredentials
+	perRPCCredentials    []credentials.PerRPCCredentials
+	unaryInterceptors    []grpc.UnaryClientInterceptor
+	streamInterceptors   []grpc.StreamClientInterceptor
+
+	// httpClient is set when calls go through the HTTP/JSON gateway instead of gRPC
+	httpClient    *http.Client
+	httpTLSConfig *tls.Config
+}
+
+// Option configures APILogisticsClient
+type Option func(lc *APILogisticsClient)
+
+// WithDialOptions appends options used when connecting to gRPC API, e.g. a custom dialer for in-memory connections
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(lc *APILogisticsClient) {
+		lc.dialOptions = append(lc.dialOptions, opts...)
+	}
+}
+
+// WithInterceptors of every call, the retry interceptor runs outside of them,
+// so they see every attempt. Either of them may be nil.
+func WithInterceptors(unary grpc.UnaryClientInterceptor, stream grpc.StreamClientInterceptor) Option {
+	return func(lc *APILogisticsClient) {
+		if unary != nil {
+			lc.unaryInterceptors = append(lc.unaryInterceptors, unary)
+		}
+		if stream != nil {
+			lc.streamInterceptors = append(lc.streamInterceptors, stream)
+		}
+	}
 }
 
 // NewLogisticsClient instance
-func NewLogisticsClient() *APILogisticsClient {
-	return &APILogisticsClient{}
+func NewLogisticsClient(opts ...Option) *APILogisticsClient {
+	lc := &APILogisticsClient{retryPolicy: DefaultRetryPolicy(), transportCredentials: insecure.NewCredentials()}
+	for _, opt := range opts {
+		opt(lc)
+	}
+
+	return lc
 }
 
-// Connect to gRPC API
+// Connect to API over gRPC or, when the client was created with WithHTTPTransport, over the HTTP/JSON gateway
 func (lc *APILogisticsClient) Connect(serverAddr string, ctx context.Context) error {
+	if lc.httpClient != nil {
+		conn, connErr := newHTTPTransport(serverAddr, lc)
+		if connErr != nil {
+			return connErr
+		}
+
+		lc.conn = conn
+		lc.apiClientGRPC = logistics_v1.NewLogisticsEngineAPIClient(lc.conn)
+
+		return nil
+	}
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(lc.transportCredentials),
+		grpc.WithBlock(),
+		grpc.WithChainUnaryInterceptor(append([]grpc.UnaryClientInterceptor{lc.retryPolicy.retryInterceptor()}, lc.unaryInterceptors...)...),
+		grpc.WithChainStreamInterceptor(lc.streamInterceptors...),
+	}
+	for _, creds := range lc.perRPCCredentials {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(creds))
+	}
+	dialOptions = append(dialOptions, lc.dialOptions...)
 
 	conn, dialErr := grpc.DialContext(
 		ctx,
 		serverAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(),
+		dialOptions...,
 	)
 	if dialErr != nil {
 		return dialErr
@@ -39,7 +107,7 @@ func (lc *APILogisticsClient) Connect(serverAddr string, ctx context.Context) er
 
 }
 
-// Disconnect from gRPC API
+// Disconnect from API
 func (lc *APILogisticsClient) Disconnect() error {
 	return lc.conn.Close()
 }
@@ -58,3 +126,26 @@ func (lc *APILogisticsClient) UnitReachedWarehouse(ctx context.Context, req *log
 	return
 
 }
+
+// PickedUp reports that unit loaded goods of its shipment
+func (lc *APILogisticsClient) PickedUp(ctx context.Context, req *logistics_v1.PickedUpRequest) error {
+	_, responseErr := lc.apiClientGRPC.PickedUp(ctx, req)
+	return responseErr
+}
+
+// Delivered reports that unit dropped goods of its shipment
+func (lc *APILogisticsClient) Delivered(ctx context.Context, req *logistics_v1.DeliveredRequest) error {
+	_, responseErr := lc.apiClientGRPC.Delivered(ctx, req)
+	return responseErr
+}
+
+// CreateOrder registers an order before it is dispatched
+func (lc *APILogisticsClient) CreateOrder(ctx context.Context, req *logistics_v1.CreateOrderRequest) error {
+	_, responseErr := lc.apiClientGRPC.CreateOrder(ctx, req)
+	return responseErr
+}
+
+// MetricsReport requests calculations made by API about all received movements and deliveries
+func (lc *APILogisticsClient) MetricsReport(ctx context.Context) (*logistics_v1.MetricsReportResponse, error) {
+	return lc.apiClientGRPC.MetricsReport(ctx, &logistics_v1.DefaultRequest{})
+}
@@ -0,0 +1,263 @@
+package grpc_client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// httpRoutes of unary methods declared by google.api.http options of the API proto
+var httpRoutes = map[string]string{
+	logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName:             "/v1/cargo_unit/move",
+	logistics_v1.LogisticsEngineAPI_UnitReachedWarehouse_FullMethodName: "/v1/warehouse/cargo_unit/reached",
+	logistics_v1.LogisticsEngineAPI_PickedUp_FullMethodName:             "/v1/shipment/picked_up",
+	logistics_v1.LogisticsEngineAPI_Delivered_FullMethodName:            "/v1/shipment/delivered",
+	logistics_v1.LogisticsEngineAPI_CreateOrder_FullMethodName:          "/v1/order",
+	logistics_v1.LogisticsEngineAPI_MetricsReport_FullMethodName:        "/v1/report",
+}
+
+// WithHTTPTransport makes the client call the HTTP/JSON gateway of API instead of gRPC. tlsConfig is used
+// for HTTPS, nil calls the gateway over plain HTTP. StreamMoveUnits has no HTTP route and fails with Unimplemented.
+func WithHTTPTransport(tlsConfig *tls.Config) Option {
+	return func(lc *APILogisticsClient) {
+		lc.httpTLSConfig = tlsConfig
+		lc.httpClient = &http.Client{Transport: &http.Transport{
+			TLSClientConfig:     tlsConfig,
+			MaxIdleConnsPerHost: 1024,
+			IdleConnTimeout:     90 * time.Second,
+		}}
+	}
+}
+
+// httpTransport makes unary calls as POST requests to the gateway. The gateway routes declare no body,
+// so request fields are sent as form values with dotted paths of nested fields.
+type httpTransport struct {
+	baseURL           string
+	client            *http.Client
+	perRPCCredentials []credentials.PerRPCCredentials
+	interceptor       grpc.UnaryClientInterceptor
+}
+
+func newHTTPTransport(serverAddr string, lc *APILogisticsClient) (*httpTransport, error) {
+	scheme := "http"
+	if lc.httpTLSConfig != nil {
+		scheme = "https"
+	}
+
+	for _, creds := range lc.perRPCCredentials {
+		if creds.RequireTransportSecurity() && lc.httpTLSConfig == nil {
+			return nil, errors.New("the credentials require transport level security, the gateway is called over plain HTTP")
+		}
+	}
+
+	return &httpTransport{
+		baseURL:           scheme + "://" + serverAddr,
+		client:            lc.httpClient,
+		perRPCCredentials: lc.perRPCCredentials,
+		interceptor:       chainUnaryInterceptors(append([]grpc.UnaryClientInterceptor{lc.retryPolicy.retryInterceptor()}, lc.unaryInterceptors...)),
+	}, nil
+}
+
+// Invoke the method through the interceptors of the client
+func (t *httpTransport) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	return t.interceptor(ctx, method, args, reply, nil, t.post, opts...)
+}
+
+// NewStream is not supported, the gateway has no routes for streaming methods
+func (t *httpTransport) NewStream(_ context.Context, _ *grpc.StreamDesc, method string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Errorf(codes.Unimplemented, "%s is not available over HTTP", method)
+}
+
+// Close idle connections to the gateway
+func (t *httpTransport) Close() error {
+	t.client.CloseIdleConnections()
+	return nil
+}
+
+// post the request to the route of the method and decode the response into reply
+func (t *httpTransport) post(ctx context.Context, method string, args, reply any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+	route, ok := httpRoutes[method]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "%s has no HTTP route", method)
+	}
+	req, ok := args.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "request of %s is not a protobuf message", method)
+	}
+
+	form := url.Values{}
+	encodeForm(form, "", req.ProtoReflect())
+
+	httpReq, reqErr := http.NewRequestWithContext(ctx, http.MethodPost, t.baseURL+route, strings.NewReader(form.Encode()))
+	if reqErr != nil {
+		return status.Error(codes.Internal, reqErr.Error())
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Accept", "application/json")
+	if headerErr := t.setMetadataHeaders(ctx, httpReq, method); headerErr != nil {
+		return headerErr
+	}
+
+	resp, doErr := t.client.Do(httpReq)
+	if doErr != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+		return status.Error(codes.Unavailable, doErr.Error())
+	}
+	defer resp.Body.Close()
+
+	body, readErr := io.ReadAll(resp.Body)
+	if readErr != nil {
+		return status.Error(codes.Unavailable, readErr.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		return statusFromHTTP(resp.StatusCode, body)
+	}
+
+	replyMsg, ok := reply.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "response of %s is not a protobuf message", method)
+	}
+	if unmarshalErr := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, replyMsg); unmarshalErr != nil {
+		return status.Errorf(codes.Internal, "failed to decode response of %s: %v", method, unmarshalErr)
+	}
+
+	return nil
+}
+
+// setMetadataHeaders of per-RPC credentials and outgoing metadata of ctx. The gateway forwards Authorization
+// as it is and Grpc-Metadata- headers as metadata without the prefix.
+func (t *httpTransport) setMetadataHeaders(ctx context.Context, httpReq *http.Request, method string) error {
+	setHeader := func(key, value string) {
+		if strings.EqualFold(key, "authorization") {
+			httpReq.Header.Set("Authorization", value)
+			return
+		}
+		httpReq.Header.Add("Grpc-Metadata-"+key, value)
+	}
+
+	for _, creds := range t.perRPCCredentials {
+		md, mdErr := creds.GetRequestMetadata(ctx, t.baseURL+method)
+		if mdErr != nil {
+			return status.Errorf(codes.Unauthenticated, "failed to get request credentials: %v", mdErr)
+		}
+		for key, value := range md {
+			setHeader(key, value)
+		}
+	}
+
+	outgoing, _ := metadata.FromOutgoingContext(ctx)
+	for key, values := range outgoing {
+		for _, value := range values {
+			setHeader(key, value)
+		}
+	}
+
+	return nil
+}
+
+// encodeForm adds every populated field of msg to form, nested fields are named by their dotted path
+func encodeForm(form url.Values, prefix string, msg protoreflect.Message) {
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		name := prefix + string(field.Name())
+
+		switch {
+		case field.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				form.Add(name, formValue(field, list.Get(i)))
+			}
+		case field.Kind() == protoreflect.MessageKind && field.Message().FullName() != timestampName:
+			encodeForm(form, name+".", value.Message())
+		default:
+			form.Set(name, formValue(field, value))
+		}
+
+		return true
+	})
+}
+
+var timestampName = (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
+
+func formValue(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch field.Kind() {
+	case protoreflect.MessageKind:
+		return value.Message().Interface().(*timestamppb.Timestamp).AsTime().Format(time.RFC3339Nano)
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return strconv.Itoa(int(value.Enum()))
+	case protoreflect.BytesKind:
+		return string(value.Bytes())
+	}
+
+	return value.String()
+}
+
+// statusFromHTTP error of the gateway, it writes google.rpc.Status as JSON with the original gRPC code
+func statusFromHTTP(statusCode int, body []byte) error {
+	var rpcStatus struct {
+		Code    int32  `json:"code"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &rpcStatus) == nil && rpcStatus.Code != 0 {
+		return status.Error(codes.Code(rpcStatus.Code), rpcStatus.Message)
+	}
+
+	message := fmt.Sprintf("gateway answered %d %s: %s", statusCode, http.StatusText(statusCode), bytes.TrimSpace(body))
+	switch statusCode {
+	case http.StatusBadRequest:
+		return status.Error(codes.InvalidArgument, message)
+	case http.StatusUnauthorized:
+		return status.Error(codes.Unauthenticated, message)
+	case http.StatusForbidden:
+		return status.Error(codes.PermissionDenied, message)
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return status.Error(codes.Unimplemented, message)
+	case http.StatusTooManyRequests:
+		return status.Error(codes.ResourceExhausted, message)
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return status.Error(codes.Unavailable, message)
+	case http.StatusGatewayTimeout:
+		return status.Error(codes.DeadlineExceeded, message)
+	}
+
+	return status.Error(codes.Unknown, message)
+}
+
+// chainUnaryInterceptors into one, the first one is the outermost like grpc.WithChainUnaryInterceptor does
+func chainUnaryInterceptors(interceptors []grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		next := invoker
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return interceptor(ctx, method, req, reply, cc, inner, opts...)
+			}
+		}
+
+		return next(ctx, method, req, reply, cc, opts...)
+	}
+}
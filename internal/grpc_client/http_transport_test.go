@@ -0,0 +1,172 @@
+package grpc_client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/fakeserver"
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// connectOverHTTP connects a client with opts to server behind the gateway, headers of every request are kept
+func connectOverHTTP(t *testing.T, server *fakeserver.Server, opts ...Option) (*APILogisticsClient, func() []http.Header) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var headers []http.Header
+	addr, stop := server.ListenHTTP(runtime.WithMetadata(func(_ context.Context, r *http.Request) metadata.MD {
+		mu.Lock()
+		defer mu.Unlock()
+
+		headers = append(headers, r.Header.Clone())
+		return nil
+	}))
+	t.Cleanup(stop)
+
+	lc := NewLogisticsClient(append([]Option{WithHTTPTransport(nil)}, opts...)...)
+	if connErr := lc.Connect(addr, context.Background()); connErr != nil {
+		t.Fatalf("Not expected error when connecting, error: %v", connErr)
+	}
+	t.Cleanup(func() { _ = lc.Disconnect() })
+
+	return lc, func() []http.Header {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]http.Header(nil), headers...)
+	}
+}
+
+func TestHTTPTransportCalls(t *testing.T) {
+	server := fakeserver.New()
+	lc, _ := connectOverHTTP(t, server)
+	ctx := context.Background()
+
+	simulatedTime := time.Date(2024, 5, 1, 12, 30, 15, 500, time.UTC)
+	move := &logistics_v1.MoveUnitRequest{
+		CargoUnitId:    7,
+		Location:       &logistics_v1.Location{Latitude: 12, Longitude: 34},
+		IdempotencyKey: "run-7-1",
+		Sequence:       1,
+		SimulatedTime:  timestamppb.New(simulatedTime),
+	}
+	if moveErr := lc.MoveUnit(ctx, move); moveErr != nil {
+		t.Fatalf("Not expected error when moving unit, error: %v", moveErr)
+	}
+
+	reached := &logistics_v1.UnitReachedWarehouseRequest{
+		Location: &logistics_v1.Location{Latitude: 12, Longitude: 34},
+		Announcement: &logistics_v1.WarehouseAnnouncement{
+			CargoUnitId: 7,
+			WarehouseId: 3,
+			Message:     "unloading",
+			QueueLength: 2,
+			WaitTicks:   4,
+		},
+		IdempotencyKey: "run-7-2",
+		Sequence:       2,
+		SimulatedTime:  timestamppb.New(simulatedTime.Add(time.Second)),
+	}
+	if reachedErr := lc.UnitReachedWarehouse(ctx, reached); reachedErr != nil {
+		t.Fatalf("Not expected error when announcing unit, error: %v", reachedErr)
+	}
+
+	if moves := server.Moves(); len(moves) != 1 || !proto.Equal(moves[0], move) {
+		t.Errorf("Expected move %v, but got %v", move, moves)
+	}
+	if announcements := server.Reached(); len(announcements) != 1 || !proto.Equal(announcements[0], reached) {
+		t.Errorf("Expected announcement %v, but got %v", reached, announcements)
+	}
+
+	report, reportErr := lc.MetricsReport(ctx)
+	if reportErr != nil {
+		t.Fatalf("Not expected error when requesting report, error: %v", reportErr)
+	}
+	if report.GetDeliveryUnitsNumber() != 1 {
+		t.Errorf("Expected 1 delivery unit in report, but got %d", report.GetDeliveryUnitsNumber())
+	}
+	if got := report.GetWarehousesReceivedSuppliesList(); len(got) != 1 || got[0] != 3 {
+		t.Errorf("Expected warehouse 3 in report, but got %v", got)
+	}
+}
+
+func TestHTTPTransportErrors(t *testing.T) {
+	server := fakeserver.New()
+	attempts := 0
+	server.SetErrorInjector(func(method string, _ proto.Message) (bool, error) {
+		if method != logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName {
+			return false, nil
+		}
+		attempts++
+		if attempts == 1 {
+			return false, status.Error(codes.Unavailable, "overloaded")
+		}
+		if attempts == 3 {
+			return false, status.Error(codes.InvalidArgument, "unknown cargo unit")
+		}
+		return false, nil
+	})
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	lc, _ := connectOverHTTP(t, server, WithRetryPolicy(policy))
+	ctx := context.Background()
+
+	if moveErr := lc.MoveUnit(ctx, &logistics_v1.MoveUnitRequest{CargoUnitId: 1}); moveErr != nil {
+		t.Fatalf("Not expected error when moving unit after retry, error: %v", moveErr)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts of the first move, but got %d", attempts)
+	}
+
+	moveErr := lc.MoveUnit(ctx, &logistics_v1.MoveUnitRequest{CargoUnitId: 2})
+	if code := status.Code(moveErr); code != codes.InvalidArgument {
+		t.Errorf("Expected %s, but got %v", codes.InvalidArgument, moveErr)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected the second move not to be retried, but got %d attempts", attempts)
+	}
+
+	if _, openErr := lc.OpenMoveUnitsStream(ctx, 10); status.Code(openErr) != codes.Unimplemented {
+		t.Errorf("Expected %s when opening stream, but got %v", codes.Unimplemented, openErr)
+	}
+}
+
+func TestHTTPTransportSendsCredentials(t *testing.T) {
+	lc, headers := connectOverHTTP(t, fakeserver.New(),
+		WithPerRPCCredentials(BearerToken{Token: "secret", AllowInsecure: true}),
+		WithPerRPCCredentials(APIKey{Header: "X-Tenant-Key", Key: "tenant", AllowInsecure: true}),
+	)
+
+	if _, reportErr := lc.MetricsReport(context.Background()); reportErr != nil {
+		t.Fatalf("Not expected error when requesting report, error: %v", reportErr)
+	}
+
+	received := headers()
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 request, but got %d", len(received))
+	}
+	if got := received[0].Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Expected bearer token, but got %q", got)
+	}
+	if got := received[0].Get("Grpc-Metadata-X-Tenant-Key"); got != "tenant" {
+		t.Errorf("Expected API key, but got %q", got)
+	}
+}
+
+func TestHTTPTransportCredentialsRequireTLS(t *testing.T) {
+	lc := NewLogisticsClient(WithHTTPTransport(nil), WithPerRPCCredentials(BearerToken{Token: "secret"}))
+	if connErr := lc.Connect("localhost:0", context.Background()); connErr == nil {
+		_ = lc.Disconnect()
+		t.Errorf("Expected error when connecting with credentials without TLS")
+	}
+}
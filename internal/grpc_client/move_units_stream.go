@@ -0,0 +1,130 @@
+package grpc_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMoveBatchSize is used when stream is opened with non-positive batch size
+const DefaultMoveBatchSize = 1000
+
+// MoveUnitsStream sends unit moves in batches over a single StreamMoveUnits call
+type MoveUnitsStream struct {
+	stream    logistics_v1.LogisticsEngineAPI_StreamMoveUnitsClient
+	batchSize int
+	batchID   uint64
+}
+
+// OpenMoveUnitsStream starts StreamMoveUnits call, moves sent through it are split into batches of batchSize
+func (lc *APILogisticsClient) OpenMoveUnitsStream(ctx context.Context, batchSize int) (*MoveUnitsStream, error) {
+	stream, streamErr := lc.apiClientGRPC.StreamMoveUnits(ctx)
+	if streamErr != nil {
+		return nil, streamErr
+	}
+
+	if batchSize <= 0 {
+		batchSize = DefaultMoveBatchSize
+	}
+
+	return &MoveUnitsStream{stream: stream, batchSize: batchSize}, nil
+}
+
+// Send all moves of a tick and wait until every batch is acknowledged.
+// unitErrs holds per-unit rejections keyed by cargo unit ID, streamErr is set when the stream itself is broken
+// and must not be used anymore.
+func (s *MoveUnitsStream) Send(moves []*logistics_v1.MoveUnitRequest) (unitErrs map[int64]error, streamErr error) {
+	unitErrs = make(map[int64]error)
+	if len(moves) == 0 {
+		return unitErrs, nil
+	}
+
+	var batches []*logistics_v1.MoveUnitsBatch
+	for start := 0; start < len(moves); start += s.batchSize {
+		end := min(start+s.batchSize, len(moves))
+
+		s.batchID++
+		batches = append(batches, &logistics_v1.MoveUnitsBatch{BatchId: s.batchID, Moves: moves[start:end]})
+	}
+
+	// Acks are received concurrently with sending, so neither side blocks on a full flow control window
+	recvDone := make(chan error, 1)
+	go func() {
+		recvDone <- s.receiveAcks(batches, unitErrs)
+	}()
+
+	for _, batch := range batches {
+		if sendErr := s.stream.Send(batch); sendErr != nil {
+			// Send only reports io.EOF on a broken stream, the actual status comes from Recv
+			if recvErr := <-recvDone; recvErr != nil {
+				return nil, recvErr
+			}
+
+			return nil, sendErr
+		}
+	}
+
+	if recvErr := <-recvDone; recvErr != nil {
+		return nil, recvErr
+	}
+
+	return unitErrs, nil
+}
+
+// Close the sending side and wait for the server to finish the stream
+func (s *MoveUnitsStream) Close() error {
+	if closeErr := s.stream.CloseSend(); closeErr != nil {
+		return closeErr
+	}
+
+	for {
+		if _, recvErr := s.stream.Recv(); recvErr != nil {
+			if errors.Is(recvErr, io.EOF) {
+				return nil
+			}
+
+			return recvErr
+		}
+	}
+}
+
+func (s *MoveUnitsStream) receiveAcks(batches []*logistics_v1.MoveUnitsBatch, unitErrs map[int64]error) error {
+	pending := make(map[uint64]*logistics_v1.MoveUnitsBatch, len(batches))
+	for _, batch := range batches {
+		pending[batch.BatchId] = batch
+	}
+
+	for len(pending) > 0 {
+		ack, recvErr := s.stream.Recv()
+		if recvErr != nil {
+			return recvErr
+		}
+
+		batch, ok := pending[ack.BatchId]
+		if !ok {
+			return fmt.Errorf("received ack for unknown batch %d", ack.BatchId)
+		}
+		delete(pending, ack.BatchId)
+
+		acknowledged := make(map[int64]bool, len(ack.Results))
+		for _, result := range ack.Results {
+			acknowledged[result.CargoUnitId] = true
+			if codes.Code(result.Code) != codes.OK {
+				unitErrs[result.CargoUnitId] = status.Error(codes.Code(result.Code), result.Message)
+			}
+		}
+
+		for _, move := range batch.Moves {
+			if !acknowledged[move.CargoUnitId] {
+				unitErrs[move.CargoUnitId] = status.Errorf(codes.DataLoss, "move missing in ack of batch %d", ack.BatchId)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package grpc_client
+
+import (
+	"io"
+	"testing"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeMovesStream answers every sent batch with the ack built by ack, it stands in for StreamMoveUnits call
+type fakeMovesStream struct {
+	grpc.ClientStream
+	ack  func(batch *logistics_v1.MoveUnitsBatch) *logistics_v1.MoveUnitsAck
+	acks chan *logistics_v1.MoveUnitsAck
+	sent []*logistics_v1.MoveUnitsBatch
+}
+
+func newFakeMovesStream(ack func(batch *logistics_v1.MoveUnitsBatch) *logistics_v1.MoveUnitsAck) *fakeMovesStream {
+	return &fakeMovesStream{ack: ack, acks: make(chan *logistics_v1.MoveUnitsAck, 100)}
+}
+
+func (s *fakeMovesStream) Send(batch *logistics_v1.MoveUnitsBatch) error {
+	s.sent = append(s.sent, batch)
+	s.acks <- s.ack(batch)
+	return nil
+}
+
+func (s *fakeMovesStream) Recv() (*logistics_v1.MoveUnitsAck, error) {
+	ack, ok := <-s.acks
+	if !ok {
+		return nil, io.EOF
+	}
+	return ack, nil
+}
+
+func (s *fakeMovesStream) CloseSend() error {
+	close(s.acks)
+	return nil
+}
+
+// ackAll moves of the batch as accepted
+func ackAll(batch *logistics_v1.MoveUnitsBatch) *logistics_v1.MoveUnitsAck {
+	ack := &logistics_v1.MoveUnitsAck{BatchId: batch.BatchId}
+	for _, move := range batch.Moves {
+		ack.Results = append(ack.Results, &logistics_v1.MoveUnitResult{CargoUnitId: move.CargoUnitId})
+	}
+	return ack
+}
+
+func unitMoves(count int) []*logistics_v1.MoveUnitRequest {
+	moves := make([]*logistics_v1.MoveUnitRequest, count)
+	for i := range moves {
+		moves[i] = &logistics_v1.MoveUnitRequest{CargoUnitId: int64(i + 1)}
+	}
+	return moves
+}
+
+func TestMoveUnitsStreamSplitsMovesIntoBatches(t *testing.T) {
+	fake := newFakeMovesStream(ackAll)
+	s := &MoveUnitsStream{stream: fake, batchSize: 2}
+
+	for tick := 0; tick < 2; tick++ {
+		unitErrs, streamErr := s.Send(unitMoves(5))
+		if streamErr != nil || len(unitErrs) != 0 {
+			t.Fatalf("Not expected error when every move is accepted, unit errors %v, error: %v", unitErrs, streamErr)
+		}
+	}
+
+	// Batch IDs keep growing across ticks, so acks of different ticks can't be mixed up
+	expected := []int{2, 2, 1, 2, 2, 1}
+	if len(fake.sent) != len(expected) {
+		t.Fatalf("Expected %d batches, but got %d", len(expected), len(fake.sent))
+	}
+	for i, batch := range fake.sent {
+		if batch.BatchId != uint64(i+1) || len(batch.Moves) != expected[i] {
+			t.Errorf("Expected batch %d with %d moves, but got batch %d with %d moves", i+1, expected[i], batch.BatchId, len(batch.Moves))
+		}
+	}
+
+	if closeErr := s.Close(); closeErr != nil {
+		t.Errorf("Not expected error when closing stream, error: %v", closeErr)
+	}
+}
+
+func TestMoveUnitsStreamReportsRejectedMoves(t *testing.T) {
+	fake := newFakeMovesStream(func(batch *logistics_v1.MoveUnitsBatch) *logistics_v1.MoveUnitsAck {
+		ack := ackAll(batch)
+		for _, result := range ack.Results {
+			if result.CargoUnitId == 2 {
+				result.Code = int32(codes.InvalidArgument)
+				result.Message = "out of grid"
+			}
+		}
+		return ack
+	})
+	s := &MoveUnitsStream{stream: fake, batchSize: 2}
+
+	unitErrs, streamErr := s.Send(unitMoves(3))
+	if streamErr != nil {
+		t.Fatalf("Not expected error when a move is rejected, error: %v", streamErr)
+	}
+	if len(unitErrs) != 1 || status.Code(unitErrs[2]) != codes.InvalidArgument {
+		t.Errorf("Expected move of unit 2 to fail with %s, but got %v", codes.InvalidArgument, unitErrs)
+	}
+}
+
+func TestMoveUnitsStreamReportsMissingAcksAsDataLoss(t *testing.T) {
+	// API acknowledges the batch but drops the result of its last move
+	fake := newFakeMovesStream(func(batch *logistics_v1.MoveUnitsBatch) *logistics_v1.MoveUnitsAck {
+		ack := ackAll(batch)
+		ack.Results = ack.Results[:len(ack.Results)-1]
+		return ack
+	})
+	s := &MoveUnitsStream{stream: fake, batchSize: 2}
+
+	unitErrs, streamErr := s.Send(unitMoves(3))
+	if streamErr != nil {
+		t.Fatalf("Not expected error when results are missing in acks, error: %v", streamErr)
+	}
+	if len(unitErrs) != 2 || status.Code(unitErrs[2]) != codes.DataLoss || status.Code(unitErrs[3]) != codes.DataLoss {
+		t.Errorf("Expected moves of units 2 and 3 to fail with %s, but got %v", codes.DataLoss, unitErrs)
+	}
+}
+
+func TestMoveUnitsStreamFailsOnAckOfUnknownBatch(t *testing.T) {
+	fake := newFakeMovesStream(func(batch *logistics_v1.MoveUnitsBatch) *logistics_v1.MoveUnitsAck {
+		return &logistics_v1.MoveUnitsAck{BatchId: batch.BatchId + 100}
+	})
+	s := &MoveUnitsStream{stream: fake, batchSize: 2}
+
+	if _, streamErr := s.Send(unitMoves(1)); streamErr == nil {
+		t.Errorf("Expected error when ack of unknown batch is received, but got nil")
+	}
+}
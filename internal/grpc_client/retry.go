@@ -0,0 +1,135 @@
+package grpc_client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy of unary calls, a call is retried only when it failed with one of RetryableCodes
+type RetryPolicy struct {
+	// MaxAttempts including the first one, 1 or less disables retries
+	MaxAttempts int
+	// InitialBackoff before the first retry, every next backoff is Multiplier times longer up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter is a fraction of the backoff it is randomly changed by in both directions, from 0 to 1
+	Jitter float64
+	// Seed of the jitter source, a run passes its own seed so its retries are spread the same way when it is reproduced
+	Seed int64
+
+	RetryableCodes []codes.Code
+
+	// AttemptTimeout bounds every attempt separately, zero means only the call context bounds it
+	AttemptTimeout time.Duration
+}
+
+// DefaultRetryPolicy retries transient failures up to 4 times within about a second
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableCodes: []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded},
+	}
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy of unary calls
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(lc *APILogisticsClient) {
+		lc.retryPolicy = policy
+	}
+}
+
+// ParseRetryableCodes from comma separated names of gRPC status codes, such as UNAVAILABLE,ABORTED
+func ParseRetryableCodes(names string) ([]codes.Code, error) {
+	var result []codes.Code
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		var code codes.Code
+		if unmarshalErr := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(name)))); unmarshalErr != nil {
+			return nil, fmt.Errorf("unknown gRPC status code %q", name)
+		}
+		result = append(result, code)
+	}
+
+	return result, nil
+}
+
+// Backoff before the retry following the given attempt, attempts start at 1
+func (p RetryPolicy) Backoff(attempt int, rnd *rand.Rand) time.Duration {
+	backoff := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	backoff = min(backoff, float64(p.MaxBackoff))
+	if p.Jitter > 0 {
+		backoff += backoff * p.Jitter * (2*rnd.Float64() - 1)
+	}
+
+	return time.Duration(backoff)
+}
+
+// Retryable reports if a call failed with err may be sent again
+func (p RetryPolicy) Retryable(err error) bool {
+	code := status.Code(err)
+	for _, retryable := range p.RetryableCodes {
+		if code == retryable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// retryInterceptor resends unary calls according to the policy, requests are sent unchanged,
+// so idempotency keys in them let API drop calls it already handled
+func (p RetryPolicy) retryInterceptor() grpc.UnaryClientInterceptor {
+	var rndMu sync.Mutex
+	rnd := rand.New(rand.NewSource(p.Seed))
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		for attempt := 1; ; attempt++ {
+			callErr := p.invoke(ctx, method, req, reply, cc, invoker, opts...)
+			if callErr == nil || attempt >= p.MaxAttempts || !p.Retryable(callErr) {
+				return callErr
+			}
+
+			rndMu.Lock()
+			backoff := p.Backoff(attempt, rnd)
+			rndMu.Unlock()
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return callErr
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+func (p RetryPolicy) invoke(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if p.AttemptTimeout <= 0 {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, p.AttemptTimeout)
+	defer cancel()
+
+	return invoker(attemptCtx, method, req, reply, cc, opts...)
+}
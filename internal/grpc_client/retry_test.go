@@ -0,0 +1,79 @@
+package grpc_client
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBackoffGrowsUpToMaxBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 100 * time.Millisecond, Multiplier: 2}
+	rnd := rand.New(rand.NewSource(1))
+
+	expected := []time.Duration{10, 20, 40, 80, 100, 100}
+	for i, backoff := range expected {
+		if got := policy.Backoff(i+1, rnd); got != backoff*time.Millisecond {
+			t.Errorf("Expected backoff of %v after attempt %d, but got %v", backoff*time.Millisecond, i+1, got)
+		}
+	}
+}
+
+func TestBackoffJitterStaysWithinBounds(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2, Jitter: 0.2}
+	rnd := rand.New(rand.NewSource(1))
+
+	var shorter, longer bool
+	for i := 0; i < 1000; i++ {
+		backoff := policy.Backoff(2, rnd)
+		if backoff < 160*time.Millisecond || backoff > 240*time.Millisecond {
+			t.Fatalf("Expected backoff within 20%% of 200ms, but got %v", backoff)
+		}
+		shorter = shorter || backoff < 200*time.Millisecond
+		longer = longer || backoff > 200*time.Millisecond
+	}
+	if !shorter || !longer {
+		t.Errorf("Expected jitter to make backoffs both shorter and longer")
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	policy := RetryPolicy{RetryableCodes: []codes.Code{codes.Unavailable, codes.Aborted}}
+
+	tests := []struct {
+		err       error
+		retryable bool
+	}{
+		{status.Error(codes.Unavailable, "down"), true},
+		{status.Error(codes.Aborted, "conflict"), true},
+		{status.Error(codes.InvalidArgument, "bad request"), false},
+		{status.Error(codes.DeadlineExceeded, "slow"), false},
+		{errors.New("not a status"), false},
+	}
+	for _, test := range tests {
+		if got := policy.Retryable(test.err); got != test.retryable {
+			t.Errorf("Expected %v retryable %v, but got %v", test.err, test.retryable, got)
+		}
+	}
+}
+
+func TestParseRetryableCodes(t *testing.T) {
+	parsed, parseErr := ParseRetryableCodes("UNAVAILABLE, deadline_exceeded,,ABORTED")
+	if parseErr != nil {
+		t.Fatalf("Not expected error when parsing retryable codes, error: %v", parseErr)
+	}
+	if expected := []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.Aborted}; !reflect.DeepEqual(parsed, expected) {
+		t.Errorf("Expected codes %v, but got %v", expected, parsed)
+	}
+
+	if parsed, parseErr := ParseRetryableCodes(""); parseErr != nil || len(parsed) != 0 {
+		t.Errorf("Expected no codes of an empty list, but got %v and %v", parsed, parseErr)
+	}
+	if _, parseErr := ParseRetryableCodes("UNAVAILABLE,SOMETIMES"); parseErr == nil {
+		t.Errorf("Expected error when parsing an unknown code")
+	}
+}
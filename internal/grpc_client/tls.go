@@ -0,0 +1,74 @@
+package grpc_client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// TLSConfig of the connection to API, files are PEM encoded
+type TLSConfig struct {
+	// CAFile is a bundle of CAs the server certificate is verified with, empty uses system roots
+	CAFile string
+	// CertFile and KeyFile are the client certificate and key presented for mutual TLS, empty disables it
+	CertFile string
+	KeyFile  string
+	// ServerName overrides the host name the server certificate is verified against
+	ServerName string
+}
+
+// WithTransportCredentials used instead of plaintext when connecting to API
+func WithTransportCredentials(creds credentials.TransportCredentials) Option {
+	return func(lc *APILogisticsClient) {
+		lc.transportCredentials = creds
+	}
+}
+
+// LoadTLSCredentials reads certificates of the config from files
+func LoadTLSCredentials(cfg TLSConfig) (credentials.TransportCredentials, error) {
+	tlsConfig, tlsErr := LoadTLSConfig(cfg)
+	if tlsErr != nil {
+		return nil, tlsErr
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// LoadTLSConfig reads certificates of the config from files
+func LoadTLSConfig(cfg TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+	}
+
+	if len(cfg.CAFile) > 0 {
+		caPEM, readErr := os.ReadFile(cfg.CAFile)
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", readErr)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no PEM certificates in CA bundle %s", cfg.CAFile)
+		}
+	}
+
+	if len(cfg.CertFile) > 0 || len(cfg.KeyFile) > 0 {
+		if len(cfg.CertFile) == 0 || len(cfg.KeyFile) == 0 {
+			return nil, errors.New("mutual TLS needs both client certificate and key")
+		}
+
+		certificate, loadErr := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if loadErr != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", loadErr)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,222 @@
+package grpc_client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/fakeserver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const testServerName = "logistics.test"
+
+// testPKI is a CA generated for a test with a server and a client certificate issued by it
+type testPKI struct {
+	caFile     string
+	certFile   string
+	keyFile    string
+	serverCert tls.Certificate
+	caPool     *x509.CertPool
+}
+
+func newTestPKI(t *testing.T) testPKI {
+	t.Helper()
+
+	dir := t.TempDir()
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caKey := newTestKey(t)
+	caDER, caErr := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if caErr != nil {
+		t.Fatalf("Not expected error when creating CA, error: %v", caErr)
+	}
+	caCert, _ := x509.ParseCertificate(caDER)
+
+	issue := func(serial int64, name string, usage x509.ExtKeyUsage, dnsNames []string) ([]byte, *ecdsa.PrivateKey) {
+		key := newTestKey(t)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			DNSNames:     dnsNames,
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, issueErr := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		if issueErr != nil {
+			t.Fatalf("Not expected error when issuing certificate, error: %v", issueErr)
+		}
+
+		return der, key
+	}
+
+	serverDER, serverKey := issue(2, "Test server", x509.ExtKeyUsageServerAuth, []string{testServerName})
+	clientDER, clientKey := issue(3, "Test client", x509.ExtKeyUsageClientAuth, nil)
+
+	pki := testPKI{
+		caFile:     filepath.Join(dir, "ca.pem"),
+		certFile:   filepath.Join(dir, "client.pem"),
+		keyFile:    filepath.Join(dir, "client-key.pem"),
+		serverCert: tls.Certificate{Certificate: [][]byte{serverDER}, PrivateKey: serverKey},
+		caPool:     x509.NewCertPool(),
+	}
+	pki.caPool.AddCert(caCert)
+
+	writePEM(t, pki.caFile, "CERTIFICATE", caDER)
+	writePEM(t, pki.certFile, "CERTIFICATE", clientDER)
+	clientKeyDER, _ := x509.MarshalECPrivateKey(clientKey)
+	writePEM(t, pki.keyFile, "EC PRIVATE KEY", clientKeyDER)
+
+	return pki
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, keyErr := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if keyErr != nil {
+		t.Fatalf("Not expected error when generating key, error: %v", keyErr)
+	}
+
+	return key
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+
+	if writeErr := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); writeErr != nil {
+		t.Fatalf("Not expected error when writing %s, error: %v", path, writeErr)
+	}
+}
+
+// listenTLS serves a fake server that requires TLS, and client certificates when mutual is true
+func listenTLS(t *testing.T, pki testPKI, mutual bool) grpc.DialOption {
+	t.Helper()
+
+	serverConfig := &tls.Config{Certificates: []tls.Certificate{pki.serverCert}, MinVersion: tls.VersionTLS12}
+	if mutual {
+		serverConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		serverConfig.ClientCAs = pki.caPool
+	}
+
+	dialOption, stop := fakeserver.New().Listen(grpc.Creds(credentials.NewTLS(serverConfig)))
+	t.Cleanup(stop)
+
+	return dialOption
+}
+
+func TestConnectOverTLS(t *testing.T) {
+	pki := newTestPKI(t)
+
+	tests := []struct {
+		name      string
+		mutual    bool
+		tlsConfig TLSConfig
+		expectErr bool
+	}{
+		{
+			name:      "TLS",
+			tlsConfig: TLSConfig{CAFile: pki.caFile, ServerName: testServerName},
+		},
+		{
+			name:      "mutual TLS",
+			mutual:    true,
+			tlsConfig: TLSConfig{CAFile: pki.caFile, CertFile: pki.certFile, KeyFile: pki.keyFile, ServerName: testServerName},
+		},
+		{
+			name:      "mutual TLS without client certificate",
+			mutual:    true,
+			tlsConfig: TLSConfig{CAFile: pki.caFile, ServerName: testServerName},
+			expectErr: true,
+		},
+		{
+			name:      "server name does not match certificate",
+			tlsConfig: TLSConfig{CAFile: pki.caFile, ServerName: "other.test"},
+			expectErr: true,
+		},
+		{
+			name:      "server certificate from unknown CA",
+			tlsConfig: TLSConfig{ServerName: testServerName},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialOption := listenTLS(t, pki, tt.mutual)
+
+			creds, credsErr := LoadTLSCredentials(tt.tlsConfig)
+			if credsErr != nil {
+				t.Fatalf("Not expected error when loading credentials, error: %v", credsErr)
+			}
+
+			noRetries := DefaultRetryPolicy()
+			noRetries.MaxAttempts = 1
+			lc := NewLogisticsClient(WithDialOptions(dialOption), WithTransportCredentials(creds), WithRetryPolicy(noRetries))
+
+			// A failed handshake is retried by the blocking dial until the context is done
+			timeout := 2 * time.Second
+			if tt.expectErr {
+				timeout = 300 * time.Millisecond
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			// A failed handshake may only show up on the first call, so a call is made either way
+			callErr := lc.Connect("bufnet", ctx)
+			if callErr == nil {
+				defer lc.Disconnect()
+				_, callErr = lc.MetricsReport(ctx)
+			}
+
+			if tt.expectErr && callErr == nil {
+				t.Errorf("Expected error when calling API")
+			}
+			if !tt.expectErr && callErr != nil {
+				t.Errorf("Not expected error when calling API, error: %v", callErr)
+			}
+		})
+	}
+}
+
+func TestLoadTLSCredentialsErrors(t *testing.T) {
+	pki := newTestPKI(t)
+
+	tests := []struct {
+		name      string
+		tlsConfig TLSConfig
+	}{
+		{name: "missing CA bundle", tlsConfig: TLSConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")}},
+		{name: "CA bundle without certificates", tlsConfig: TLSConfig{CAFile: pki.keyFile}},
+		{name: "client certificate without key", tlsConfig: TLSConfig{CertFile: pki.certFile}},
+		{name: "client key does not match certificate", tlsConfig: TLSConfig{CertFile: pki.caFile, KeyFile: pki.keyFile}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, credsErr := LoadTLSCredentials(tt.tlsConfig); credsErr == nil {
+				t.Errorf("Expected error when loading credentials")
+			}
+		})
+	}
+}
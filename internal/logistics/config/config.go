@@ -1,19 +1,189 @@
 package config
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
 	envClientServiceHost = "CLIENT_SERVICE_HOST"
 	envClientServicePort = "CLIENT_SERVICE_PORT"
+	envClientSeed        = "CLIENT_SEED"
+
+	envClientTransport      = "CLIENT_TRANSPORT"
+	envClientConnectTimeout = "CLIENT_CONNECT_TIMEOUT"
+	envClientCallTimeout    = "CLIENT_CALL_TIMEOUT"
+
+	envClientTLS           = "CLIENT_TLS"
+	envClientTLSCAFile     = "CLIENT_TLS_CA_FILE"
+	envClientTLSCertFile   = "CLIENT_TLS_CERT_FILE"
+	envClientTLSKeyFile    = "CLIENT_TLS_KEY_FILE"
+	envClientTLSServerName = "CLIENT_TLS_SERVER_NAME"
+
+	envClientAuthToken        = "CLIENT_AUTH_TOKEN"
+	envClientAuthTokenFile    = "CLIENT_AUTH_TOKEN_FILE"
+	envClientAuthAPIKey       = "CLIENT_AUTH_API_KEY"
+	envClientAuthAPIKeyHeader = "CLIENT_AUTH_API_KEY_HEADER"
+	envClientAuthInsecure     = "CLIENT_AUTH_INSECURE"
+
+	envClientWarehouses = "CLIENT_WAREHOUSES"
+	envClientCargoUnits = "CLIENT_CARGO_UNITS"
+
+	envClientShipmentDropOffs = "CLIENT_SHIPMENT_DROP_OFFS"
+
+	envClientOrders                = "CLIENT_ORDERS"
+	envClientOrderRate             = "CLIENT_ORDER_RATE"
+	envClientOrderBurstProbability = "CLIENT_ORDER_BURST_PROBABILITY"
+	envClientOrderBurstSize        = "CLIENT_ORDER_BURST_SIZE"
+	envClientOrderDeadline         = "CLIENT_ORDER_DEADLINE"
+
+	envClientRouting       = "CLIENT_ROUTING"
+	envClientUnitMaxWeight = "CLIENT_UNIT_MAX_WEIGHT"
+	envClientUnitMaxVolume = "CLIENT_UNIT_MAX_VOLUME"
+
+	envClientStreamMoves   = "CLIENT_STREAM_MOVES"
+	envClientMoveBatchSize = "CLIENT_MOVE_BATCH_SIZE"
+
+	envClientClockMode    = "CLIENT_CLOCK_MODE"
+	envClientTickDuration = "CLIENT_TICK_DURATION"
+	envClientClockSpeedup = "CLIENT_CLOCK_SPEEDUP"
+	envClientWorkers      = "CLIENT_WORKERS"
+
+	envClientRetryMaxAttempts    = "CLIENT_RETRY_MAX_ATTEMPTS"
+	envClientRetryInitialBackoff = "CLIENT_RETRY_INITIAL_BACKOFF"
+	envClientRetryMaxBackoff     = "CLIENT_RETRY_MAX_BACKOFF"
+	envClientRetryJitter         = "CLIENT_RETRY_JITTER"
+	envClientRetryCodes          = "CLIENT_RETRY_CODES"
+
+	envClientMetricsAddr = "CLIENT_METRICS_ADDR"
+
+	envClientReportFormat = "CLIENT_REPORT_FORMAT"
+	envClientReportFile   = "CLIENT_REPORT_FILE"
+
+	envClientScenario       = "CLIENT_SCENARIO"
+	envClientExportScenario = "CLIENT_EXPORT_SCENARIO"
+
+	envClientRecordFile = "CLIENT_RECORD_FILE"
+	envClientDryRun     = "CLIENT_DRY_RUN"
+)
+
+// Transports API can be called over
+const (
+	TransportGRPC = "grpc"
+	TransportHTTP = "http"
+)
+
+// Routings orders are dispatched to free units by
+const (
+	// RoutingGreedy gives every order to the closest free unit, a unit carries one order at a time
+	RoutingGreedy = "greedy"
+	// RoutingVRP plans routes carrying many orders at once for all free units, see vrp.Solve
+	RoutingVRP = "vrp"
 )
 
 // ClientAppConfig ...
 type ClientAppConfig struct {
 	Host string
 	Port string
+
+	// Transport API is called over: grpc, or http for the HTTP/JSON gateway listening on Host and Port
+	Transport string
+	// ConnectTimeout bounds connecting to API
+	ConnectTimeout time.Duration
+	// CallTimeout bounds every attempt of a unary call, zero means no limit
+	CallTimeout time.Duration
+
+	// TLS connects to API over TLS instead of plaintext
+	TLS bool
+	// TLSCAFile is PEM bundle of CAs the API certificate is verified with, empty uses system roots
+	TLSCAFile string
+	// TLSCertFile and TLSKeyFile are PEM client certificate and key for mutual TLS, empty disables it
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSServerName overrides the host name the API certificate is verified against
+	TLSServerName string
+
+	// AuthToken is a static bearer token sent with every call
+	AuthToken string
+	// AuthTokenFile holds a bearer token sent with every call, it is read again when it changes
+	AuthTokenFile string
+	// AuthAPIKey is sent with every call in AuthAPIKeyHeader
+	AuthAPIKey       string
+	AuthAPIKeyHeader string
+	// AuthInsecure allows credentials to be sent without TLS
+	AuthInsecure bool
+
+	// Seed drives every random decision of a run, so a run can be reproduced from a logged seed
+	Seed int64
+	// Warehouses and CargoUnits to populate the world with, zero picks a random number
+	Warehouses uint
+	CargoUnits uint
+	// ShipmentDropOffs is the maximum number of drop-off warehouses of every shipment, zero sends units
+	// to their nearest warehouse without a shipment
+	ShipmentDropOffs uint
+
+	// Orders to generate and dispatch to free units, zero sends units to their nearest warehouse without orders
+	Orders uint
+	// OrderRate is the mean number of orders arriving during a tick, arrivals are Poisson distributed
+	OrderRate float64
+	// OrderBurstProbability is the chance of a burst of orders during a tick, a burst brings OrderBurstSize
+	// more orders on average
+	OrderBurstProbability float64
+	OrderBurstSize        float64
+	// OrderDeadline of a normal priority order after its arrival in simulated time
+	OrderDeadline time.Duration
+	// Routing orders are dispatched by: greedy or vrp
+	Routing string
+	// UnitMaxWeight and UnitMaxVolume every generated cargo unit can carry at once, units of a scenario
+	// carry what the scenario sets
+	UnitMaxWeight float64
+	UnitMaxVolume float64
+
+	// StreamMoves sends moves of every tick in batches over StreamMoveUnits instead of unary MoveUnit calls
+	StreamMoves bool
+	// MoveBatchSize is the maximum number of moves in one StreamMoveUnits batch
+	MoveBatchSize int
+
+	// ClockMode is realtime, accelerated or asap, see clock.Mode
+	ClockMode string
+	// TickDuration is simulated time of one tick, every unit moves one step per tick
+	TickDuration time.Duration
+	// ClockSpeedup is how many times faster than real time an accelerated clock runs
+	ClockSpeedup float64
+	// Workers is the maximum number of units handled concurrently during a tick
+	Workers int
+
+	// RetryMaxAttempts of a unary call including the first one, 1 disables retries
+	RetryMaxAttempts int
+	// RetryInitialBackoff before the first retry, it doubles with every retry up to RetryMaxBackoff
+	RetryInitialBackoff time.Duration
+	RetryMaxBackoff     time.Duration
+	// RetryJitter is a fraction of the backoff it is randomly changed by in both directions, from 0 to 1
+	RetryJitter float64
+	// RetryCodes are comma separated gRPC status codes of failed calls that are retried, such as UNAVAILABLE
+	RetryCodes string
+
+	// MetricsAddr to serve Prometheus /metrics on, empty disables the endpoint
+	MetricsAddr string
+
+	// ReportFormat of the run report: ascii, json, csv or markdown
+	ReportFormat string
+	// ReportFile to write the run report to, empty writes it to stdout
+	ReportFile string
+
+	// Scenario file to load the world from instead of populating a random one
+	Scenario string
+	// ExportScenario file to save the world to before the run starts
+	ExportScenario string
+
+	// RecordFile to record every request sent to API in, empty disables recording
+	RecordFile string
+	// DryRun simulates without API, requests are logged and answered locally
+	DryRun bool
 }
 
 // GetCombinedAddress with Host and Port
@@ -31,13 +201,266 @@ func (cfg *ClientAppConfig) LoadFromEnv() {
 	if len(cfg.Port) == 0 {
 		cfg.Port = "50051"
 	}
+
+	cfg.Transport = os.Getenv(envClientTransport)
+	if len(cfg.Transport) == 0 {
+		cfg.Transport = TransportGRPC
+	}
+	cfg.ConnectTimeout, _ = time.ParseDuration(os.Getenv(envClientConnectTimeout))
+	if cfg.ConnectTimeout <= 0 {
+		cfg.ConnectTimeout = 30 * time.Second
+	}
+	cfg.CallTimeout, _ = time.ParseDuration(os.Getenv(envClientCallTimeout))
+
+	cfg.TLS, _ = strconv.ParseBool(os.Getenv(envClientTLS))
+	cfg.TLSCAFile = os.Getenv(envClientTLSCAFile)
+	cfg.TLSCertFile = os.Getenv(envClientTLSCertFile)
+	cfg.TLSKeyFile = os.Getenv(envClientTLSKeyFile)
+	cfg.TLSServerName = os.Getenv(envClientTLSServerName)
+
+	cfg.AuthToken = os.Getenv(envClientAuthToken)
+	cfg.AuthTokenFile = os.Getenv(envClientAuthTokenFile)
+	cfg.AuthAPIKey = os.Getenv(envClientAuthAPIKey)
+	cfg.AuthAPIKeyHeader = os.Getenv(envClientAuthAPIKeyHeader)
+	if len(cfg.AuthAPIKeyHeader) == 0 {
+		cfg.AuthAPIKeyHeader = "x-api-key"
+	}
+	cfg.AuthInsecure, _ = strconv.ParseBool(os.Getenv(envClientAuthInsecure))
+
+	seed, seedErr := strconv.ParseInt(os.Getenv(envClientSeed), 10, 64)
+	if seedErr != nil {
+		seed = time.Now().UnixNano()
+	}
+	cfg.Seed = seed
+
+	warehouses, _ := strconv.ParseUint(os.Getenv(envClientWarehouses), 10, 32)
+	cfg.Warehouses = uint(warehouses)
+	cargoUnits, _ := strconv.ParseUint(os.Getenv(envClientCargoUnits), 10, 32)
+	cfg.CargoUnits = uint(cargoUnits)
+	shipmentDropOffs, _ := strconv.ParseUint(os.Getenv(envClientShipmentDropOffs), 10, 32)
+	cfg.ShipmentDropOffs = uint(shipmentDropOffs)
+
+	orders, _ := strconv.ParseUint(os.Getenv(envClientOrders), 10, 32)
+	cfg.Orders = uint(orders)
+	cfg.OrderRate, _ = strconv.ParseFloat(os.Getenv(envClientOrderRate), 64)
+	if cfg.OrderRate <= 0 {
+		cfg.OrderRate = 1
+	}
+	cfg.OrderBurstProbability, _ = strconv.ParseFloat(os.Getenv(envClientOrderBurstProbability), 64)
+	cfg.OrderBurstSize, _ = strconv.ParseFloat(os.Getenv(envClientOrderBurstSize), 64)
+	if cfg.OrderBurstSize <= 0 {
+		cfg.OrderBurstSize = 10
+	}
+	cfg.OrderDeadline, _ = time.ParseDuration(os.Getenv(envClientOrderDeadline))
+	if cfg.OrderDeadline <= 0 {
+		cfg.OrderDeadline = 10 * time.Minute
+	}
+	cfg.Routing = os.Getenv(envClientRouting)
+	if len(cfg.Routing) == 0 {
+		cfg.Routing = RoutingGreedy
+	}
+	cfg.UnitMaxWeight, _ = strconv.ParseFloat(os.Getenv(envClientUnitMaxWeight), 64)
+	if cfg.UnitMaxWeight <= 0 {
+		cfg.UnitMaxWeight = 2000
+	}
+	cfg.UnitMaxVolume, _ = strconv.ParseFloat(os.Getenv(envClientUnitMaxVolume), 64)
+	if cfg.UnitMaxVolume <= 0 {
+		cfg.UnitMaxVolume = 20
+	}
+
+	cfg.StreamMoves, _ = strconv.ParseBool(os.Getenv(envClientStreamMoves))
+	cfg.MoveBatchSize, _ = strconv.Atoi(os.Getenv(envClientMoveBatchSize))
+	if cfg.MoveBatchSize <= 0 {
+		cfg.MoveBatchSize = 1000
+	}
+
+	cfg.ClockMode = os.Getenv(envClientClockMode)
+	if len(cfg.ClockMode) == 0 {
+		cfg.ClockMode = "asap"
+	}
+	cfg.TickDuration, _ = time.ParseDuration(os.Getenv(envClientTickDuration))
+	if cfg.TickDuration <= 0 {
+		cfg.TickDuration = time.Second
+	}
+	cfg.ClockSpeedup, _ = strconv.ParseFloat(os.Getenv(envClientClockSpeedup), 64)
+	if cfg.ClockSpeedup <= 0 {
+		cfg.ClockSpeedup = 10
+	}
+	cfg.Workers, _ = strconv.Atoi(os.Getenv(envClientWorkers))
+	if cfg.Workers <= 0 {
+		cfg.Workers = 64
+	}
+
+	cfg.RetryMaxAttempts, _ = strconv.Atoi(os.Getenv(envClientRetryMaxAttempts))
+	if cfg.RetryMaxAttempts <= 0 {
+		cfg.RetryMaxAttempts = 5
+	}
+	cfg.RetryInitialBackoff, _ = time.ParseDuration(os.Getenv(envClientRetryInitialBackoff))
+	if cfg.RetryInitialBackoff <= 0 {
+		cfg.RetryInitialBackoff = 50 * time.Millisecond
+	}
+	cfg.RetryMaxBackoff, _ = time.ParseDuration(os.Getenv(envClientRetryMaxBackoff))
+	if cfg.RetryMaxBackoff <= 0 {
+		cfg.RetryMaxBackoff = time.Second
+	}
+	cfg.RetryJitter = 0.2
+	if jitter, parseErr := strconv.ParseFloat(os.Getenv(envClientRetryJitter), 64); parseErr == nil {
+		cfg.RetryJitter = jitter
+	}
+	retryCodes, ok := os.LookupEnv(envClientRetryCodes)
+	if !ok {
+		retryCodes = "UNAVAILABLE,RESOURCE_EXHAUSTED,ABORTED,DEADLINE_EXCEEDED"
+	}
+	cfg.RetryCodes = retryCodes
+
+	cfg.MetricsAddr = os.Getenv(envClientMetricsAddr)
+
+	cfg.ReportFormat = os.Getenv(envClientReportFormat)
+	if len(cfg.ReportFormat) == 0 {
+		cfg.ReportFormat = "ascii"
+	}
+	cfg.ReportFile = os.Getenv(envClientReportFile)
+
+	cfg.Scenario = os.Getenv(envClientScenario)
+	cfg.ExportScenario = os.Getenv(envClientExportScenario)
+
+	cfg.RecordFile = os.Getenv(envClientRecordFile)
+	cfg.DryRun, _ = strconv.ParseBool(os.Getenv(envClientDryRun))
+}
+
+// RegisterConnectionFlags binds command line flags of API address and timeouts
+func (cfg *ClientAppConfig) RegisterConnectionFlags(fs *flag.FlagSet) {
+	fs.StringVar(&cfg.Host, "host", cfg.Host, "API host (env "+envClientServiceHost+")")
+	fs.StringVar(&cfg.Port, "port", cfg.Port, "API port (env "+envClientServicePort+")")
+	fs.StringVar(&cfg.Transport, "transport", cfg.Transport, "transport API is called over: grpc or http (env "+envClientTransport+")")
+	fs.DurationVar(&cfg.ConnectTimeout, "connect-timeout", cfg.ConnectTimeout, "timeout of connecting to API (env "+envClientConnectTimeout+")")
+	fs.DurationVar(&cfg.CallTimeout, "call-timeout", cfg.CallTimeout, "timeout of every call attempt, 0 means no limit (env "+envClientCallTimeout+")")
+	fs.BoolVar(&cfg.TLS, "tls", cfg.TLS, "connect to API over TLS (env "+envClientTLS+")")
+	fs.StringVar(&cfg.TLSCAFile, "tls-ca-file", cfg.TLSCAFile, "PEM CA bundle to verify API certificate with, empty uses system roots (env "+envClientTLSCAFile+")")
+	fs.StringVar(&cfg.TLSCertFile, "tls-cert-file", cfg.TLSCertFile, "PEM client certificate for mutual TLS (env "+envClientTLSCertFile+")")
+	fs.StringVar(&cfg.TLSKeyFile, "tls-key-file", cfg.TLSKeyFile, "PEM client key for mutual TLS (env "+envClientTLSKeyFile+")")
+	fs.StringVar(&cfg.TLSServerName, "tls-server-name", cfg.TLSServerName, "host name to verify API certificate against (env "+envClientTLSServerName+")")
+	fs.StringVar(&cfg.AuthToken, "auth-token", cfg.AuthToken, "bearer token sent with every call (env "+envClientAuthToken+")")
+	fs.StringVar(&cfg.AuthTokenFile, "auth-token-file", cfg.AuthTokenFile, "file with bearer token sent with every call, read again when it changes (env "+envClientAuthTokenFile+")")
+	fs.StringVar(&cfg.AuthAPIKey, "auth-api-key", cfg.AuthAPIKey, "API key sent with every call (env "+envClientAuthAPIKey+")")
+	fs.StringVar(&cfg.AuthAPIKeyHeader, "auth-api-key-header", cfg.AuthAPIKeyHeader, "header the API key is sent in (env "+envClientAuthAPIKeyHeader+")")
+	fs.BoolVar(&cfg.AuthInsecure, "auth-insecure", cfg.AuthInsecure, "allow credentials to be sent without TLS (env "+envClientAuthInsecure+")")
+}
+
+// RegisterWorldFlags binds command line flags of the seed and counts the world is populated with
+func (cfg *ClientAppConfig) RegisterWorldFlags(fs *flag.FlagSet) {
+	fs.Int64Var(&cfg.Seed, "seed", cfg.Seed, "seed for world generation and unit movements (env "+envClientSeed+")")
+	fs.UintVar(&cfg.Warehouses, "warehouses", cfg.Warehouses, "number of warehouses, 0 picks a random number (env "+envClientWarehouses+")")
+	fs.UintVar(&cfg.CargoUnits, "cargo-units", cfg.CargoUnits, "number of cargo units, 0 picks a random number (env "+envClientCargoUnits+")")
+	fs.UintVar(&cfg.ShipmentDropOffs, "shipment-drop-offs", cfg.ShipmentDropOffs, "maximum drop-off warehouses of every shipment, 0 sends units to their nearest warehouse (env "+envClientShipmentDropOffs+")")
+	fs.UintVar(&cfg.Orders, "orders", cfg.Orders, "number of orders to generate and dispatch to free units, 0 disables orders (env "+envClientOrders+")")
+	fs.Float64Var(&cfg.OrderRate, "order-rate", cfg.OrderRate, "mean number of orders arriving per tick (env "+envClientOrderRate+")")
+	fs.Float64Var(&cfg.OrderBurstProbability, "order-burst-probability", cfg.OrderBurstProbability, "chance of a burst of orders per tick (env "+envClientOrderBurstProbability+")")
+	fs.Float64Var(&cfg.OrderBurstSize, "order-burst-size", cfg.OrderBurstSize, "mean number of orders in a burst (env "+envClientOrderBurstSize+")")
+	fs.DurationVar(&cfg.OrderDeadline, "order-deadline", cfg.OrderDeadline, "simulated time to deliver a normal priority order in (env "+envClientOrderDeadline+")")
+	fs.StringVar(&cfg.Routing, "routing", cfg.Routing, "routing orders are dispatched by: greedy or vrp (env "+envClientRouting+")")
+	fs.Float64Var(&cfg.UnitMaxWeight, "unit-max-weight", cfg.UnitMaxWeight, "kilograms every generated cargo unit can carry at once (env "+envClientUnitMaxWeight+")")
+	fs.Float64Var(&cfg.UnitMaxVolume, "unit-max-volume", cfg.UnitMaxVolume, "cubic meters every generated cargo unit can carry at once (env "+envClientUnitMaxVolume+")")
+}
+
+// RegisterFlags binds command line flags that override values loaded from environment
+func (cfg *ClientAppConfig) RegisterFlags(fs *flag.FlagSet) {
+	cfg.RegisterConnectionFlags(fs)
+	cfg.RegisterWorldFlags(fs)
+	fs.BoolVar(&cfg.StreamMoves, "stream-moves", cfg.StreamMoves, "send moves in batches over StreamMoveUnits (env "+envClientStreamMoves+")")
+	fs.IntVar(&cfg.MoveBatchSize, "move-batch-size", cfg.MoveBatchSize, "maximum number of moves per batch (env "+envClientMoveBatchSize+")")
+	fs.StringVar(&cfg.ClockMode, "clock", cfg.ClockMode, "simulation clock mode: realtime, accelerated or asap (env "+envClientClockMode+")")
+	fs.DurationVar(&cfg.TickDuration, "tick", cfg.TickDuration, "simulated time of one tick (env "+envClientTickDuration+")")
+	fs.Float64Var(&cfg.ClockSpeedup, "speedup", cfg.ClockSpeedup, "how many times faster than real time the accelerated clock runs (env "+envClientClockSpeedup+")")
+	fs.IntVar(&cfg.Workers, "workers", cfg.Workers, "maximum number of units handled concurrently (env "+envClientWorkers+")")
+	fs.IntVar(&cfg.RetryMaxAttempts, "retry-max-attempts", cfg.RetryMaxAttempts, "attempts of a failed call including the first one, 1 disables retries (env "+envClientRetryMaxAttempts+")")
+	fs.DurationVar(&cfg.RetryInitialBackoff, "retry-initial-backoff", cfg.RetryInitialBackoff, "backoff before the first retry (env "+envClientRetryInitialBackoff+")")
+	fs.DurationVar(&cfg.RetryMaxBackoff, "retry-max-backoff", cfg.RetryMaxBackoff, "maximum backoff between retries (env "+envClientRetryMaxBackoff+")")
+	fs.Float64Var(&cfg.RetryJitter, "retry-jitter", cfg.RetryJitter, "fraction of the backoff it is randomly changed by, from 0 to 1 (env "+envClientRetryJitter+")")
+	fs.StringVar(&cfg.RetryCodes, "retry-codes", cfg.RetryCodes, "comma separated gRPC status codes of failed calls that are retried (env "+envClientRetryCodes+")")
+	fs.StringVar(&cfg.MetricsAddr, "metrics-addr", cfg.MetricsAddr, "address to serve Prometheus /metrics on, empty disables it (env "+envClientMetricsAddr+")")
+	fs.StringVar(&cfg.ReportFormat, "report-format", cfg.ReportFormat, "run report format: ascii, json, csv or markdown (env "+envClientReportFormat+")")
+	fs.StringVar(&cfg.ReportFile, "report-file", cfg.ReportFile, "file to write the run report to, empty writes it to stdout (env "+envClientReportFile+")")
+	fs.StringVar(&cfg.Scenario, "scenario", cfg.Scenario, "YAML or JSON scenario file to load the world from (env "+envClientScenario+")")
+	fs.StringVar(&cfg.ExportScenario, "export-scenario", cfg.ExportScenario, "YAML or JSON file to export the world to (env "+envClientExportScenario+")")
+	fs.StringVar(&cfg.RecordFile, "record", cfg.RecordFile, "JSONL file to record every request sent to API in (env "+envClientRecordFile+")")
+	fs.BoolVar(&cfg.DryRun, "dry-run", cfg.DryRun, "simulate without API, logging requests instead of sending them (env "+envClientDryRun+")")
+}
+
+// Setting is a single configuration value rendered as text
+type Setting struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Settings lists every configuration value in declaration order
+func (cfg *ClientAppConfig) Settings() []Setting {
+	return []Setting{
+		{Name: "Host", Value: cfg.Host},
+		{Name: "Port", Value: cfg.Port},
+		{Name: "Transport", Value: cfg.Transport},
+		{Name: "ConnectTimeout", Value: cfg.ConnectTimeout.String()},
+		{Name: "CallTimeout", Value: cfg.CallTimeout.String()},
+		{Name: "TLS", Value: strconv.FormatBool(cfg.TLS)},
+		{Name: "TLSCAFile", Value: cfg.TLSCAFile},
+		{Name: "TLSCertFile", Value: cfg.TLSCertFile},
+		{Name: "TLSKeyFile", Value: cfg.TLSKeyFile},
+		{Name: "TLSServerName", Value: cfg.TLSServerName},
+		{Name: "AuthToken", Value: secret(cfg.AuthToken)},
+		{Name: "AuthTokenFile", Value: cfg.AuthTokenFile},
+		{Name: "AuthAPIKey", Value: secret(cfg.AuthAPIKey)},
+		{Name: "AuthAPIKeyHeader", Value: cfg.AuthAPIKeyHeader},
+		{Name: "AuthInsecure", Value: strconv.FormatBool(cfg.AuthInsecure)},
+		{Name: "Seed", Value: strconv.FormatInt(cfg.Seed, 10)},
+		{Name: "Warehouses", Value: strconv.FormatUint(uint64(cfg.Warehouses), 10)},
+		{Name: "CargoUnits", Value: strconv.FormatUint(uint64(cfg.CargoUnits), 10)},
+		{Name: "ShipmentDropOffs", Value: strconv.FormatUint(uint64(cfg.ShipmentDropOffs), 10)},
+		{Name: "Orders", Value: strconv.FormatUint(uint64(cfg.Orders), 10)},
+		{Name: "OrderRate", Value: strconv.FormatFloat(cfg.OrderRate, 'g', -1, 64)},
+		{Name: "OrderBurstProbability", Value: strconv.FormatFloat(cfg.OrderBurstProbability, 'g', -1, 64)},
+		{Name: "OrderBurstSize", Value: strconv.FormatFloat(cfg.OrderBurstSize, 'g', -1, 64)},
+		{Name: "OrderDeadline", Value: cfg.OrderDeadline.String()},
+		{Name: "Routing", Value: cfg.Routing},
+		{Name: "UnitMaxWeight", Value: strconv.FormatFloat(cfg.UnitMaxWeight, 'g', -1, 64)},
+		{Name: "UnitMaxVolume", Value: strconv.FormatFloat(cfg.UnitMaxVolume, 'g', -1, 64)},
+		{Name: "StreamMoves", Value: strconv.FormatBool(cfg.StreamMoves)},
+		{Name: "MoveBatchSize", Value: strconv.Itoa(cfg.MoveBatchSize)},
+		{Name: "ClockMode", Value: cfg.ClockMode},
+		{Name: "TickDuration", Value: cfg.TickDuration.String()},
+		{Name: "ClockSpeedup", Value: strconv.FormatFloat(cfg.ClockSpeedup, 'g', -1, 64)},
+		{Name: "Workers", Value: strconv.Itoa(cfg.Workers)},
+		{Name: "RetryMaxAttempts", Value: strconv.Itoa(cfg.RetryMaxAttempts)},
+		{Name: "RetryInitialBackoff", Value: cfg.RetryInitialBackoff.String()},
+		{Name: "RetryMaxBackoff", Value: cfg.RetryMaxBackoff.String()},
+		{Name: "RetryJitter", Value: strconv.FormatFloat(cfg.RetryJitter, 'g', -1, 64)},
+		{Name: "RetryCodes", Value: cfg.RetryCodes},
+		{Name: "MetricsAddr", Value: cfg.MetricsAddr},
+		{Name: "Scenario", Value: cfg.Scenario},
+		{Name: "ExportScenario", Value: cfg.ExportScenario},
+		{Name: "RecordFile", Value: cfg.RecordFile},
+		{Name: "DryRun", Value: strconv.FormatBool(cfg.DryRun)},
+		{Name: "ReportFormat", Value: cfg.ReportFormat},
+		{Name: "ReportFile", Value: cfg.ReportFile},
+	}
 }
 
 // String impl
 func (cfg *ClientAppConfig) String() string {
-	return fmt.Sprintf(
-		"---Client Configuration---\nHost:%s\nPort:%s\n",
-		cfg.Host,
-		cfg.Port,
-	)
+	var builder strings.Builder
+
+	builder.WriteString("---Client Configuration---\n")
+	for _, setting := range cfg.Settings() {
+		builder.WriteString(fmt.Sprintf("%s:%s\n", setting.Name, setting.Value))
+	}
+
+	return builder.String()
+}
+
+// secret hides a set value, so it does not end up in logs and reports
+func secret(value string) string {
+	if len(value) == 0 {
+		return ""
+	}
+
+	return "********"
 }
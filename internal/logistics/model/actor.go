@@ -1,9 +1,69 @@
 package model
 
-// ActorType kind
+import "fmt"
+
+// ActorType kind, it is written as text in JSON and YAML
 type ActorType byte
 
 const (
-    Warehouses ActorType = iota
+    // Warehouses receive cargo units at their docks
+    Warehouses ActorType = iota + 1
+    // CargoUnits carry goods between warehouses
     CargoUnits
 )
+
+// String impl
+func (t ActorType) String() string {
+    switch t {
+    case Warehouses:
+        return "warehouse"
+    case CargoUnits:
+        return "cargo_unit"
+    }
+
+    return "unknown"
+}
+
+// MarshalText impl
+func (t ActorType) MarshalText() ([]byte, error) {
+    if t != Warehouses && t != CargoUnits {
+        return nil, fmt.Errorf("unknown actor type %d", t)
+    }
+
+    return []byte(t.String()), nil
+}
+
+// UnmarshalText impl
+func (t *ActorType) UnmarshalText(text []byte) error {
+    switch string(text) {
+    case Warehouses.String():
+        *t = Warehouses
+    case CargoUnits.String():
+        *t = CargoUnits
+    default:
+        return fmt.Errorf("unknown actor type %q, expected %s or %s", text, Warehouses, CargoUnits)
+    }
+
+    return nil
+}
+
+// ActorPayload holds fields of a single actor type, the payload of a node decides its type.
+// It is implemented by WarehouseCapacity and *CargoUnit only.
+type ActorPayload interface {
+    ActorType() ActorType
+    actorPayload()
+}
+
+// ActorType impl
+func (WarehouseCapacity) ActorType() ActorType {
+    return Warehouses
+}
+
+func (WarehouseCapacity) actorPayload() {}
+
+// ActorType impl
+func (*CargoUnit) ActorType() ActorType {
+    return CargoUnits
+}
+
+func (*CargoUnit) actorPayload() {}
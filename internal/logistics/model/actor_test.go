@@ -0,0 +1,55 @@
+package model
+
+import (
+    "encoding/json"
+    "testing"
+)
+
+func TestActorTypeJSON(t *testing.T) {
+    for _, actorType := range []ActorType{Warehouses, CargoUnits} {
+        data, marshalErr := json.Marshal(actorType)
+        if marshalErr != nil {
+            t.Fatalf("Not expected error when marshalling %s, error: %v", actorType, marshalErr)
+        }
+        if string(data) != `"`+actorType.String()+`"` {
+            t.Errorf("Expected %q, but got %s", actorType.String(), data)
+        }
+
+        var decoded ActorType
+        if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil {
+            t.Fatalf("Not expected error when unmarshalling %s, error: %v", data, unmarshalErr)
+        }
+        if decoded != actorType {
+            t.Errorf("Expected %s, but got %s", actorType, decoded)
+        }
+    }
+
+    var decoded ActorType
+    if unmarshalErr := json.Unmarshal([]byte(`"truck"`), &decoded); unmarshalErr == nil {
+        t.Errorf("Expected error when unmarshalling an unknown actor type, but got %s", decoded)
+    }
+    if _, marshalErr := json.Marshal(ActorType(0)); marshalErr == nil {
+        t.Errorf("Expected error when marshalling the zero actor type")
+    }
+}
+
+func TestGraphNodePayload(t *testing.T) {
+    warehouse := GraphNode{ID: 1, Payload: WarehouseCapacity{Capacity: 2}}
+    unit := GraphNode{ID: 2, Payload: NewCargoUnit(1, 1)}
+
+    if warehouse.Type() != Warehouses || unit.Type() != CargoUnits {
+        t.Errorf("Expected warehouse and cargo unit, but got %s and %s", warehouse.Type(), unit.Type())
+    }
+    if capacity, ok := warehouse.Warehouse(); !ok || capacity.Capacity != 2 {
+        t.Errorf("Expected warehouse capacity 2, but got %v", capacity)
+    }
+    if warehouse.CargoUnit() != nil {
+        t.Errorf("Expected no cargo unit state of a warehouse")
+    }
+    if _, ok := unit.Warehouse(); ok || unit.CargoUnit() == nil {
+        t.Errorf("Expected cargo unit state and no warehouse capacity of a cargo unit")
+    }
+    if empty := (GraphNode{}); empty.Type().String() != "unknown" {
+        t.Errorf("Expected unknown type of a node without payload, but got %s", empty.Type())
+    }
+}
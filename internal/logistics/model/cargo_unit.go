@@ -0,0 +1,93 @@
+package model
+
+// CargoUnitStatus of a cargo unit
+type CargoUnitStatus byte
+
+const (
+    // CargoUnitIdle unit has nowhere to go
+    CargoUnitIdle CargoUnitStatus = iota
+    // CargoUnitEnRoute unit drives to a warehouse
+    CargoUnitEnRoute
+    // CargoUnitLoading unit waits for goods to be loaded at a pickup warehouse
+    CargoUnitLoading
+    // CargoUnitUnloading unit waits for goods to be unloaded at a warehouse
+    CargoUnitUnloading
+    // CargoUnitBroken unit ran out of fuel and stays where it is
+    CargoUnitBroken
+)
+
+// String impl
+func (s CargoUnitStatus) String() string {
+    switch s {
+    case CargoUnitIdle:
+        return "idle"
+    case CargoUnitEnRoute:
+        return "en route"
+    case CargoUnitLoading:
+        return "loading"
+    case CargoUnitUnloading:
+        return "unloading"
+    case CargoUnitBroken:
+        return "broken"
+    }
+
+    return "unknown"
+}
+
+const (
+    DefaultCargoUnitMaxWeight = 2000.0 // kilograms
+    DefaultCargoUnitMaxVolume = 20.0   // cubic meters
+    // FuelPerStep is the percent of a full tank a unit burns driving one step
+    FuelPerStep = 0.05
+)
+
+// CargoUnit state, it is the GraphNode.Payload of cargo units. Nodes copied from the graph share it
+// the way they share their Coordinate.
+type CargoUnit struct {
+    // MaxWeight and MaxVolume of goods the unit can carry at once
+    MaxWeight float64
+    MaxVolume float64
+    // Weight and Volume of goods the unit carries
+    Weight float64
+    Volume float64
+    // Speed in steps per tick
+    Speed uint
+    // Fuel left in percent of a full tank
+    Fuel   float64
+    Status CargoUnitStatus
+}
+
+// NewCargoUnit idle with a full tank, moving one step per tick
+func NewCargoUnit(maxWeight, maxVolume float64) *CargoUnit {
+    return &CargoUnit{MaxWeight: maxWeight, MaxVolume: maxVolume, Speed: 1, Fuel: 100}
+}
+
+// Fits reports if goods of weight and volume can be loaded on top of what the unit carries
+func (u *CargoUnit) Fits(weight, volume float64) bool {
+    return u.Weight+weight <= u.MaxWeight && u.Volume+volume <= u.MaxVolume
+}
+
+// Load goods of weight and volume
+func (u *CargoUnit) Load(weight, volume float64) {
+    u.Weight += weight
+    u.Volume += volume
+}
+
+// Unload goods of weight and volume
+func (u *CargoUnit) Unload(weight, volume float64) {
+    u.Weight = max(u.Weight-weight, 0)
+    u.Volume = max(u.Volume-volume, 0)
+}
+
+// Drive burns fuel of the steps, a unit that runs out of fuel breaks down
+func (u *CargoUnit) Drive(steps int) {
+    u.Fuel = max(u.Fuel-float64(steps)*FuelPerStep, 0)
+    if u.Fuel == 0 {
+        u.Status = CargoUnitBroken
+    }
+}
+
+// Refuel to a full tank
+func (u *CargoUnit) Refuel() {
+    u.Fuel = 100
+}
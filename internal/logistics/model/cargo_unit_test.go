@@ -0,0 +1,29 @@
+package model
+
+import "testing"
+
+func TestCargoUnitLoadAndFuel(t *testing.T) {
+    unit := NewCargoUnit(100, 2)
+
+    if !unit.Fits(60, 1) {
+        t.Fatalf("Expected 60 kg to fit an empty unit of 100 kg")
+    }
+    unit.Load(60, 1)
+    if unit.Fits(60, 1) || !unit.Fits(40, 1) {
+        t.Errorf("Expected only 40 kg more to fit, but got %+v", unit)
+    }
+    unit.Unload(60, 1)
+    if unit.Weight != 0 || unit.Volume != 0 {
+        t.Errorf("Expected empty unit, but got %+v", unit)
+    }
+
+    unit.Status = CargoUnitEnRoute
+    unit.Drive(10)
+    if unit.Fuel != 100-10*FuelPerStep || unit.Status != CargoUnitEnRoute {
+        t.Errorf("Expected fuel of 10 steps burned, but got %+v", unit)
+    }
+    unit.Drive(int(100 / FuelPerStep))
+    if unit.Fuel != 0 || unit.Status != CargoUnitBroken {
+        t.Errorf("Expected unit broken without fuel, but got %+v", unit)
+    }
+}
@@ -1,12 +1,22 @@
 package model
 
-import "sync"
+import (
+    "math"
+    "sync"
+)
 
 // Graph model
 type Graph struct {
     Nodes []GraphNode
     Edges []GraphEdge
-    sync.Mutex
+    sync.RWMutex
+
+    // nodeIndex maps node ID to its position in Nodes
+    nodeIndex map[uint]int
+    // adjacency maps node ID to IDs of nodes it shares an edge with
+    adjacency map[uint][]uint
+    // spatial indexes node locations separately for every node type
+    spatial map[ActorType]*spatialIndex
 }
 
 // GraphNode ...
@@ -14,11 +24,32 @@ type GraphNode struct {
     ID        uint
     Name      string
     Connected bool
-    Type      any
-    Metadata  any
+    // Payload with fields of the actor, its type is the type of the node
+    Payload ActorPayload
     *Coordinate
 }
 
+// Type of the actor, zero for a node without payload
+func (n *GraphNode) Type() ActorType {
+    if n.Payload == nil {
+        return 0
+    }
+
+    return n.Payload.ActorType()
+}
+
+// Warehouse capacity of a warehouse node, ok is false for nodes of other types
+func (n *GraphNode) Warehouse() (capacity WarehouseCapacity, ok bool) {
+    capacity, ok = n.Payload.(WarehouseCapacity)
+    return capacity, ok
+}
+
+// CargoUnit state of a cargo unit node, nil for nodes of other types
+func (n *GraphNode) CargoUnit() *CargoUnit {
+    unit, _ := n.Payload.(*CargoUnit)
+    return unit
+}
+
 // GraphEdge ...
 type GraphEdge struct {
     Source uint
@@ -27,15 +58,28 @@ type GraphEdge struct {
 
 // NewGraph instance
 func NewGraph() *Graph {
-    return &Graph{}
+    return &Graph{
+        nodeIndex: make(map[uint]int),
+        adjacency: make(map[uint][]uint),
+        spatial:   make(map[ActorType]*spatialIndex),
+    }
 }
 
-// AddNode to the graph
+// AddNode to the graph, a node added with an ID already in the graph shadows the previous one in lookups
 func (g *Graph) AddNode(node GraphNode) {
     g.Lock()
     defer g.Unlock()
 
+    if previous, ok := g.nodeIndex[node.ID]; ok && g.Nodes[previous].Coordinate != nil {
+        g.spatialFor(g.Nodes[previous].Type()).remove(node.ID, *g.Nodes[previous].Coordinate)
+    }
+
     g.Nodes = append(g.Nodes, node)
+    g.nodeIndex[node.ID] = len(g.Nodes) - 1
+
+    if node.Coordinate != nil {
+        g.spatialFor(node.Type()).insert(node.ID, *node.Coordinate)
+    }
 }
 
 // AddEdge to the graph
@@ -44,35 +88,54 @@ func (g *Graph) AddEdge(edge GraphEdge) {
     defer g.Unlock()
 
     g.Edges = append(g.Edges, edge)
+    g.connect(edge.Source, edge.Target)
+    g.connect(edge.Target, edge.Source)
 
-    for i := range g.Nodes {
-        if g.Nodes[i].ID == edge.Source {
-            g.Nodes[i].Connected = true
-        }
+    if i, ok := g.nodeIndex[edge.Source]; ok {
+        g.Nodes[i].Connected = true
+    }
+}
+
+// MoveNode to the coordinate, keeping the spatial index up to date
+func (g *Graph) MoveNode(nodeID uint, coordinate Coordinate) {
+    g.Lock()
+    defer g.Unlock()
+
+    i, ok := g.nodeIndex[nodeID]
+    if !ok || g.Nodes[i].Coordinate == nil {
+        return
     }
+
+    node := &g.Nodes[i]
+    if bucketOf(*node.Coordinate) == bucketOf(coordinate) {
+        *node.Coordinate = coordinate
+        return
+    }
+
+    index := g.spatialFor(node.Type())
+    index.remove(nodeID, *node.Coordinate)
+    *node.Coordinate = coordinate
+    index.insert(nodeID, coordinate)
 }
 
 // GetNodeByID returns the node with the specified ID, or nil if it is not found
 func (g *Graph) GetNodeByID(nodeID uint) *GraphNode {
-    for _, node := range g.Nodes {
-        if node.ID == nodeID {
-            return &node
-        }
-    }
-    return nil
+    g.RLock()
+    defer g.RUnlock()
+
+    return g.nodeByID(nodeID)
 }
 
 // GetNodesByType returns a slice of nodes with the specified type
-func (g *Graph) GetNodesByType(nodeType any) []*GraphNode {
-    var nodesByType []*GraphNode
+func (g *Graph) GetNodesByType(nodeType ActorType) []*GraphNode {
+    g.RLock()
+    defer g.RUnlock()
 
-    for _, node := range g.Nodes {
-        if node.Type == nodeType {
+    var nodesByType []*GraphNode
+    for i, node := range g.Nodes {
+        if node.Type() == nodeType && g.nodeIndex[node.ID] == i {
             copyNode := node
-
-            if !containsNode(nodesByType, &copyNode) {
-                nodesByType = append(nodesByType, &copyNode)
-            }
+            nodesByType = append(nodesByType, &copyNode)
         }
     }
 
@@ -80,24 +143,15 @@ func (g *Graph) GetNodesByType(nodeType any) []*GraphNode {
 }
 
 // GetConnectedNodes returns a slice of connected nodes of the given type to the node with the specified ID
-func (g *Graph) GetConnectedNodes(nodeID uint, nodeType any) []*GraphNode {
-    var connectedNodes []*GraphNode
+func (g *Graph) GetConnectedNodes(nodeID uint, nodeType ActorType) []*GraphNode {
+    g.RLock()
+    defer g.RUnlock()
 
-    for _, edge := range g.Edges {
-        if edge.Source == nodeID {
-            targetNode := g.GetNodeByID(edge.Target)
-            if targetNode != nil && targetNode.Type == nodeType {
-                if !containsNode(connectedNodes, targetNode) {
-                    connectedNodes = append(connectedNodes, targetNode)
-                }
-            }
-        } else if edge.Target == nodeID {
-            sourceNode := g.GetNodeByID(edge.Source)
-            if sourceNode != nil && sourceNode.Type == nodeType {
-                if !containsNode(connectedNodes, sourceNode) {
-                    connectedNodes = append(connectedNodes, sourceNode)
-                }
-            }
+    var connectedNodes []*GraphNode
+    for _, neighbourID := range g.adjacency[nodeID] {
+        neighbour := g.nodeByID(neighbourID)
+        if neighbour != nil && neighbour.Type() == nodeType {
+            connectedNodes = append(connectedNodes, neighbour)
         }
     }
 
@@ -105,25 +159,127 @@ func (g *Graph) GetConnectedNodes(nodeID uint, nodeType any) []*GraphNode {
 }
 
 // FindNodesByLocation node in given coordinate
-func (g *Graph) FindNodesByLocation(coordinate Coordinate, nodeType any) *GraphNode {
-    for _, node := range g.Nodes {
-        if node.Type != nodeType {
-            continue
-        }
+func (g *Graph) FindNodesByLocation(coordinate Coordinate, nodeType ActorType) *GraphNode {
+    g.RLock()
+    defer g.RUnlock()
 
+    index, ok := g.spatial[nodeType]
+    if !ok {
+        return nil
+    }
+
+    for _, id := range index.buckets[bucketOf(coordinate)] {
+        node := g.nodeByID(id)
         if *node.Coordinate == coordinate {
-            return &node
+            return node
         }
     }
 
     return nil
 }
 
-func containsNode(nodes []*GraphNode, node *GraphNode) bool {
-    for _, n := range nodes {
-        if n.ID == node.ID {
-            return true
+// NodesInRange returns nodes of the given type within radius from the coordinate, closest first
+func (g *Graph) NodesInRange(coordinate Coordinate, radius float64, nodeType ActorType) []*GraphNode {
+    g.RLock()
+    defer g.RUnlock()
+
+    index, ok := g.spatial[nodeType]
+    if !ok {
+        return nil
+    }
+
+    var nodesInRange []*GraphNode
+    center := bucketOf(coordinate)
+    rings := int(math.Ceil(radius/spatialCellSize)) + 1
+    for r := 0; r <= rings; r++ {
+        if !index.ring(center, r, func(id uint) {
+            node := g.nodeByID(id)
+            if distance(*node.Coordinate, coordinate) <= radius {
+                nodesInRange = append(nodesInRange, node)
+            }
+        }) {
+            break
         }
     }
-    return false
+
+    sortByDistance(nodesInRange, coordinate)
+
+    return nodesInRange
+}
+
+// NearestNodes returns up to k nodes of the given type closest to the coordinate, closest first
+func (g *Graph) NearestNodes(coordinate Coordinate, nodeType ActorType, k int) []*GraphNode {
+    g.RLock()
+    defer g.RUnlock()
+
+    index, ok := g.spatial[nodeType]
+    if !ok || k <= 0 {
+        return nil
+    }
+
+    var candidates []*GraphNode
+    center := bucketOf(coordinate)
+    for r := 0; ; r++ {
+        if !index.ring(center, r, func(id uint) {
+            candidates = append(candidates, g.nodeByID(id))
+        }) {
+            break
+        }
+
+        // Nodes in rings not visited yet are farther than r whole buckets away
+        if len(candidates) >= k {
+            sortByDistance(candidates, coordinate)
+            if distance(*candidates[k-1].Coordinate, coordinate) <= float64(r*spatialCellSize) {
+                break
+            }
+        }
+    }
+
+    sortByDistance(candidates, coordinate)
+    if len(candidates) > k {
+        candidates = candidates[:k]
+    }
+
+    return candidates
+}
+
+// NearestNode of the given type to the coordinate, or nil if there are no such nodes
+func (g *Graph) NearestNode(coordinate Coordinate, nodeType ActorType) *GraphNode {
+    nearest := g.NearestNodes(coordinate, nodeType, 1)
+    if len(nearest) == 0 {
+        return nil
+    }
+
+    return nearest[0]
+}
+
+// nodeByID returns a copy of the node, graph must be locked
+func (g *Graph) nodeByID(nodeID uint) *GraphNode {
+    i, ok := g.nodeIndex[nodeID]
+    if !ok {
+        return nil
+    }
+
+    node := g.Nodes[i]
+    return &node
+}
+
+func (g *Graph) connect(from, to uint) {
+    for _, id := range g.adjacency[from] {
+        if id == to {
+            return
+        }
+    }
+
+    g.adjacency[from] = append(g.adjacency[from], to)
+}
+
+func (g *Graph) spatialFor(nodeType ActorType) *spatialIndex {
+    index, ok := g.spatial[nodeType]
+    if !ok {
+        index = newSpatialIndex()
+        g.spatial[nodeType] = index
+    }
+
+    return index
 }
@@ -1,19 +1,21 @@
 package model
 
 import (
+    "fmt"
+    "math/rand"
     "testing"
 )
 
 func TestGraph(t *testing.T) {
     graph := NewGraph()
 
-    warehouse1 := GraphNode{ID: 1, Name: "Warehouse 1", Type: "WH"}
-    warehouse2 := GraphNode{ID: 2, Name: "Warehouse 2", Type: "WH"}
+    warehouse1 := GraphNode{ID: 1, Name: "Warehouse 1", Payload: WarehouseCapacity{}}
+    warehouse2 := GraphNode{ID: 2, Name: "Warehouse 2", Payload: WarehouseCapacity{}}
     graph.AddNode(warehouse1)
     graph.AddNode(warehouse2)
 
-    truck1 := GraphNode{ID: 3, Name: "Delivery Truck 1"}
-    truck2 := GraphNode{ID: 4, Name: "Delivery Truck 2"}
+    truck1 := GraphNode{ID: 3, Name: "Delivery Truck 1", Payload: NewCargoUnit(1, 1)}
+    truck2 := GraphNode{ID: 4, Name: "Delivery Truck 2", Payload: NewCargoUnit(1, 1)}
     graph.AddNode(truck1)
     graph.AddNode(truck2)
 
@@ -33,7 +35,7 @@ func TestGraph(t *testing.T) {
 
     // Check the Connected flag for each warehouse
     for _, node := range graph.Nodes {
-        if node.Type == "WH" && !node.Connected {
+        if node.Type() == Warehouses && !node.Connected {
             t.Errorf("%s should be connected, but it is not", node.Name)
         }
     }
@@ -44,10 +46,10 @@ func TestGetConnectedNodes(t *testing.T) {
     graph := NewGraph()
 
     // Add some nodes to the graph
-    nodeA := GraphNode{ID: 1, Type: "TypeA"}
-    nodeB := GraphNode{ID: 2, Type: "TypeB"}
-    nodeC := GraphNode{ID: 3, Type: "TypeA"}
-    nodeD := GraphNode{ID: 4, Type: "TypeB"}
+    nodeA := GraphNode{ID: 1, Payload: WarehouseCapacity{}}
+    nodeB := GraphNode{ID: 2, Payload: NewCargoUnit(1, 1)}
+    nodeC := GraphNode{ID: 3, Payload: WarehouseCapacity{}}
+    nodeD := GraphNode{ID: 4, Payload: NewCargoUnit(1, 1)}
     graph.AddNode(nodeA)
     graph.AddNode(nodeB)
     graph.AddNode(nodeC)
@@ -61,8 +63,8 @@ func TestGetConnectedNodes(t *testing.T) {
     graph.AddEdge(edge2)
     graph.AddEdge(edge3)
 
-    // Get connected nodes for node with ID 1 and Type "TypeA"
-    connectedNodes := graph.GetConnectedNodes(1, "TypeA")
+    // Get connected warehouses of node with ID 1
+    connectedNodes := graph.GetConnectedNodes(1, Warehouses)
 
     // Check the number of connected nodes
     expectedNumNodes := 1
@@ -76,3 +78,123 @@ func TestGetConnectedNodes(t *testing.T) {
         t.Errorf("Expected connected node ID %d, but got %d", expectedNodeID, connectedNodes[0].ID)
     }
 }
+
+func TestSpatialQueries(t *testing.T) {
+    graph := NewGraph()
+    graph.AddNode(GraphNode{ID: 1, Coordinate: &Coordinate{X: 0, Y: 0}, Payload: WarehouseCapacity{}})
+    graph.AddNode(GraphNode{ID: 2, Coordinate: &Coordinate{X: 10, Y: 0}, Payload: WarehouseCapacity{}})
+    graph.AddNode(GraphNode{ID: 3, Coordinate: &Coordinate{X: 100, Y: 100}, Payload: WarehouseCapacity{}})
+    graph.AddNode(GraphNode{ID: 4, Coordinate: &Coordinate{X: 9, Y: 0}, Payload: NewCargoUnit(1, 1)})
+
+    nearest := graph.NearestNode(Coordinate{X: 8, Y: 1}, Warehouses)
+    if nearest == nil || nearest.ID != 2 {
+        t.Errorf("Expected nearest node ID 2, but got %v", nearest)
+    }
+
+    nearestNodes := graph.NearestNodes(Coordinate{X: 90, Y: 90}, Warehouses, 2)
+    if len(nearestNodes) != 2 || nearestNodes[0].ID != 3 || nearestNodes[1].ID != 2 {
+        t.Errorf("Expected nearest nodes 3 and 2, but got %v", nearestNodes)
+    }
+
+    inRange := graph.NodesInRange(Coordinate{X: 5, Y: 0}, 5, Warehouses)
+    if len(inRange) != 2 {
+        t.Errorf("Expected 2 nodes in range, but got %d", len(inRange))
+    }
+
+    if found := graph.FindNodesByLocation(Coordinate{X: 9, Y: 0}, Warehouses); found != nil {
+        t.Errorf("Expected no warehouse at (9, 0), but got %d", found.ID)
+    }
+    if found := graph.FindNodesByLocation(Coordinate{X: 9, Y: 0}, CargoUnits); found == nil || found.ID != 4 {
+        t.Errorf("Expected node 4 at (9, 0), but got %v", found)
+    }
+}
+
+func TestMoveNode(t *testing.T) {
+    graph := NewGraph()
+    graph.AddNode(GraphNode{ID: 1, Coordinate: &Coordinate{X: 0, Y: 0}, Payload: NewCargoUnit(1, 1)})
+
+    graph.MoveNode(1, Coordinate{X: 200, Y: 150})
+
+    if found := graph.FindNodesByLocation(Coordinate{X: 0, Y: 0}, CargoUnits); found != nil {
+        t.Errorf("Expected moved node to be gone from its old location")
+    }
+    if found := graph.FindNodesByLocation(Coordinate{X: 200, Y: 150}, CargoUnits); found == nil || found.ID != 1 {
+        t.Errorf("Expected moved node at its new location, but got %v", found)
+    }
+    if node := graph.GetNodeByID(1); *node.Coordinate != (Coordinate{X: 200, Y: 150}) {
+        t.Errorf("Expected node coordinate to be updated, but got %v", *node.Coordinate)
+    }
+}
+
+func newBenchmarkGraph(nodes int) *Graph {
+    rnd := rand.New(rand.NewSource(1))
+    graph := NewGraph()
+
+    for i := 0; i < nodes; i++ {
+        var payload ActorPayload = NewCargoUnit(1, 1)
+        if i%100 == 0 {
+            payload = WarehouseCapacity{}
+        }
+
+        graph.AddNode(GraphNode{
+            ID:         uint(i),
+            Payload:    payload,
+            Coordinate: &Coordinate{X: rnd.Intn(GridWidth), Y: rnd.Intn(GridHeight)},
+        })
+        if i%100 != 0 {
+            graph.AddEdge(GraphEdge{Source: uint(i), Target: uint(i - i%100)})
+        }
+    }
+
+    return graph
+}
+
+var benchmarkSizes = []int{1_000, 10_000, 100_000}
+
+func BenchmarkGetNodeByID(b *testing.B) {
+    for _, size := range benchmarkSizes {
+        graph := newBenchmarkGraph(size)
+
+        b.Run(fmt.Sprintf("nodes=%d", size), func(b *testing.B) {
+            for i := 0; i < b.N; i++ {
+                graph.GetNodeByID(uint(i % size))
+            }
+        })
+    }
+}
+
+func BenchmarkFindNodesByLocation(b *testing.B) {
+    for _, size := range benchmarkSizes {
+        graph := newBenchmarkGraph(size)
+
+        b.Run(fmt.Sprintf("nodes=%d", size), func(b *testing.B) {
+            for i := 0; i < b.N; i++ {
+                graph.FindNodesByLocation(Coordinate{X: i % GridWidth, Y: (i / GridWidth) % GridHeight}, Warehouses)
+            }
+        })
+    }
+}
+
+func BenchmarkNearestNode(b *testing.B) {
+    for _, size := range benchmarkSizes {
+        graph := newBenchmarkGraph(size)
+
+        b.Run(fmt.Sprintf("nodes=%d", size), func(b *testing.B) {
+            for i := 0; i < b.N; i++ {
+                graph.NearestNode(Coordinate{X: i % GridWidth, Y: (i / GridWidth) % GridHeight}, Warehouses)
+            }
+        })
+    }
+}
+
+func BenchmarkGetConnectedNodes(b *testing.B) {
+    for _, size := range benchmarkSizes {
+        graph := newBenchmarkGraph(size)
+
+        b.Run(fmt.Sprintf("nodes=%d", size), func(b *testing.B) {
+            for i := 0; i < b.N; i++ {
+                graph.GetConnectedNodes(uint(i%size), Warehouses)
+            }
+        })
+    }
+}
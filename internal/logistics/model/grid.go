@@ -0,0 +1,58 @@
+package model
+
+// Size of the world grid actors are placed on
+const (
+    GridWidth  = 255
+    GridHeight = 255
+)
+
+// Movement cost of grid cells, a cell with CostBlocked can't be entered at all (water, closed roads)
+const (
+    CostBlocked uint8 = 0
+    CostRoad    uint8 = 1
+)
+
+// Grid of the world terrain, every cell holds the cost of entering it
+type Grid struct {
+    Width  int
+    Height int
+    costs  []uint8
+}
+
+// NewGrid instance where every cell is a road
+func NewGrid(width, height int) *Grid {
+    costs := make([]uint8, width*height)
+    for i := range costs {
+        costs[i] = CostRoad
+    }
+
+    return &Grid{Width: width, Height: height, costs: costs}
+}
+
+// InBounds reports if coordinate lies inside the grid
+func (g *Grid) InBounds(c Coordinate) bool {
+    return c.X >= 0 && c.X < g.Width && c.Y >= 0 && c.Y < g.Height
+}
+
+// Cost of entering the cell, CostBlocked for cells outside the grid
+func (g *Grid) Cost(c Coordinate) uint8 {
+    if !g.InBounds(c) {
+        return CostBlocked
+    }
+
+    return g.costs[c.Y*g.Width+c.X]
+}
+
+// SetCost of entering the cell, coordinates outside the grid are ignored
+func (g *Grid) SetCost(c Coordinate, cost uint8) {
+    if !g.InBounds(c) {
+        return
+    }
+
+    g.costs[c.Y*g.Width+c.X] = cost
+}
+
+// Passable reports if the cell can be entered
+func (g *Grid) Passable(c Coordinate) bool {
+    return g.Cost(c) != CostBlocked
+}
@@ -0,0 +1,96 @@
+package model
+
+import "time"
+
+// OrderPriority of an order, higher priority orders are dispatched first
+type OrderPriority byte
+
+const (
+    OrderPriorityLow OrderPriority = iota + 1
+    OrderPriorityNormal
+    OrderPriorityHigh
+)
+
+// String impl
+func (p OrderPriority) String() string {
+    switch p {
+    case OrderPriorityLow:
+        return "low"
+    case OrderPriorityNormal:
+        return "normal"
+    case OrderPriorityHigh:
+        return "high"
+    }
+
+    return "unknown"
+}
+
+// OrderStatus of an order from its arrival to the delivery of its goods
+type OrderStatus byte
+
+const (
+    // OrderPending order waits for a free cargo unit
+    OrderPending OrderStatus = iota
+    // OrderAssigned order is carried by its unit as a shipment with the same ID
+    OrderAssigned
+    // OrderDelivered goods were dropped at the destination warehouse
+    OrderDelivered
+    // OrderStranded order was left on its unit that broke down before dropping its goods
+    OrderStranded
+)
+
+// String impl
+func (s OrderStatus) String() string {
+    switch s {
+    case OrderPending:
+        return "pending"
+    case OrderAssigned:
+        return "assigned"
+    case OrderDelivered:
+        return "delivered"
+    case OrderStranded:
+        return "stranded"
+    }
+
+    return "unknown"
+}
+
+// Order of goods to be carried from the origin warehouse to the destination warehouse
+type Order struct {
+    ID uint
+    // Origin and Destination are warehouse IDs
+    Origin      uint
+    Destination uint
+    // Weight of goods in kilograms
+    Weight float64
+    // Volume of goods in cubic meters
+    Volume   float64
+    Priority OrderPriority
+    // CreatedAt and Deadline are simulated time the order arrived at and must be delivered by
+    CreatedAt time.Time
+    Deadline  time.Time
+
+    Status OrderStatus
+    // UnitID carrying the order, set once it is assigned
+    UnitID uint
+    // DeliveredAt is simulated time goods were dropped at the destination
+    DeliveredAt time.Time
+}
+
+// Late reports if the order was delivered after its deadline
+func (o *Order) Late() bool {
+    return o.Status == OrderDelivered && o.DeliveredAt.After(o.Deadline)
+}
+
+// Before reports if the order must be dispatched before the other one: higher priority first,
+// then the earlier deadline, then the order that arrived first
+func (o *Order) Before(other *Order) bool {
+    if o.Priority != other.Priority {
+        return o.Priority > other.Priority
+    }
+    if !o.Deadline.Equal(other.Deadline) {
+        return o.Deadline.Before(other.Deadline)
+    }
+
+    return o.ID < other.ID
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+    "sort"
+    "testing"
+    "time"
+)
+
+func TestOrderDispatchOrder(t *testing.T) {
+    now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+    orders := []*Order{
+        {ID: 1, Priority: OrderPriorityLow, Deadline: now},
+        {ID: 2, Priority: OrderPriorityNormal, Deadline: now.Add(time.Hour)},
+        {ID: 3, Priority: OrderPriorityHigh, Deadline: now.Add(2 * time.Hour)},
+        {ID: 4, Priority: OrderPriorityNormal, Deadline: now},
+        {ID: 5, Priority: OrderPriorityNormal, Deadline: now},
+    }
+
+    sort.Slice(orders, func(i, j int) bool { return orders[i].Before(orders[j]) })
+
+    expected := []uint{3, 4, 5, 2, 1}
+    for i, order := range orders {
+        if order.ID != expected[i] {
+            t.Errorf("Expected order %d at position %d, but got %d", expected[i], i, order.ID)
+        }
+    }
+}
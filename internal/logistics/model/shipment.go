@@ -0,0 +1,81 @@
+package model
+
+// ShipmentStatus of a shipment along its stops
+type ShipmentStatus byte
+
+const (
+    // ShipmentAssigned shipment waits for its unit to load goods at the pickup warehouse
+    ShipmentAssigned ShipmentStatus = iota
+    // ShipmentInTransit goods are loaded and carried to the drop-off warehouses
+    ShipmentInTransit
+    // ShipmentDelivered goods were dropped at every drop-off warehouse
+    ShipmentDelivered
+)
+
+// String impl
+func (s ShipmentStatus) String() string {
+    switch s {
+    case ShipmentAssigned:
+        return "assigned"
+    case ShipmentInTransit:
+        return "in transit"
+    case ShipmentDelivered:
+        return "delivered"
+    }
+
+    return "unknown"
+}
+
+// Shipment carried by a cargo unit: goods are picked up at the first stop and dropped at every following one in order
+type Shipment struct {
+    ID     uint
+    UnitID uint
+    // Stops are warehouse IDs, the first one is the pickup warehouse and the rest are drop-off warehouses
+    Stops []uint
+    // Next is the index of the stop the unit is heading to or handled at, len(Stops) once the shipment is delivered
+    Next   int
+    Status ShipmentStatus
+    // Weight and Volume of goods, the unit carries them from the pickup to the last drop-off
+    Weight float64
+    Volume float64
+}
+
+// Pickup warehouse of the shipment
+func (s *Shipment) Pickup() uint {
+    return s.Stops[0]
+}
+
+// DropOffs warehouses of the shipment in order
+func (s *Shipment) DropOffs() []uint {
+    return s.Stops[1:]
+}
+
+// NextStop warehouse, ok is false once the shipment is delivered
+func (s *Shipment) NextStop() (warehouseID uint, ok bool) {
+    if s.Next >= len(s.Stops) {
+        return 0, false
+    }
+
+    return s.Stops[s.Next], true
+}
+
+// CompleteStop marks the next stop as handled and moves the shipment on to the following one
+func (s *Shipment) CompleteStop() {
+    if s.Next >= len(s.Stops) {
+        return
+    }
+
+    s.Next++
+    switch {
+    case s.Next == len(s.Stops):
+        s.Status = ShipmentDelivered
+    case s.Next > 0:
+        s.Status = ShipmentInTransit
+    }
+}
+
+// Stop of a cargo unit at a warehouse, where goods of the shipment are picked up or dropped
+type Stop struct {
+    WarehouseID uint
+    ShipmentID  uint
+}
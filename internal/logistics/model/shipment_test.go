@@ -0,0 +1,37 @@
+package model
+
+import "testing"
+
+func TestShipmentStops(t *testing.T) {
+    shipment := &Shipment{ID: 1, UnitID: 10, Stops: []uint{3, 5, 7}}
+
+    expected := []struct {
+        stop   uint
+        status ShipmentStatus
+    }{
+        {stop: 3, status: ShipmentAssigned},
+        {stop: 5, status: ShipmentInTransit},
+        {stop: 7, status: ShipmentInTransit},
+    }
+    for _, e := range expected {
+        stop, ok := shipment.NextStop()
+        if !ok || stop != e.stop {
+            t.Fatalf("Expected next stop %d, but got %d", e.stop, stop)
+        }
+        if shipment.Status != e.status {
+            t.Errorf("Expected status %s at stop %d, but got %s", e.status, e.stop, shipment.Status)
+        }
+
+        shipment.CompleteStop()
+    }
+
+    if _, ok := shipment.NextStop(); ok {
+        t.Errorf("Expected no stop after the last drop-off")
+    }
+    if shipment.Status != ShipmentDelivered {
+        t.Errorf("Expected status %s, but got %s", ShipmentDelivered, shipment.Status)
+    }
+    if shipment.Pickup() != 3 || len(shipment.DropOffs()) != 2 {
+        t.Errorf("Expected pickup 3 and 2 drop-offs, but got %d and %v", shipment.Pickup(), shipment.DropOffs())
+    }
+}
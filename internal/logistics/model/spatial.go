@@ -0,0 +1,97 @@
+package model
+
+import (
+    "math"
+    "sort"
+)
+
+// spatialCellSize is the side of a square bucket of the spatial index
+const spatialCellSize = 16
+
+// spatialIndex is a uniform grid of buckets, every bucket holds IDs of nodes located inside it
+type spatialIndex struct {
+    buckets map[Coordinate][]uint
+    // min and max bucket ever used, queries never look outside of them
+    min, max Coordinate
+}
+
+func newSpatialIndex() *spatialIndex {
+    return &spatialIndex{buckets: make(map[Coordinate][]uint)}
+}
+
+func bucketOf(c Coordinate) Coordinate {
+    return Coordinate{X: floorDiv(c.X, spatialCellSize), Y: floorDiv(c.Y, spatialCellSize)}
+}
+
+func (s *spatialIndex) insert(id uint, c Coordinate) {
+    b := bucketOf(c)
+    if len(s.buckets) == 0 {
+        s.min, s.max = b, b
+    }
+    s.min = Coordinate{X: min(s.min.X, b.X), Y: min(s.min.Y, b.Y)}
+    s.max = Coordinate{X: max(s.max.X, b.X), Y: max(s.max.Y, b.Y)}
+
+    s.buckets[b] = append(s.buckets[b], id)
+}
+
+// remove keeps order of the remaining IDs, so lookups return nodes in the order they were added
+func (s *spatialIndex) remove(id uint, c Coordinate) {
+    b := bucketOf(c)
+    ids := s.buckets[b]
+    for i := range ids {
+        if ids[i] == id {
+            s.buckets[b] = append(ids[:i], ids[i+1:]...)
+            break
+        }
+    }
+
+    if len(s.buckets[b]) == 0 {
+        delete(s.buckets, b)
+    }
+}
+
+// ring calls visit for every ID in buckets at Chebyshev bucket distance r from the center bucket.
+// It returns false once the ring lies completely outside of used buckets.
+func (s *spatialIndex) ring(center Coordinate, r int, visit func(id uint)) bool {
+    if center.X-r < s.min.X && center.X+r > s.max.X && center.Y-r < s.min.Y && center.Y+r > s.max.Y {
+        return false
+    }
+
+    for x := center.X - r; x <= center.X+r; x++ {
+        for y := center.Y - r; y <= center.Y+r; y++ {
+            if x != center.X-r && x != center.X+r && y != center.Y-r && y != center.Y+r {
+                continue
+            }
+
+            for _, id := range s.buckets[Coordinate{X: x, Y: y}] {
+                visit(id)
+            }
+        }
+    }
+
+    return true
+}
+
+// distance between coordinates on the plane
+func distance(a, b Coordinate) float64 {
+    return math.Hypot(float64(a.X-b.X), float64(a.Y-b.Y))
+}
+
+// sortByDistance from c, nodes at the same distance are ordered by ID
+func sortByDistance(nodes []*GraphNode, c Coordinate) {
+    sort.Slice(nodes, func(i, j int) bool {
+        di, dj := distance(*nodes[i].Coordinate, c), distance(*nodes[j].Coordinate, c)
+        if di == dj {
+            return nodes[i].ID < nodes[j].ID
+        }
+        return di < dj
+    })
+}
+
+func floorDiv(a, b int) int {
+    q := a / b
+    if a%b != 0 && a < 0 {
+        q--
+    }
+    return q
+}
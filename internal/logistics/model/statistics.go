@@ -1,6 +1,7 @@
 package model
 
 import (
+    "math/bits"
     "sync"
     "time"
 )
@@ -9,6 +10,39 @@ import (
 type Statistics struct {
     Operation []*Operation
     ExecTime  time.Time
+
+    // WarehouseDeliveries counts units the client delivered to each warehouse, keyed by warehouse ID
+    WarehouseDeliveries map[uint]uint64
+    deliveriesMu        sync.Mutex
+
+    // WarehouseQueues of warehouses that served at least one unit, ordered by warehouse ID
+    WarehouseQueues []WarehouseQueue
+}
+
+// AddDelivery safe incrementation of units delivered to the warehouse
+func (s *Statistics) AddDelivery(warehouseID uint) {
+    s.deliveriesMu.Lock()
+    defer s.deliveriesMu.Unlock()
+
+    if s.WarehouseDeliveries == nil {
+        s.WarehouseDeliveries = make(map[uint]uint64)
+    }
+    s.WarehouseDeliveries[warehouseID]++
+}
+
+// WarehouseQueue statistics of a single warehouse, wait times are in simulation ticks
+type WarehouseQueue struct {
+    WarehouseID uint
+    // Served units finished unloading
+    Served uint64
+    // Queued units had to wait for a free dock
+    Queued uint64
+    // MaxLength of the queue ever seen
+    MaxLength uint64
+    TotalWait uint64
+    MaxWait   uint64
+    // Rerouted units were sent to another warehouse because this one was full
+    Rerouted uint64
 }
 
 // Operation kind
@@ -17,9 +51,23 @@ type Operation struct {
     A    uint64
     B    uint64
 
+    // latencies of every call, failed ones included
+    latencies latencyHistogram
+
     sync.Mutex
 }
 
+// Latency summary of calls of an operation
+type Latency struct {
+    Count uint64
+    Min   time.Duration
+    Avg   time.Duration
+    P50   time.Duration
+    P90   time.Duration
+    P99   time.Duration
+    Max   time.Duration
+}
+
 // AddA safe incrementation
 func (o *Operation) AddA() {
     o.Lock()
@@ -33,3 +81,96 @@ func (o *Operation) AddB() {
     defer o.Unlock()
     o.B++
 }
+
+// AddLatency of a single call
+func (o *Operation) AddLatency(latency time.Duration) {
+    o.Lock()
+    defer o.Unlock()
+    o.latencies.add(latency)
+}
+
+// Latency summary of all calls. Count, Min, Avg and Max are exact, percentiles are nearest-rank
+// within latencySubBuckets of the precise value, see latencyHistogram.
+func (o *Operation) Latency() Latency {
+    o.Lock()
+    defer o.Unlock()
+
+    h := &o.latencies
+    if h.count == 0 {
+        return Latency{}
+    }
+
+    return Latency{
+        Count: h.count,
+        Min:   h.min,
+        Avg:   h.total / time.Duration(h.count),
+        P50:   h.percentile(50),
+        P90:   h.percentile(90),
+        P99:   h.percentile(99),
+        Max:   h.max,
+    }
+}
+
+// latencySubBuckets every power of two of nanoseconds is split into, a percentile is off by at most 1/latencySubBuckets
+const latencySubBuckets = 16
+
+// latencyBuckets cover every positive time.Duration
+const latencyBuckets = (64 - 4) * latencySubBuckets
+
+// latencyHistogram of calls in fixed log-linear buckets, it takes the same memory however many calls it counts.
+// Latencies below 2*latencySubBuckets nanoseconds have a bucket each, larger ones share a bucket with the latencies
+// of the same power of two and the same leading 4 bits after the highest one.
+type latencyHistogram struct {
+    buckets  [latencyBuckets]uint64
+    count    uint64
+    total    time.Duration
+    min, max time.Duration
+}
+
+// add a single call
+func (h *latencyHistogram) add(latency time.Duration) {
+    latency = max(latency, 0)
+    if h.count == 0 || latency < h.min {
+        h.min = latency
+    }
+    h.max = max(h.max, latency)
+    h.count++
+    h.total += latency
+    h.buckets[latencyBucket(latency)]++
+}
+
+// percentile p of calls, the upper bound of the bucket holding the nearest-rank call within min and max
+func (h *latencyHistogram) percentile(p int) time.Duration {
+    rank := max((uint64(p)*h.count+99)/100, 1) // ceil(p/100 * n)
+
+    var seen uint64
+    for bucket, count := range h.buckets {
+        seen += count
+        if seen >= rank {
+            return min(max(latencyBucketBound(bucket), h.min), h.max)
+        }
+    }
+
+    return h.max
+}
+
+// latencyBucket the latency is counted in
+func latencyBucket(latency time.Duration) int {
+    if latency < 2*latencySubBuckets {
+        return int(latency)
+    }
+
+    shift := bits.Len64(uint64(latency)) - 5
+    return shift*latencySubBuckets + int(latency>>shift)
+}
+
+// latencyBucketBound is the largest latency counted in the bucket
+func latencyBucketBound(bucket int) time.Duration {
+    if bucket < 2*latencySubBuckets {
+        return time.Duration(bucket)
+    }
+
+    shift := bucket/latencySubBuckets - 1
+    sub := bucket%latencySubBuckets + latencySubBuckets
+    return time.Duration((uint64(sub+1) << shift) - 1)
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+    "testing"
+    "time"
+)
+
+// assertPercentile is at least the exact value and off by at most 1/latencySubBuckets of it
+func assertPercentile(t *testing.T, name string, exact, got time.Duration) {
+    t.Helper()
+
+    if got < exact || got > exact+exact/latencySubBuckets {
+        t.Errorf("Expected %s within 1/%d above %v, but got %v", name, latencySubBuckets, exact, got)
+    }
+}
+
+func TestOperationLatency(t *testing.T) {
+    operation := &Operation{Name: "MoveUnit"}
+    if latency := operation.Latency(); latency != (Latency{}) {
+        t.Errorf("Expected empty latency without calls, but got %+v", latency)
+    }
+
+    // 1ms..100ms shuffled, percentiles must not depend on the order calls finished in
+    for i := 0; i < 100; i++ {
+        operation.AddLatency(time.Duration((i*37)%100+1) * time.Millisecond)
+    }
+
+    latency := operation.Latency()
+    if latency.Count != 100 || latency.Min != time.Millisecond || latency.Avg != 50500*time.Microsecond || latency.Max != 100*time.Millisecond {
+        t.Errorf("Expected exact count, min, avg and max of 100 calls, but got %+v", latency)
+    }
+    assertPercentile(t, "P50", 50*time.Millisecond, latency.P50)
+    assertPercentile(t, "P90", 90*time.Millisecond, latency.P90)
+    assertPercentile(t, "P99", 99*time.Millisecond, latency.P99)
+}
+
+func TestOperationLatencyBuckets(t *testing.T) {
+    // Every latency is counted in a bucket bounded above it and close to it, from nanoseconds to hours
+    previous := -1
+    for latency := time.Duration(1); latency < 10*time.Hour; latency += latency/7 + 1 {
+        bucket := latencyBucket(latency)
+        if bucket < previous || bucket >= latencyBuckets {
+            t.Fatalf("Expected bucket of %v ordered below %d, but got %d after %d", latency, latencyBuckets, bucket, previous)
+        }
+        previous = bucket
+
+        assertPercentile(t, "bucket bound", latency, latencyBucketBound(bucket))
+    }
+
+    operation := &Operation{Name: "MoveUnit"}
+    for i := 0; i < 1000; i++ {
+        operation.AddLatency(time.Second)
+    }
+    operation.AddLatency(time.Hour)
+    latency := operation.Latency()
+    if latency.Count != 1001 || latency.Max != time.Hour {
+        t.Errorf("Expected 1001 calls up to 1h, but got %+v", latency)
+    }
+    assertPercentile(t, "P99", time.Second, latency.P99)
+}
@@ -0,0 +1,12 @@
+package model
+
+// WarehouseCapacity limits how many units a warehouse handles, it is the GraphNode.Payload of warehouses.
+// Zero Capacity or Docks means no limit.
+type WarehouseCapacity struct {
+    // Capacity is the number of units that can be at the warehouse at once, docked or waiting in its queue
+    Capacity uint
+    // Docks is the number of units unloaded at the same time
+    Docks uint
+    // UnloadTicks is how many simulation ticks unloading of a unit takes
+    UnloadTicks uint
+}
@@ -0,0 +1,384 @@
+package scenario
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/pathfinder"
+	"gopkg.in/yaml.v3"
+)
+
+// Format of a scenario file
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// Terrain cells are written as one character per cell, digits from 2 to 9 are costs of slower cells
+const (
+	cellBlocked = '#'
+	cellRoad    = '.'
+	maxCellCost = 9
+)
+
+// Scenario describes a whole world: its terrain, warehouses and cargo units with their assigned warehouses
+type Scenario struct {
+	Name       string      `json:"name,omitempty" yaml:"name,omitempty"`
+	Grid       Grid        `json:"grid" yaml:"grid"`
+	Warehouses []Warehouse `json:"warehouses" yaml:"warehouses"`
+	CargoUnits []CargoUnit `json:"cargo_units" yaml:"cargo_units"`
+}
+
+// Grid the actors are placed on, zero size means model.GridWidth x model.GridHeight
+type Grid struct {
+	Width  int `json:"width,omitempty" yaml:"width,omitempty"`
+	Height int `json:"height,omitempty" yaml:"height,omitempty"`
+	// Terrain has one row per Y with one character per X: '.' is a road, '#' can't be crossed
+	// and digits are costs of slower cells. No terrain means every cell is a road.
+	Terrain []string `json:"terrain,omitempty" yaml:"terrain,omitempty"`
+}
+
+// Warehouse of the scenario, zero capacity, docks or unload ticks means no limit
+type Warehouse struct {
+	ID          uint   `json:"id" yaml:"id"`
+	Name        string `json:"name" yaml:"name"`
+	X           int    `json:"x" yaml:"x"`
+	Y           int    `json:"y" yaml:"y"`
+	Capacity    uint   `json:"capacity,omitempty" yaml:"capacity,omitempty"`
+	Docks       uint   `json:"docks,omitempty" yaml:"docks,omitempty"`
+	UnloadTicks uint   `json:"unload_ticks,omitempty" yaml:"unload_ticks,omitempty"`
+}
+
+// CargoUnit of the scenario and its start position
+type CargoUnit struct {
+	ID   uint   `json:"id" yaml:"id"`
+	Name string `json:"name" yaml:"name"`
+	X    int    `json:"x" yaml:"x"`
+	Y    int    `json:"y" yaml:"y"`
+	// Warehouses the unit is assigned to, it delivers to the one with the cheapest route.
+	// A unit without assigned warehouses delivers to one of the nearest.
+	Warehouses []uint `json:"warehouses,omitempty" yaml:"warehouses,omitempty"`
+	// MaxWeight in kilograms and MaxVolume in cubic meters the unit can carry, zero means the model default
+	MaxWeight float64 `json:"max_weight,omitempty" yaml:"max_weight,omitempty"`
+	MaxVolume float64 `json:"max_volume,omitempty" yaml:"max_volume,omitempty"`
+	// Speed in steps per tick, zero means one
+	Speed uint `json:"speed,omitempty" yaml:"speed,omitempty"`
+}
+
+// FormatFromPath picks the format by file extension
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	}
+
+	return "", fmt.Errorf("unknown scenario format of %q, expected .json, .yaml or .yml", path)
+}
+
+// Load and validate the scenario file
+func Load(path string) (*Scenario, error) {
+	format, formatErr := FormatFromPath(path)
+	if formatErr != nil {
+		return nil, formatErr
+	}
+
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	s, decodeErr := Decode(data, format)
+	if decodeErr != nil {
+		return nil, fmt.Errorf("failed to load scenario %s: %w", path, decodeErr)
+	}
+
+	return s, nil
+}
+
+// Save the scenario to the file, the format is picked by file extension
+func Save(path string, s *Scenario) error {
+	format, formatErr := FormatFromPath(path)
+	if formatErr != nil {
+		return formatErr
+	}
+
+	data, encodeErr := Encode(s, format)
+	if encodeErr != nil {
+		return encodeErr
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Decode and validate the scenario
+func Decode(data []byte, format Format) (*Scenario, error) {
+	s := &Scenario{}
+
+	var decodeErr error
+	switch format {
+	case FormatJSON:
+		decodeErr = json.Unmarshal(data, s)
+	case FormatYAML:
+		decodeErr = yaml.Unmarshal(data, s)
+	default:
+		decodeErr = fmt.Errorf("unknown scenario format %q", format)
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	if validateErr := s.Validate(); validateErr != nil {
+		return nil, validateErr
+	}
+
+	return s, nil
+}
+
+// Encode the scenario
+func Encode(s *Scenario, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(s, "", "  ")
+	case FormatYAML:
+		return yaml.Marshal(s)
+	}
+
+	return nil, fmt.Errorf("unknown scenario format %q", format)
+}
+
+// Validate that the scenario has warehouses, actor IDs are unique, actors stand on passable cells
+// they can reach each other from and units are assigned to existing warehouses
+func (s *Scenario) Validate() error {
+	grid, gridErr := s.Grid.build()
+	if gridErr != nil {
+		return gridErr
+	}
+
+	var errs []error
+	if len(s.Warehouses) == 0 {
+		errs = append(errs, errors.New("scenario has no warehouses"))
+	}
+
+	ids := make(map[uint]bool)
+	checkActor := func(kind string, id uint, c model.Coordinate) {
+		if ids[id] {
+			errs = append(errs, fmt.Errorf("%s %d: duplicate ID", kind, id))
+		}
+		ids[id] = true
+
+		if !grid.InBounds(c) {
+			errs = append(errs, fmt.Errorf("%s %d: coordinate %d,%d is outside of %dx%d grid", kind, id, c.X, c.Y, grid.Width, grid.Height))
+		} else if !grid.Passable(c) {
+			errs = append(errs, fmt.Errorf("%s %d: coordinate %d,%d can't be crossed", kind, id, c.X, c.Y))
+		}
+	}
+
+	warehouseIDs := make(map[uint]bool)
+	for _, warehouse := range s.Warehouses {
+		checkActor("warehouse", warehouse.ID, model.Coordinate{X: warehouse.X, Y: warehouse.Y})
+		warehouseIDs[warehouse.ID] = true
+	}
+	for _, unit := range s.CargoUnits {
+		checkActor("cargo unit", unit.ID, model.Coordinate{X: unit.X, Y: unit.Y})
+
+		for _, warehouseID := range unit.Warehouses {
+			if !warehouseIDs[warehouseID] {
+				errs = append(errs, fmt.Errorf("cargo unit %d: assigned to unknown warehouse %d", unit.ID, warehouseID))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// Like in generated worlds, every actor can be reached from the first warehouse through the terrain
+	first := s.Warehouses[0]
+	region := pathfinder.NewRegion(grid, model.Coordinate{X: first.X, Y: first.Y})
+	checkReachable := func(kind string, id uint, c model.Coordinate) {
+		if !region.Contains(c) {
+			errs = append(errs, fmt.Errorf("%s %d: coordinate %d,%d can't be reached from warehouse %d", kind, id, c.X, c.Y, first.ID))
+		}
+	}
+	for _, warehouse := range s.Warehouses[1:] {
+		checkReachable("warehouse", warehouse.ID, model.Coordinate{X: warehouse.X, Y: warehouse.Y})
+	}
+	for _, unit := range s.CargoUnits {
+		checkReachable("cargo unit", unit.ID, model.Coordinate{X: unit.X, Y: unit.Y})
+	}
+
+	return errors.Join(errs...)
+}
+
+// Build the world graph and its terrain grid from the scenario
+func (s *Scenario) Build() (*model.Graph, *model.Grid, error) {
+	if validateErr := s.Validate(); validateErr != nil {
+		return nil, nil, validateErr
+	}
+
+	grid, _ := s.Grid.build()
+	world := model.NewGraph()
+
+	for _, warehouse := range s.Warehouses {
+		world.AddNode(model.GraphNode{
+			ID:         warehouse.ID,
+			Name:       warehouse.Name,
+			Coordinate: &model.Coordinate{X: warehouse.X, Y: warehouse.Y},
+			Payload: model.WarehouseCapacity{
+				Capacity:    warehouse.Capacity,
+				Docks:       warehouse.Docks,
+				UnloadTicks: warehouse.UnloadTicks,
+			},
+		})
+	}
+	for _, unit := range s.CargoUnits {
+		state := model.NewCargoUnit(model.DefaultCargoUnitMaxWeight, model.DefaultCargoUnitMaxVolume)
+		if unit.MaxWeight > 0 {
+			state.MaxWeight = unit.MaxWeight
+		}
+		if unit.MaxVolume > 0 {
+			state.MaxVolume = unit.MaxVolume
+		}
+		if unit.Speed > 0 {
+			state.Speed = unit.Speed
+		}
+
+		world.AddNode(model.GraphNode{
+			ID:         unit.ID,
+			Name:       unit.Name,
+			Coordinate: &model.Coordinate{X: unit.X, Y: unit.Y},
+			Payload:    state,
+		})
+	}
+	for _, unit := range s.CargoUnits {
+		for _, warehouseID := range unit.Warehouses {
+			world.AddEdge(model.GraphEdge{Source: unit.ID, Target: warehouseID})
+		}
+	}
+
+	return world, grid, nil
+}
+
+// FromWorld describes the world graph and its terrain grid as a scenario, units are written at their current positions
+func FromWorld(world *model.Graph, grid *model.Grid) (*Scenario, error) {
+	world.RLock()
+	defer world.RUnlock()
+
+	terrain, terrainErr := encodeTerrain(grid)
+	if terrainErr != nil {
+		return nil, terrainErr
+	}
+
+	s := &Scenario{Grid: Grid{Width: grid.Width, Height: grid.Height, Terrain: terrain}}
+
+	assignments := make(map[uint][]uint)
+	for _, edge := range world.Edges {
+		assignments[edge.Source] = append(assignments[edge.Source], edge.Target)
+	}
+
+	for _, node := range world.Nodes {
+		switch node.Type() {
+		case model.Warehouses:
+			capacity, _ := node.Warehouse()
+			s.Warehouses = append(s.Warehouses, Warehouse{
+				ID:          node.ID,
+				Name:        node.Name,
+				X:           node.X,
+				Y:           node.Y,
+				Capacity:    capacity.Capacity,
+				Docks:       capacity.Docks,
+				UnloadTicks: capacity.UnloadTicks,
+			})
+		case model.CargoUnits:
+			unit := CargoUnit{
+				ID:         node.ID,
+				Name:       node.Name,
+				X:          node.X,
+				Y:          node.Y,
+				Warehouses: assignments[node.ID],
+			}
+			if state := node.CargoUnit(); state != nil {
+				unit.MaxWeight = state.MaxWeight
+				unit.MaxVolume = state.MaxVolume
+				unit.Speed = state.Speed
+			}
+			s.CargoUnits = append(s.CargoUnits, unit)
+		}
+	}
+
+	return s, nil
+}
+
+func (g Grid) build() (*model.Grid, error) {
+	width, height := g.Width, g.Height
+	if width == 0 && height == 0 {
+		width, height = model.GridWidth, model.GridHeight
+	}
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("grid size %dx%d must be positive", width, height)
+	}
+
+	grid := model.NewGrid(width, height)
+	if len(g.Terrain) == 0 {
+		return grid, nil
+	}
+	if len(g.Terrain) != height {
+		return nil, fmt.Errorf("terrain has %d rows, expected %d", len(g.Terrain), height)
+	}
+
+	for y, row := range g.Terrain {
+		if len(row) != width {
+			return nil, fmt.Errorf("terrain row %d has %d cells, expected %d", y, len(row), width)
+		}
+
+		for x := 0; x < len(row); x++ {
+			var cost uint8
+			switch cell := row[x]; {
+			case cell == cellBlocked:
+				cost = model.CostBlocked
+			case cell == cellRoad:
+				cost = model.CostRoad
+			case cell > '1' && cell <= '0'+maxCellCost:
+				cost = cell - '0'
+			default:
+				return nil, fmt.Errorf("terrain cell %d,%d has unknown value %q", x, y, cell)
+			}
+
+			grid.SetCost(model.Coordinate{X: x, Y: y}, cost)
+		}
+	}
+
+	return grid, nil
+}
+
+func encodeTerrain(grid *model.Grid) ([]string, error) {
+	terrain := make([]string, grid.Height)
+	row := make([]byte, grid.Width)
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			switch cost := grid.Cost(model.Coordinate{X: x, Y: y}); {
+			case cost == model.CostBlocked:
+				row[x] = cellBlocked
+			case cost == model.CostRoad:
+				row[x] = cellRoad
+			case cost <= maxCellCost:
+				row[x] = '0' + cost
+			default:
+				return nil, fmt.Errorf("terrain cell %d,%d costs %d, at most %d can be written", x, y, cost, maxCellCost)
+			}
+		}
+
+		terrain[y] = string(row)
+	}
+
+	return terrain, nil
+}
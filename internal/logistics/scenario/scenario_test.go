@@ -0,0 +1,168 @@
+package scenario
+
+import (
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/generator"
+)
+
+const testScenario = `
+name: two warehouses
+grid:
+  width: 5
+  height: 3
+  terrain:
+    - "..#.."
+    - ".3#.."
+    - "....."
+warehouses:
+  - id: 0
+    name: North
+    x: 4
+    y: 0
+    capacity: 2
+    docks: 1
+    unload_ticks: 2
+  - id: 1
+    name: South
+    x: 0
+    y: 2
+cargo_units:
+  - id: 2
+    name: Truck
+    x: 0
+    y: 0
+    warehouses: [0, 1]
+    max_weight: 500
+    speed: 2
+`
+
+func TestDecodeBuildsWorld(t *testing.T) {
+	s, decodeErr := Decode([]byte(testScenario), FormatYAML)
+	if decodeErr != nil {
+		t.Fatalf("Not expected error when decoding scenario, error: %v", decodeErr)
+	}
+
+	world, grid, buildErr := s.Build()
+	if buildErr != nil {
+		t.Fatalf("Not expected error when building world, error: %v", buildErr)
+	}
+
+	if grid.Width != 5 || grid.Height != 3 {
+		t.Errorf("Expected 5x3 grid, but got %dx%d", grid.Width, grid.Height)
+	}
+	if grid.Passable(model.Coordinate{X: 2, Y: 1}) || grid.Cost(model.Coordinate{X: 1, Y: 1}) != 3 {
+		t.Errorf("Expected terrain to be loaded from rows")
+	}
+
+	north := world.GetNodeByID(0)
+	if north.Name != "North" || north.Payload != (model.WarehouseCapacity{Capacity: 2, Docks: 1, UnloadTicks: 2}) {
+		t.Errorf("Expected warehouse North with capacity, but got %+v", north)
+	}
+
+	truck := world.GetNodeByID(2).CargoUnit()
+	if truck == nil || truck.MaxWeight != 500 || truck.MaxVolume != model.DefaultCargoUnitMaxVolume || truck.Speed != 2 {
+		t.Errorf("Expected unit Truck with its capacity and speed, but got %+v", truck)
+	}
+
+	connected := world.GetConnectedNodes(2, model.Warehouses)
+	if len(connected) != 2 || connected[0].ID != 0 || connected[1].ID != 1 {
+		t.Errorf("Expected unit assigned to warehouses 0 and 1, but got %+v", connected)
+	}
+}
+
+func TestValidateRejectsInvalidScenario(t *testing.T) {
+	s := &Scenario{
+		Grid:       Grid{Width: 3, Height: 1, Terrain: []string{".#."}},
+		Warehouses: []Warehouse{{ID: 0, X: 1, Y: 0}},
+		CargoUnits: []CargoUnit{{ID: 0, X: 5, Y: 0, Warehouses: []uint{7}}},
+	}
+
+	validateErr := s.Validate()
+	if validateErr == nil {
+		t.Fatalf("Expected error for invalid scenario")
+	}
+
+	for _, expected := range []string{"can't be crossed", "duplicate ID", "outside of 3x1 grid", "unknown warehouse 7"} {
+		if !strings.Contains(validateErr.Error(), expected) {
+			t.Errorf("Expected error to mention %q, but got: %v", expected, validateErr)
+		}
+	}
+}
+
+func TestValidateRejectsScenarioWithoutWarehouses(t *testing.T) {
+	s := &Scenario{
+		Grid:       Grid{Width: 3, Height: 1},
+		CargoUnits: []CargoUnit{{ID: 0, X: 0, Y: 0}},
+	}
+
+	validateErr := s.Validate()
+	if validateErr == nil || !strings.Contains(validateErr.Error(), "scenario has no warehouses") {
+		t.Errorf("Expected error for scenario without warehouses, but got: %v", validateErr)
+	}
+}
+
+func TestValidateRejectsUnreachableActors(t *testing.T) {
+	s := &Scenario{
+		Grid:       Grid{Width: 5, Height: 1, Terrain: []string{"..#.."}},
+		Warehouses: []Warehouse{{ID: 0, X: 0, Y: 0}, {ID: 1, X: 4, Y: 0}},
+		CargoUnits: []CargoUnit{{ID: 2, X: 1, Y: 0}, {ID: 3, X: 3, Y: 0}},
+	}
+
+	validateErr := s.Validate()
+	if validateErr == nil {
+		t.Fatalf("Expected error for actors cut off by terrain")
+	}
+
+	for _, expected := range []string{"warehouse 1: coordinate 4,0 can't be reached", "cargo unit 3: coordinate 3,0 can't be reached"} {
+		if !strings.Contains(validateErr.Error(), expected) {
+			t.Errorf("Expected error to mention %q, but got: %v", expected, validateErr)
+		}
+	}
+	if strings.Contains(validateErr.Error(), "cargo unit 2") {
+		t.Errorf("Expected reachable unit not to be reported, but got: %v", validateErr)
+	}
+}
+
+func TestRandomWorldRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	grid := generator.NewTerrain(rnd, model.GridWidth, model.GridHeight)
+	world := model.NewGraph()
+	generator.AddNewActors(rnd, model.Warehouses, world, 10, 0)
+	generator.AddNewActors(rnd, model.CargoUnits, world, 30, 10)
+	var actorCells []model.Coordinate
+	for _, node := range world.Nodes {
+		grid.SetCost(*node.Coordinate, model.CostRoad)
+		actorCells = append(actorCells, *node.Coordinate)
+		if node.Type() == model.CargoUnits {
+			world.AddEdge(model.GraphEdge{Source: node.ID, Target: node.ID % 10})
+		}
+	}
+	generator.ConnectCells(grid, actorCells)
+
+	exported, exportErr := FromWorld(world, grid)
+	if exportErr != nil {
+		t.Fatalf("Not expected error when exporting world, error: %v", exportErr)
+	}
+
+	for _, name := range []string{"world.yaml", "world.json"} {
+		path := filepath.Join(t.TempDir(), name)
+		if saveErr := Save(path, exported); saveErr != nil {
+			t.Fatalf("Not expected error when saving %s, error: %v", name, saveErr)
+		}
+
+		loaded, loadErr := Load(path)
+		if loadErr != nil {
+			t.Fatalf("Not expected error when loading %s, error: %v", name, loadErr)
+		}
+
+		if !reflect.DeepEqual(loaded, exported) {
+			t.Errorf("Expected %s to load the exported world back", name)
+		}
+	}
+}
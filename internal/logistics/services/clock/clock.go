@@ -0,0 +1,105 @@
+package clock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Mode decides how long a tick lasts in wall time
+type Mode string
+
+const (
+	// ModeRealtime lasts a tick as long as its simulated duration
+	ModeRealtime Mode = "realtime"
+	// ModeAccelerated lasts a tick its simulated duration divided by the speedup
+	ModeAccelerated Mode = "accelerated"
+	// ModeASAP starts the next tick as soon as the previous one is done
+	ModeASAP Mode = "asap"
+)
+
+// ParseMode from its name
+func ParseMode(name string) (Mode, error) {
+	switch mode := Mode(name); mode {
+	case ModeRealtime, ModeAccelerated, ModeASAP:
+		return mode, nil
+	}
+
+	return "", fmt.Errorf("unknown clock mode %q, expected %s, %s or %s", name, ModeRealtime, ModeAccelerated, ModeASAP)
+}
+
+// Clock of the simulation, it counts discrete ticks of tickDuration simulated time each
+type Clock struct {
+	mu   sync.Mutex
+	tick uint64
+
+	start        time.Time
+	tickDuration time.Duration
+	wallTick     time.Duration
+	wallStart    time.Time
+}
+
+// New clock with simulated time starting at start. Speedup is only used in ModeAccelerated.
+func New(start time.Time, tickDuration time.Duration, mode Mode, speedup float64) *Clock {
+	c := &Clock{start: start, tickDuration: tickDuration, wallStart: time.Now()}
+
+	switch mode {
+	case ModeRealtime:
+		c.wallTick = tickDuration
+	case ModeAccelerated:
+		if speedup > 0 {
+			c.wallTick = time.Duration(float64(tickDuration) / speedup)
+		}
+	}
+
+	return c
+}
+
+// Tick the simulation is at, starting at 0
+func (c *Clock) Tick() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.tick
+}
+
+// Now is the simulated time of the current tick
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.start.Add(time.Duration(c.tick) * c.tickDuration)
+}
+
+// Elapsed simulated time since start
+func (c *Clock) Elapsed() time.Duration {
+	return c.Now().Sub(c.start)
+}
+
+// Advance to the next tick, waiting until it is due in wall time. Deadlines of ticks are counted from
+// the clock creation, so a slow tick shortens the wait for the next one instead of shifting every following tick.
+func (c *Clock) Advance(ctx context.Context) error {
+	c.mu.Lock()
+	due := c.wallStart.Add(time.Duration(c.tick+1) * c.wallTick)
+	c.mu.Unlock()
+
+	if wait := time.Until(due); c.wallTick > 0 && wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	} else if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	c.mu.Lock()
+	c.tick++
+	c.mu.Unlock()
+
+	return nil
+}
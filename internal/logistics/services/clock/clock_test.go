@@ -0,0 +1,68 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseMode(t *testing.T) {
+	for _, name := range []string{"realtime", "accelerated", "asap"} {
+		if _, err := ParseMode(name); err != nil {
+			t.Errorf("Not expected error when parsing %q, error: %v", name, err)
+		}
+	}
+
+	if _, err := ParseMode("warp"); err == nil {
+		t.Errorf("Expected error for unknown mode")
+	}
+}
+
+func TestAdvanceASAP(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New(start, time.Hour, ModeASAP, 0)
+
+	began := time.Now()
+	for i := 0; i < 1000; i++ {
+		if err := c.Advance(context.Background()); err != nil {
+			t.Fatalf("Not expected error when advancing, error: %v", err)
+		}
+	}
+
+	if time.Since(began) > time.Second {
+		t.Errorf("Expected asap clock not to wait, but 1000 ticks took %s", time.Since(began))
+	}
+	if c.Tick() != 1000 || !c.Now().Equal(start.Add(1000*time.Hour)) || c.Elapsed() != 1000*time.Hour {
+		t.Errorf("Expected tick 1000 at %s, but got tick %d at %s", start.Add(1000*time.Hour), c.Tick(), c.Now())
+	}
+}
+
+func TestAdvanceAccelerated(t *testing.T) {
+	c := New(time.Now(), 100*time.Millisecond, ModeAccelerated, 10)
+
+	began := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := c.Advance(context.Background()); err != nil {
+			t.Fatalf("Not expected error when advancing, error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(began); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected 5 ticks to take at least 50ms, but took %s", elapsed)
+	}
+}
+
+func TestAdvanceCanceled(t *testing.T) {
+	c := New(time.Now(), time.Hour, ModeRealtime, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := c.Advance(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected canceled error, but got %v", err)
+	}
+	if c.Tick() != 0 {
+		t.Errorf("Expected canceled advance to keep tick 0, but got %d", c.Tick())
+	}
+}
@@ -2,41 +2,44 @@ package generator
 
 import (
 	"fmt"
-	"sync"
+	"math/rand"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
 )
 
-// AddNewActors by type to the model.Graph with actorNumber and from what ID it must be added (idPrefix)
-func AddNewActors(t model.ActorType, g *model.Graph, actorNumber uint, idPrefix uint) {
-	locsAndRange := int(actorNumber)
-	locations := NewCoordinates(locsAndRange, 255, 255)
+const (
+	minWarehouseCapacity = 4
+	maxWarehouseCapacity = 32
+	maxWarehouseDocks    = 4
+	maxUnloadTicks       = 3
+)
 
-	var wg sync.WaitGroup
-	wg.Add(int(actorNumber))
+// AddNewActors by type to the model.Graph with actorNumber and from what ID it must be added (idPrefix).
+// Actors are generated sequentially from rnd, so the same seed always yields the same actors in the same order.
+func AddNewActors(rnd *rand.Rand, t model.ActorType, g *model.Graph, actorNumber uint, idPrefix uint) {
+	locsAndRange := int(actorNumber)
+	locations := NewCoordinates(rnd, locsAndRange, model.GridWidth, model.GridHeight)
+	faker := &gofakeit.Faker{Rand: rnd}
 
 	for i := uint(0); i < actorNumber; i++ {
-		go func(i uint) {
-			defer wg.Done()
-
-			actorNode := model.GraphNode{ID: idPrefix + i}
-
-			switch t {
-			case model.Warehouses:
-				actorNode.Name = fmt.Sprintf("Warehouse: %s - %s", gofakeit.City(), gofakeit.Company())
-				actorNode.Type = model.Warehouses
-			case model.CargoUnits:
-				actorNode.Name = fmt.Sprintf("CargoUnit: %s - %s", gofakeit.CarMaker(), gofakeit.CarModel())
-				actorNode.Type = model.CargoUnits
-				actorNode.Metadata = false // Used to indicate if unit reached objective
+		actorNode := model.GraphNode{ID: idPrefix + i}
+
+		switch t {
+		case model.Warehouses:
+			actorNode.Name = fmt.Sprintf("Warehouse: %s - %s", faker.City(), faker.Company())
+			actorNode.Payload = model.WarehouseCapacity{
+				Capacity:    uint(rnd.Intn(maxWarehouseCapacity-minWarehouseCapacity+1) + minWarehouseCapacity),
+				Docks:       uint(rnd.Intn(maxWarehouseDocks) + 1),
+				UnloadTicks: uint(rnd.Intn(maxUnloadTicks) + 1),
 			}
+		case model.CargoUnits:
+			actorNode.Name = fmt.Sprintf("CargoUnit: %s - %s", faker.CarMaker(), faker.CarModel())
+			actorNode.Payload = model.NewCargoUnit(model.DefaultCargoUnitMaxWeight, model.DefaultCargoUnitMaxVolume)
+		}
 
-			actorNode.Coordinate = &locations[i]
+		actorNode.Coordinate = &locations[i]
 
-			g.AddNode(actorNode)
-		}(i)
+		g.AddNode(actorNode)
 	}
-
-	wg.Wait()
 }
@@ -7,12 +7,12 @@ import (
 )
 
 // NewCoordinates with unique placement
-func NewCoordinates(numCoordinates, xRange, yRange int) []model.Coordinate {
+func NewCoordinates(rnd *rand.Rand, numCoordinates, xRange, yRange int) []model.Coordinate {
 	coordinates := make([]model.Coordinate, numCoordinates)
 
 	for i := 0; i < numCoordinates; i++ {
-		x := rand.Intn(xRange)
-		y := rand.Intn(yRange)
+		x := rnd.Intn(xRange)
+		y := rnd.Intn(yRange)
 
 		coordinates[i] = model.Coordinate{X: x, Y: y}
 	}
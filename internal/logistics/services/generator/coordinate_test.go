@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
@@ -11,7 +12,7 @@ func TestNewCoordinates(t *testing.T) {
 	xRange := 100
 	yRange := 100
 
-	coordinates := NewCoordinates(numCoordinates, xRange, yRange)
+	coordinates := NewCoordinates(rand.New(rand.NewSource(1)), numCoordinates, xRange, yRange)
 
 	// Check if the number of coordinates is correct
 	if len(coordinates) != numCoordinates {
@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+// MaxOrderWeight and MaxOrderVolume of a generated order, cargo units carrying orders must fit them
+const (
+	MaxOrderWeight = 1000.0 // kilograms
+	MaxOrderVolume = 10.0   // cubic meters
+)
+
+const (
+	minOrderWeight = 1.0  // kilograms
+	minOrderVolume = 0.01 // cubic meters
+
+	// poissonNormalMean above which Poisson arrivals are drawn from the normal approximation
+	poissonNormalMean = 30
+)
+
+// OrderStream configures how orders arrive
+type OrderStream struct {
+	// Rate is the mean number of orders arriving during a tick, arrivals are Poisson distributed
+	Rate float64
+	// BurstProbability is the chance of a burst during a tick, a burst brings BurstSize more orders on average
+	BurstProbability float64
+	BurstSize        float64
+	// Deadline of a normal priority order after its arrival, high priority orders get half of it
+	// and low priority ones twice as much
+	Deadline time.Duration
+	// Total number of orders to generate, zero generates them without an end
+	Total uint
+}
+
+// OrderGenerator produces orders between random warehouses. Orders are generated sequentially from rnd,
+// so the same seed always yields the same order stream.
+type OrderGenerator struct {
+	rnd        *rand.Rand
+	warehouses []uint
+	stream     OrderStream
+	generated  uint
+}
+
+// NewOrderGenerator of orders between the warehouses arriving as stream sets
+func NewOrderGenerator(rnd *rand.Rand, warehouses []uint, stream OrderStream) (*OrderGenerator, error) {
+	if len(warehouses) < 2 {
+		return nil, errors.New("orders need at least two warehouses")
+	}
+	if stream.Rate < 0 || stream.BurstSize < 0 {
+		return nil, errors.New("order rate and burst size can't be negative")
+	}
+	if stream.BurstProbability < 0 || stream.BurstProbability > 1 {
+		return nil, errors.New("order burst probability must be between 0 and 1")
+	}
+	if stream.Rate == 0 && (stream.BurstProbability == 0 || stream.BurstSize == 0) {
+		return nil, errors.New("orders never arrive with zero rate and no bursts")
+	}
+	if stream.Deadline <= 0 {
+		return nil, errors.New("order deadline must be positive")
+	}
+
+	return &OrderGenerator{
+		rnd:        rnd,
+		warehouses: append([]uint(nil), warehouses...),
+		stream:     stream,
+	}, nil
+}
+
+// Next orders arriving during the tick at now, IDs of orders start at 1 and follow the order of arrival
+func (g *OrderGenerator) Next(now time.Time) []model.Order {
+	if g.Done() {
+		return nil
+	}
+
+	arrivals := poisson(g.rnd, g.stream.Rate)
+	if g.rnd.Float64() < g.stream.BurstProbability {
+		arrivals += poisson(g.rnd, g.stream.BurstSize)
+	}
+	if g.stream.Total > 0 {
+		arrivals = min(arrivals, int(g.stream.Total-g.generated))
+	}
+
+	orders := make([]model.Order, 0, arrivals)
+	for i := 0; i < arrivals; i++ {
+		g.generated++
+		orders = append(orders, g.newOrder(g.generated, now))
+	}
+
+	return orders
+}
+
+// Done reports if every order of the stream was generated
+func (g *OrderGenerator) Done() bool {
+	return g.stream.Total > 0 && g.generated >= g.stream.Total
+}
+
+func (g *OrderGenerator) newOrder(id uint, now time.Time) model.Order {
+	origin := g.rnd.Intn(len(g.warehouses))
+	destination := g.rnd.Intn(len(g.warehouses) - 1)
+	if destination >= origin {
+		destination++
+	}
+
+	priority := model.OrderPriorityNormal
+	deadline := g.stream.Deadline
+	switch roll := g.rnd.Float64(); {
+	case roll < 0.2:
+		priority = model.OrderPriorityHigh
+		deadline /= 2
+	case roll < 0.4:
+		priority = model.OrderPriorityLow
+		deadline *= 2
+	}
+
+	return model.Order{
+		ID:          id,
+		Origin:      g.warehouses[origin],
+		Destination: g.warehouses[destination],
+		Weight:      round2(minOrderWeight + g.rnd.Float64()*(MaxOrderWeight-minOrderWeight)),
+		Volume:      round2(minOrderVolume + g.rnd.Float64()*(MaxOrderVolume-minOrderVolume)),
+		Priority:    priority,
+		CreatedAt:   now,
+		Deadline:    now.Add(deadline),
+	}
+}
+
+// poisson draws the number of arrivals with the mean, by Knuth's method for small means
+// and from the normal approximation for large ones
+func poisson(rnd *rand.Rand, mean float64) int {
+	if mean <= 0 {
+		return 0
+	}
+	if mean > poissonNormalMean {
+		return max(int(math.Round(mean+rnd.NormFloat64()*math.Sqrt(mean))), 0)
+	}
+
+	limit := math.Exp(-mean)
+	arrivals := 0
+	for p := rnd.Float64(); p > limit; p *= rnd.Float64() {
+		arrivals++
+	}
+
+	return arrivals
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOrderGeneratorArrivalRate(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	warehouses := []uint{0, 1, 2}
+
+	for _, stream := range []OrderStream{
+		{Rate: 2, Deadline: time.Hour},
+		{Rate: 50, Deadline: time.Hour},
+		{Rate: 1, BurstProbability: 0.1, BurstSize: 20, Deadline: time.Hour},
+	} {
+		orderGenerator, err := NewOrderGenerator(rand.New(rand.NewSource(1)), warehouses, stream)
+		if err != nil {
+			t.Fatalf("Not expected error when creating generator, error: %v", err)
+		}
+
+		const ticks = 2000
+		arrived := 0
+		for tick := 0; tick < ticks; tick++ {
+			for _, order := range orderGenerator.Next(now) {
+				arrived++
+				if order.ID != uint(arrived) {
+					t.Fatalf("Expected order %d, but got %d", arrived, order.ID)
+				}
+				if order.Origin == order.Destination {
+					t.Errorf("Expected order %d between two warehouses, but both are %d", order.ID, order.Origin)
+				}
+				if !order.Deadline.After(order.CreatedAt) {
+					t.Errorf("Expected deadline of order %d after its arrival", order.ID)
+				}
+			}
+		}
+
+		expected := stream.Rate + stream.BurstProbability*stream.BurstSize
+		if mean := float64(arrived) / ticks; math.Abs(mean-expected) > expected*0.1 {
+			t.Errorf("Expected %.2f orders per tick on average, but got %.2f", expected, mean)
+		}
+	}
+}
+
+func TestOrderGeneratorTotal(t *testing.T) {
+	stream := OrderStream{Rate: 3, Deadline: time.Hour, Total: 10}
+	generate := func() []uint {
+		orderGenerator, err := NewOrderGenerator(rand.New(rand.NewSource(7)), []uint{4, 5}, stream)
+		if err != nil {
+			t.Fatalf("Not expected error when creating generator, error: %v", err)
+		}
+
+		var origins []uint
+		for tick := 0; tick < 100; tick++ {
+			for _, order := range orderGenerator.Next(time.Time{}) {
+				origins = append(origins, order.Origin)
+			}
+		}
+		if !orderGenerator.Done() {
+			t.Errorf("Expected generator to be done")
+		}
+
+		return origins
+	}
+
+	origins := generate()
+	if len(origins) != int(stream.Total) {
+		t.Errorf("Expected %d orders, but got %d", stream.Total, len(origins))
+	}
+	if again := generate(); !reflect.DeepEqual(origins, again) {
+		t.Errorf("Expected the same orders from the same seed, but got %v and %v", origins, again)
+	}
+}
+
+func TestNewOrderGeneratorInvalidStream(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	if _, err := NewOrderGenerator(rnd, []uint{1}, OrderStream{Rate: 1, Deadline: time.Hour}); err == nil {
+		t.Errorf("Expected error with a single warehouse")
+	}
+	if _, err := NewOrderGenerator(rnd, []uint{1, 2}, OrderStream{Deadline: time.Hour}); err == nil {
+		t.Errorf("Expected error when orders never arrive")
+	}
+	if _, err := NewOrderGenerator(rnd, []uint{1, 2}, OrderStream{Rate: 1, BurstProbability: 2, Deadline: time.Hour}); err == nil {
+		t.Errorf("Expected error with burst probability above 1")
+	}
+}
@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"math/rand"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/pathfinder"
+)
+
+const (
+	maxLakes        = 12
+	maxLakeRadius   = 12
+	maxClosedRoads  = 30
+	maxClosedLength = 40
+	maxTrafficJams  = 25
+	maxTrafficSide  = 30
+	maxTrafficCost  = 6
+)
+
+// NewTerrain of width x height with random lakes and closed roads that can't be crossed
+// and traffic jams that are slower to cross than plain roads
+func NewTerrain(rnd *rand.Rand, width, height int) *model.Grid {
+	grid := model.NewGrid(width, height)
+
+	for i := rnd.Intn(maxTrafficJams + 1); i > 0; i-- {
+		corner := model.Coordinate{X: rnd.Intn(width), Y: rnd.Intn(height)}
+		w, h := rnd.Intn(maxTrafficSide)+1, rnd.Intn(maxTrafficSide)+1
+		cost := uint8(rnd.Intn(int(maxTrafficCost-model.CostRoad))) + model.CostRoad + 1
+
+		for x := corner.X; x < corner.X+w; x++ {
+			for y := corner.Y; y < corner.Y+h; y++ {
+				grid.SetCost(model.Coordinate{X: x, Y: y}, cost)
+			}
+		}
+	}
+
+	for i := rnd.Intn(maxLakes + 1); i > 0; i-- {
+		center := model.Coordinate{X: rnd.Intn(width), Y: rnd.Intn(height)}
+		radius := rnd.Intn(maxLakeRadius) + 1
+
+		for x := center.X - radius; x <= center.X+radius; x++ {
+			for y := center.Y - radius; y <= center.Y+radius; y++ {
+				dx, dy := x-center.X, y-center.Y
+				if dx*dx+dy*dy <= radius*radius {
+					grid.SetCost(model.Coordinate{X: x, Y: y}, model.CostBlocked)
+				}
+			}
+		}
+	}
+
+	for i := rnd.Intn(maxClosedRoads + 1); i > 0; i-- {
+		start := model.Coordinate{X: rnd.Intn(width), Y: rnd.Intn(height)}
+		length := rnd.Intn(maxClosedLength) + 1
+		horizontal := rnd.Intn(2) == 0
+
+		for step := 0; step < length; step++ {
+			cell := start
+			if horizontal {
+				cell.X += step
+			} else {
+				cell.Y += step
+			}
+
+			grid.SetCost(cell, model.CostBlocked)
+		}
+	}
+
+	return grid
+}
+
+// ConnectCells opens roads through lakes and closed roads cutting cells off, so every cell can be reached
+// from the first one and units always have a way to their warehouses
+func ConnectCells(grid *model.Grid, cells []model.Coordinate) {
+	if len(cells) == 0 {
+		return
+	}
+
+	region := pathfinder.NewRegion(grid, cells[0])
+	for _, cell := range cells[1:] {
+		if region.Contains(cell) {
+			continue
+		}
+
+		openRoad(grid, cell, cells[0])
+		region.Extend(cell)
+	}
+}
+
+// openRoad from one cell to another, first along X then along Y so it can be walked without diagonal moves
+func openRoad(grid *model.Grid, from, to model.Coordinate) {
+	for current := from; ; {
+		if !grid.Passable(current) {
+			grid.SetCost(current, model.CostRoad)
+		}
+		if current == to {
+			return
+		}
+
+		if current.X != to.X {
+			current.X += sign(to.X - current.X)
+		} else {
+			current.Y += sign(to.Y - current.Y)
+		}
+	}
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/pathfinder"
+)
+
+func TestConnectCellsOpensRoadsToEnclosedCells(t *testing.T) {
+	grid := model.NewGrid(10, 10)
+	// Lake ring around the cell at 7,7
+	for x := 5; x <= 9; x++ {
+		for y := 5; y <= 9; y++ {
+			if x != 7 || y != 7 {
+				grid.SetCost(model.Coordinate{X: x, Y: y}, model.CostBlocked)
+			}
+		}
+	}
+
+	cells := []model.Coordinate{{X: 0, Y: 0}, {X: 7, Y: 7}, {X: 2, Y: 3}}
+	ConnectCells(grid, cells)
+
+	region := pathfinder.NewRegion(grid, cells[0])
+	for _, cell := range cells {
+		if !region.Contains(cell) {
+			t.Errorf("Expected cell %v to be reachable from %v", cell, cells[0])
+		}
+	}
+	if grid.Passable(model.Coordinate{X: 9, Y: 9}) {
+		t.Errorf("Expected only the road to be opened through the lake")
+	}
+}
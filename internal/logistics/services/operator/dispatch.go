@@ -0,0 +1,100 @@
+package operator
+
+import (
+	"sort"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/pathfinder"
+)
+
+// Dispatch assigns orders to free cargo units, a unit is free when it has no stops left and is not broken.
+// Orders are taken as model.Order.Before sorts them and each goes to the free unit that can carry it with the lowest
+// possible path cost to its origin. An assigned order becomes the shipment of its unit with the same ID, picked up at the origin and
+// dropped at the destination, and the unit leaves for the origin. Orders left without a free unit are pending.
+func (g *GlobalOperator) Dispatch(orders []model.Order) (assigned, pending []model.Order) {
+	sorted := append([]model.Order(nil), orders...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(&sorted[j]) })
+
+	g.yardsMu.Lock()
+	defer g.yardsMu.Unlock()
+
+	free := g.freeUnits()
+
+	for _, order := range sorted {
+		origin := g.world.GetNodeByID(order.Origin)
+		closest := -1
+		for i, unit := range free {
+			if !unit.CargoUnit().Fits(order.Weight, order.Volume) {
+				continue
+			}
+			if closest < 0 || pathfinder.MinCost(*unit.Coordinate, *origin.Coordinate) < pathfinder.MinCost(*free[closest].Coordinate, *origin.Coordinate) {
+				closest = i
+			}
+		}
+		if closest < 0 {
+			pending = append(pending, order)
+			continue
+		}
+		unit := free[closest]
+		free = append(free[:closest], free[closest+1:]...)
+
+		g.assign(unit, []model.Stop{
+			{WarehouseID: order.Origin, ShipmentID: order.ID},
+			{WarehouseID: order.Destination, ShipmentID: order.ID},
+		}, order)
+
+		order.Status = model.OrderAssigned
+		order.UnitID = unit.ID
+		assigned = append(assigned, order)
+	}
+
+	return assigned, pending
+}
+
+// freeUnits without stops left that are not broken
+func (g *GlobalOperator) freeUnits() []*model.GraphNode {
+	var free []*model.GraphNode
+	for _, unit := range g.GetDeliveryUnit() {
+		if len(g.itineraries[unit.ID]) == 0 && unit.CargoUnit().Status != model.CargoUnitBroken {
+			free = append(free, unit)
+		}
+	}
+
+	return free
+}
+
+// Carriable reports if any cargo unit that is not broken can carry goods of weight and volume once it is empty
+func (g *GlobalOperator) Carriable(weight, volume float64) bool {
+	for _, unit := range g.GetDeliveryUnit() {
+		cargoUnit := unit.CargoUnit()
+		if cargoUnit.Status != model.CargoUnitBroken && weight <= cargoUnit.MaxWeight && volume <= cargoUnit.MaxVolume {
+			return true
+		}
+	}
+
+	return false
+}
+
+// assign stops to the free unit, orders become shipments of the unit with the same ID and the unit leaves for
+// the first stop
+func (g *GlobalOperator) assign(unit *model.GraphNode, itinerary []model.Stop, orders ...model.Order) {
+	for _, order := range orders {
+		g.shipments[order.ID] = &model.Shipment{
+			ID:     order.ID,
+			UnitID: unit.ID,
+			Stops:  []uint{order.Origin, order.Destination},
+			Weight: order.Weight,
+			Volume: order.Volume,
+		}
+	}
+	g.itineraries[unit.ID] = itinerary
+	unit.CargoUnit().Status = model.CargoUnitEnRoute
+
+	route := g.routeTo(unit, itinerary[0].WarehouseID)
+
+	g.routesMu.Lock()
+	g.routes[unit.ID] = route
+	g.routesMu.Unlock()
+
+	g.waiting[unit.ID] = false
+}
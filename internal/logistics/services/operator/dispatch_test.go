@@ -0,0 +1,192 @@
+package operator
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+func TestDispatchAssignsOrdersToFreeUnits(t *testing.T) {
+	gOperator := New(rand.New(rand.NewSource(5)))
+	if err := gOperator.Populate(4, 3); err != nil {
+		t.Fatalf("Not expected error when populating world, error: %v", err)
+	}
+
+	orders := []model.Order{
+		{ID: 1, Origin: 0, Destination: 1, Priority: model.OrderPriorityLow},
+		{ID: 2, Origin: 1, Destination: 2, Priority: model.OrderPriorityNormal},
+		{ID: 3, Origin: 2, Destination: 3, Priority: model.OrderPriorityHigh},
+		{ID: 4, Origin: 3, Destination: 0, Priority: model.OrderPriorityNormal},
+	}
+
+	assigned, pending := gOperator.Dispatch(orders)
+	if len(assigned) != 3 || len(pending) != 1 || pending[0].ID != 1 {
+		t.Fatalf("Expected 3 orders assigned and low priority order 1 pending, but got %v and %v", assigned, pending)
+	}
+
+	units := make(map[uint]bool)
+	for _, order := range assigned {
+		if order.Status != model.OrderAssigned || units[order.UnitID] {
+			t.Errorf("Expected order %d assigned to a unit of its own, but got %+v", order.ID, order)
+		}
+		units[order.UnitID] = true
+
+		shipment, ok := gOperator.Shipment(order.UnitID)
+		if !ok || shipment.ID != order.ID || shipment.Pickup() != order.Origin || shipment.DropOffs()[0] != order.Destination {
+			t.Errorf("Expected unit %d to carry order %d as its shipment, but got %+v", order.UnitID, order.ID, shipment)
+		}
+	}
+
+	if assigned, _ := gOperator.Dispatch(pending); len(assigned) != 0 {
+		t.Errorf("Expected no free unit while every unit carries a shipment, but got %v", assigned)
+	}
+
+	driveShipments(t, gOperator)
+
+	assigned, pending = gOperator.Dispatch(pending)
+	if len(assigned) != 1 || len(pending) != 0 {
+		t.Errorf("Expected order 1 assigned once units delivered their shipments, but got %v and %v", assigned, pending)
+	}
+}
+
+func TestDispatchRoutesCarriesManyOrdersPerUnit(t *testing.T) {
+	gOperator := New(rand.New(rand.NewSource(5)))
+	if err := gOperator.Populate(4, 1); err != nil {
+		t.Fatalf("Not expected error when populating world, error: %v", err)
+	}
+
+	now := time.Now()
+	orders := []model.Order{
+		{ID: 1, Origin: 0, Destination: 1, Weight: 10, Volume: 1, Priority: model.OrderPriorityNormal, Deadline: now.Add(time.Hour)},
+		{ID: 2, Origin: 0, Destination: 2, Weight: 10, Volume: 1, Priority: model.OrderPriorityNormal, Deadline: now.Add(time.Hour)},
+		{ID: 3, Origin: 1, Destination: 3, Weight: 10, Volume: 1, Priority: model.OrderPriorityHigh, Deadline: now.Add(time.Hour)},
+	}
+
+	assigned, pending, plan := gOperator.DispatchRoutes(orders, now, time.Second)
+	if len(assigned)+len(pending) != len(orders) || len(assigned) < 2 || plan.Orders != len(assigned) {
+		t.Fatalf("Expected a single unit to carry many orders, but got %v and %v", assigned, pending)
+	}
+	if plan.Distance > plan.GreedyDistance {
+		t.Errorf("Expected routes no longer than greedy dispatch %d, but got %d", plan.GreedyDistance, plan.Distance)
+	}
+
+	if _, _, plan := gOperator.DispatchRoutes(pending, now, time.Second); plan.Orders != 0 {
+		t.Errorf("Expected no free unit while the unit has stops left, but got %+v", plan)
+	}
+
+	visited := driveShipments(t, gOperator)[assigned[0].UnitID]
+	for _, order := range assigned {
+		pickup := slices.Index(visited, order.Origin)
+		if pickup < 0 || !slices.Contains(visited[pickup+1:], order.Destination) {
+			t.Errorf("Expected order %d picked up at %d before it is dropped at %d, but unit visited %v", order.ID, order.Origin, order.Destination, visited)
+		}
+	}
+	for _, shipment := range gOperator.Shipments() {
+		if shipment.Status != model.ShipmentDelivered {
+			t.Errorf("Expected every routed order delivered, but got %+v", shipment)
+		}
+	}
+}
+
+func TestDispatchRoutesIgnoresOrderOfOrders(t *testing.T) {
+	now := time.Now()
+	var orders []model.Order
+	for id := uint(1); id <= 10; id++ {
+		// Orders between the same warehouses tie, so only their order could tell them apart
+		orders = append(orders, model.Order{
+			ID: id, Origin: id % 3, Destination: id%3 + 3, Weight: 10, Volume: 1,
+			Priority: model.OrderPriorityNormal, Deadline: now.Add(time.Hour),
+		})
+	}
+	shuffled := append([]model.Order(nil), orders...)
+	rand.New(rand.NewSource(3)).Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+	var itineraries []map[uint][]model.Stop
+	var plans []RoutePlan
+	for _, input := range [][]model.Order{orders, shuffled} {
+		gOperator := New(rand.New(rand.NewSource(5)))
+		if err := gOperator.Populate(6, 3); err != nil {
+			t.Fatalf("Not expected error when populating world, error: %v", err)
+		}
+
+		_, _, plan := gOperator.DispatchRoutes(input, now, time.Second)
+		plans = append(plans, plan)
+		itineraries = append(itineraries, gOperator.itineraries)
+	}
+
+	if !reflect.DeepEqual(plans[0], plans[1]) || !reflect.DeepEqual(itineraries[0], itineraries[1]) {
+		t.Errorf("Expected the same routes for shuffled orders, but got %+v %v and %+v %v", plans[0], itineraries[0], plans[1], itineraries[1])
+	}
+}
+
+func TestDispatchRoutesMeasuresWalkedSteps(t *testing.T) {
+	gOperator := New(rand.New(rand.NewSource(1)))
+	gOperator.grid = model.NewGrid(20, 20)
+	for y := 0; y < 15; y++ {
+		gOperator.grid.SetCost(model.Coordinate{X: 5, Y: y}, model.CostBlocked)
+	}
+
+	gOperator.world.AddNode(model.GraphNode{ID: 0, Coordinate: &model.Coordinate{X: 0, Y: 0}, Payload: model.WarehouseCapacity{}})
+	gOperator.world.AddNode(model.GraphNode{ID: 1, Coordinate: &model.Coordinate{X: 10, Y: 0}, Payload: model.WarehouseCapacity{}})
+	gOperator.world.AddNode(model.GraphNode{ID: 2, Coordinate: &model.Coordinate{X: 0, Y: 0}, Payload: model.NewCargoUnit(100, 100)})
+
+	now := time.Now()
+	orders := []model.Order{{ID: 1, Origin: 0, Destination: 1, Weight: 1, Volume: 1, Priority: model.OrderPriorityNormal, Deadline: now.Add(time.Hour)}}
+
+	// The wall between the warehouses makes the walk longer than the 10 steps of a straight line
+	walked := len(gOperator.stepsBetween(model.Coordinate{X: 0, Y: 0}, model.Coordinate{X: 10, Y: 0}))
+	_, _, plan := gOperator.DispatchRoutes(orders, now, time.Second)
+	if walked <= 10 || plan.Distance != walked || plan.GreedyDistance != walked {
+		t.Errorf("Expected plan and greedy distance of %d walked steps, but got %+v", walked, plan)
+	}
+}
+
+func TestCargoUnitCarriesLoadOfItsOrder(t *testing.T) {
+	gOperator := New(rand.New(rand.NewSource(5)))
+	if err := gOperator.Populate(2, 1); err != nil {
+		t.Fatalf("Not expected error when populating world, error: %v", err)
+	}
+	unitID := gOperator.GetDeliveryUnit()[0].ID
+	unit := gOperator.world.GetNodeByID(unitID).CargoUnit()
+
+	heavy := model.Order{ID: 1, Origin: 0, Destination: 1, Weight: unit.MaxWeight + 1, Volume: 1}
+	if assigned, _ := gOperator.Dispatch([]model.Order{heavy}); len(assigned) != 0 {
+		t.Fatalf("Expected order heavier than the unit pending, but got %v", assigned)
+	}
+
+	order := model.Order{ID: 2, Origin: 0, Destination: 1, Weight: 100, Volume: 2}
+	if assigned, _ := gOperator.Dispatch([]model.Order{order}); len(assigned) != 1 || unit.Status != model.CargoUnitEnRoute {
+		t.Fatalf("Expected unit en route to order 2, but got %v and %s", assigned, unit.Status)
+	}
+
+	for unit.Status == model.CargoUnitEnRoute {
+		old := *gOperator.world.GetNodeByID(unitID).Coordinate
+		if gOperator.MoveDeliveryUnitToNearestWarehouse(unitID) == old {
+			gOperator.ArriveAtWarehouse(unitID)
+		}
+	}
+	if unit.Status != model.CargoUnitLoading || unit.Fuel >= 100 {
+		t.Fatalf("Expected unit loading at the pickup after burning fuel, but got %+v", unit)
+	}
+	for tick := 0; len(gOperator.ProcessWarehouses()) == 0; tick++ {
+		if tick > 100 {
+			t.Fatalf("Expected unit loaded at the pickup")
+		}
+	}
+
+	if _, completeErr := gOperator.CompleteStop(unitID); completeErr != nil {
+		t.Fatalf("Not expected error when completing stop, error: %v", completeErr)
+	}
+	if unit.Weight != order.Weight || unit.Volume != order.Volume || unit.Fuel != 100 || unit.Status != model.CargoUnitEnRoute {
+		t.Errorf("Expected refueled unit carrying order 2 en route, but got %+v", unit)
+	}
+
+	driveShipments(t, gOperator)
+	if unit.Weight != 0 || unit.Volume != 0 || unit.Status != model.CargoUnitIdle {
+		t.Errorf("Expected empty idle unit once order 2 is delivered, but got %+v", unit)
+	}
+}
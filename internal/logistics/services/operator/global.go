@@ -3,38 +3,85 @@ package operator
 import (
 	"errors"
 	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/generator"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/pathfinder"
 	"math"
 	"math/rand"
+	"sort"
+	"sync"
 
 	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
 )
 
+// nearestCandidates is how many closest warehouses are considered for units without connected warehouses
+const nearestCandidates = 8
+
 // GlobalOperator that handles world and units movements
 type GlobalOperator struct {
 	world *model.Graph
+	grid  *model.Grid
+	rnd   *rand.Rand
+
+	routesMu sync.Mutex
+	routes   map[uint]*plannedRoute
+
+	yardsMu         sync.Mutex
+	yards           map[uint]*yard
+	waiting         map[uint]bool
+	triedWarehouses map[uint]map[uint]bool
+	// shipments by ID and the stops left to every unit that carries any, in the order they are visited
+	shipments   map[uint]*model.Shipment
+	itineraries map[uint][]model.Stop
+	tick        uint64
+}
+
+// plannedRoute of a delivery unit to its warehouse, steps already walked are removed
+type plannedRoute struct {
+	warehouseID uint
+	steps       []model.Coordinate
 }
 
-// New GlobalOperator instance
-func New() *GlobalOperator {
+// New GlobalOperator instance, every random decision about the world is taken from rnd
+func New(rnd *rand.Rand) *GlobalOperator {
 	return &GlobalOperator{
-		world: model.NewGraph(),
+		world:  model.NewGraph(),
+		grid:   model.NewGrid(model.GridWidth, model.GridHeight),
+		rnd:    rnd,
+		routes: make(map[uint]*plannedRoute),
+
+		yards:           make(map[uint]*yard),
+		waiting:         make(map[uint]bool),
+		triedWarehouses: make(map[uint]map[uint]bool),
+		shipments:       make(map[uint]*model.Shipment),
+		itineraries:     make(map[uint][]model.Stop),
 	}
 }
 
+// Populate the world with random warehouses and cargo units and connect them
 func (g *GlobalOperator) Populate(maxWarehouses, maxCargoUnits uint32) error {
 	if uint64(maxWarehouses)+uint64(maxCargoUnits) >= 4294967295 {
 		return errors.New("world actor count overflow")
 	}
 
-	generator.AddNewActors(model.Warehouses, g.world, uint(maxWarehouses), 0)
-	generator.AddNewActors(model.CargoUnits, g.world, uint(maxCargoUnits), uint(maxWarehouses))
+	g.grid = generator.NewTerrain(g.rnd, model.GridWidth, model.GridHeight)
+	generator.AddNewActors(g.rnd, model.Warehouses, g.world, uint(maxWarehouses), 0)
+	generator.AddNewActors(g.rnd, model.CargoUnits, g.world, uint(maxCargoUnits), uint(maxWarehouses))
+
+	// Actors are never placed in the water or on a closed road, nor cut off from each other by them
+	var actorCells []model.Coordinate
+	for _, node := range g.world.Nodes {
+		if !g.grid.Passable(*node.Coordinate) {
+			g.grid.SetCost(*node.Coordinate, model.CostRoad)
+		}
+		actorCells = append(actorCells, *node.Coordinate)
+	}
+	generator.ConnectCells(g.grid, actorCells)
 
 	var warehouseIDs []uint
 	var deliveryUnitIDs []uint
 	for _, node := range g.world.Nodes {
-		if node.Type == model.Warehouses {
+		if node.Type() == model.Warehouses {
 			warehouseIDs = append(warehouseIDs, node.ID)
-		} else if node.Type == model.CargoUnits {
+		} else if node.Type() == model.CargoUnits {
 			deliveryUnitIDs = append(deliveryUnitIDs, node.ID)
 		}
 	}
@@ -44,11 +91,11 @@ func (g *GlobalOperator) Populate(maxWarehouses, maxCargoUnits uint32) error {
 			break
 		}
 
-		rand.Shuffle(len(deliveryUnitIDs), func(i, j int) {
+		g.rnd.Shuffle(len(deliveryUnitIDs), func(i, j int) {
 			deliveryUnitIDs[i], deliveryUnitIDs[j] = deliveryUnitIDs[j], deliveryUnitIDs[i]
 		})
 
-		numDeliveryUnits := rand.Intn(len(deliveryUnitIDs)) + 1 // Random number of units to connect (at least 1)
+		numDeliveryUnits := g.rnd.Intn(len(deliveryUnitIDs)) + 1 // Random number of units to connect (at least 1)
 		for i := 0; i < numDeliveryUnits; i++ {
 			unitID := deliveryUnitIDs[i]
 
@@ -61,52 +108,128 @@ func (g *GlobalOperator) Populate(maxWarehouses, maxCargoUnits uint32) error {
 	return nil
 }
 
+// EquipCargoUnits with capacity of maxWeight and maxVolume, a zero limit keeps what units can carry
+func (g *GlobalOperator) EquipCargoUnits(maxWeight, maxVolume float64) {
+	for _, unit := range g.GetDeliveryUnit() {
+		if maxWeight > 0 {
+			unit.CargoUnit().MaxWeight = maxWeight
+		}
+		if maxVolume > 0 {
+			unit.CargoUnit().MaxVolume = maxVolume
+		}
+	}
+}
+
 // GetDeliveryUnit from the world
 func (g *GlobalOperator) GetDeliveryUnit() []*model.GraphNode {
 	return g.world.GetNodesByType(model.CargoUnits)
 }
 
+// GetWarehouses from the world
+func (g *GlobalOperator) GetWarehouses() []*model.GraphNode {
+	return g.world.GetNodesByType(model.Warehouses)
+}
+
+// GridWidth of the world terrain
+func (g *GlobalOperator) GridWidth() int {
+	return g.grid.Width
+}
+
+// GridHeight of the world terrain
+func (g *GlobalOperator) GridHeight() int {
+	return g.grid.Height
+}
+
 // FindEntityByCoordinate in the world
 func (g *GlobalOperator) FindEntityByCoordinate(coordinate model.Coordinate, entityType model.ActorType) *model.GraphNode {
 	return g.world.FindNodesByLocation(coordinate, entityType)
 }
 
-// MoveDeliveryUnitToNearestWarehouse moves the given unit to the nearest connected warehouse based on their X and Y locations
+// MoveDeliveryUnitToNearestWarehouse moves the given unit along the route to its warehouse by as many steps
+// as its speed, burning fuel of every step. The route is planned on the first move to the connected warehouse
+// with the lowest path cost and walked afterward, when the unit is already at the warehouse or broken
+// its coordinate stays the same.
 func (g *GlobalOperator) MoveDeliveryUnitToNearestWarehouse(unitID uint) model.Coordinate {
 	deliveryUnitNode := g.world.GetNodeByID(unitID)
-	unitX := deliveryUnitNode.X
-	unitY := deliveryUnitNode.Y
+	unit := deliveryUnitNode.CargoUnit()
 
-	connectedWarehouses := g.world.GetConnectedNodes(unitID, model.Warehouses)
+	route := g.unitRoute(deliveryUnitNode)
+	if len(route.steps) > 0 && unit.Status != model.CargoUnitBroken {
+		steps := min(len(route.steps), int(max(unit.Speed, 1)))
+		next := route.steps[steps-1]
+		route.steps = route.steps[steps:]
 
-	// Initialize variables for tracking the nearest warehouse
-	minDistance := math.MaxFloat64
-	nearestWarehouseID := uint(0)
+		g.world.MoveNode(unitID, next)
+		unit.Status = model.CargoUnitEnRoute
+		unit.Drive(steps)
 
-	for _, warehouseNode := range connectedWarehouses {
-		warehouseX := warehouseNode.X
-		warehouseY := warehouseNode.Y
+		return next
+	}
 
-		distance := math.Sqrt(math.Pow(float64(unitX-warehouseX), 2) + math.Pow(float64(unitY-warehouseY), 2))
+	return *deliveryUnitNode.Coordinate
+}
 
-		// Update nearest warehouse if distance is smaller
-		if distance < minDistance {
-			minDistance = distance
-			nearestWarehouseID = warehouseNode.ID
-		}
+// unitRoute returns the route planned for the unit, planning it when the unit has none yet
+func (g *GlobalOperator) unitRoute(unit *model.GraphNode) *plannedRoute {
+	g.routesMu.Lock()
+	route, ok := g.routes[unit.ID]
+	g.routesMu.Unlock()
+	if ok {
+		return route
 	}
 
-	// Move unit to goal
-	if unitX < g.world.GetNodeByID(nearestWarehouseID).X {
-		deliveryUnitNode.X++
-	} else if unitX > g.world.GetNodeByID(nearestWarehouseID).X {
-		deliveryUnitNode.X--
+	// A unit with no warehouse to go to stays where it is
+	route = g.planRoute(unit, nil)
+	if route == nil {
+		route = &plannedRoute{}
+	}
+
+	g.routesMu.Lock()
+	g.routes[unit.ID] = route
+	g.routesMu.Unlock()
+
+	return route
+}
+
+// planRoute to the connected warehouse with the lowest path cost, only warehouses passing accept (when set)
+// are considered. Units without connected warehouses go to the best of nearestCandidates closest warehouses.
+// Warehouses that can't be reached through the terrain are not considered either,
+// it returns nil when there is no warehouse to go to.
+func (g *GlobalOperator) planRoute(unit *model.GraphNode, accept func(warehouse *model.GraphNode) bool) *plannedRoute {
+	warehouses := g.world.GetConnectedNodes(unit.ID, model.Warehouses)
+	if len(warehouses) == 0 {
+		warehouses = g.world.NearestNodes(*unit.Coordinate, model.Warehouses, nearestCandidates)
+	}
+	if accept != nil {
+		accepted := warehouses[:0]
+		for _, warehouseNode := range warehouses {
+			if accept(warehouseNode) {
+				accepted = append(accepted, warehouseNode)
+			}
+		}
+		warehouses = accepted
 	}
-	if unitY < g.world.GetNodeByID(nearestWarehouseID).Y {
-		deliveryUnitNode.Y++
-	} else if unitY > g.world.GetNodeByID(nearestWarehouseID).Y {
-		deliveryUnitNode.Y--
+
+	// Candidates are tried from the lowest possible cost, once it's not lower than the best route found
+	// no remaining candidate can beat it
+	sort.SliceStable(warehouses, func(i, j int) bool {
+		return pathfinder.MinCost(*unit.Coordinate, *warehouses[i].Coordinate) <
+			pathfinder.MinCost(*unit.Coordinate, *warehouses[j].Coordinate)
+	})
+
+	var best *plannedRoute
+	bestCost := math.MaxInt
+	for _, warehouseNode := range warehouses {
+		if pathfinder.MinCost(*unit.Coordinate, *warehouseNode.Coordinate) >= bestCost {
+			break
+		}
+
+		route, found := pathfinder.FindRoute(g.grid, *unit.Coordinate, *warehouseNode.Coordinate)
+		if found && route.Cost < bestCost {
+			bestCost = route.Cost
+			best = &plannedRoute{warehouseID: warehouseNode.ID, steps: route.Steps}
+		}
 	}
 
-	return model.Coordinate{X: deliveryUnitNode.X, Y: deliveryUnitNode.Y}
+	return best
 }
@@ -1,6 +1,9 @@
 package operator
 
 import (
+	"fmt"
+	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
@@ -8,7 +11,7 @@ import (
 
 func TestNewGlobalOperator(t *testing.T) {
 	// Create a new GlobalOperator instance
-	gOperator := New()
+	gOperator := New(rand.New(rand.NewSource(1)))
 
 	populationErr := gOperator.Populate(2, 2)
 	if populationErr != nil {
@@ -30,7 +33,7 @@ func TestNewGlobalOperator(t *testing.T) {
 
 	// Check the Connected flag for each warehouse
 	for _, node := range gOperator.world.Nodes {
-		if node.Type == model.CargoUnits {
+		if node.Type() == model.CargoUnits {
 			if !node.Connected {
 				t.Errorf("Cargo unit with ID %d should not be connected, but it is", node.ID)
 			}
@@ -39,10 +42,131 @@ func TestNewGlobalOperator(t *testing.T) {
 }
 
 func TestNewGlobalOperatorActorOverflow(t *testing.T) {
-	gOperator := New()
+	gOperator := New(rand.New(rand.NewSource(1)))
 
 	populationErr := gOperator.Populate(^uint32(0), ^uint32(0))
 	if populationErr == nil {
 		t.Errorf("Expected error, since sum of max will overflow uint32")
 	}
 }
+
+func TestPopulateIsDeterministicForSeed(t *testing.T) {
+	first := New(rand.New(rand.NewSource(42)))
+	second := New(rand.New(rand.NewSource(42)))
+
+	if err := first.Populate(20, 50); err != nil {
+		t.Fatalf("Not expected error when populating first world, error: %v", err)
+	}
+	if err := second.Populate(20, 50); err != nil {
+		t.Fatalf("Not expected error when populating second world, error: %v", err)
+	}
+
+	if len(first.world.Nodes) != len(second.world.Nodes) {
+		t.Fatalf("Expected %d nodes, but got %d", len(first.world.Nodes), len(second.world.Nodes))
+	}
+	for i := range first.world.Nodes {
+		a, b := first.world.Nodes[i], second.world.Nodes[i]
+		if a.ID != b.ID || a.Name != b.Name || *a.Coordinate != *b.Coordinate {
+			t.Errorf("Node %d differs between runs with the same seed: %+v != %+v", i, a, b)
+		}
+	}
+
+	if !reflect.DeepEqual(first.world.Edges, second.world.Edges) {
+		t.Errorf("Edges differ between runs with the same seed")
+	}
+
+	// Walking every unit must produce the same trajectory as well
+	for _, unit := range first.GetDeliveryUnit() {
+		for step := 0; step < 10; step++ {
+			a := first.MoveDeliveryUnitToNearestWarehouse(unit.ID)
+			b := second.MoveDeliveryUnitToNearestWarehouse(unit.ID)
+			if a != b {
+				t.Fatalf("Unit %d moved to %v and %v on step %d with the same seed", unit.ID, a, b, step)
+			}
+		}
+	}
+}
+
+func TestMoveDeliveryUnitChoosesWarehouseByPathCost(t *testing.T) {
+	gOperator := New(rand.New(rand.NewSource(1)))
+	gOperator.grid = model.NewGrid(20, 20)
+
+	// Lake between the unit and the closest warehouse
+	for x := 0; x < 20; x++ {
+		if x != 19 {
+			gOperator.grid.SetCost(model.Coordinate{X: x, Y: 5}, model.CostBlocked)
+		}
+	}
+
+	gOperator.world.AddNode(model.GraphNode{ID: 0, Coordinate: &model.Coordinate{X: 0, Y: 7}, Payload: model.WarehouseCapacity{}})
+	gOperator.world.AddNode(model.GraphNode{ID: 1, Coordinate: &model.Coordinate{X: 8, Y: 0}, Payload: model.WarehouseCapacity{}})
+	gOperator.world.AddNode(model.GraphNode{ID: 2, Coordinate: &model.Coordinate{X: 0, Y: 3}, Payload: model.NewCargoUnit(1, 1)})
+	gOperator.world.AddEdge(model.GraphEdge{Source: 2, Target: 0})
+	gOperator.world.AddEdge(model.GraphEdge{Source: 2, Target: 1})
+
+	var position model.Coordinate
+	for step := 0; step < 100; step++ {
+		next := gOperator.MoveDeliveryUnitToNearestWarehouse(2)
+		if !gOperator.grid.Passable(next) {
+			t.Fatalf("Unit entered blocked cell %v", next)
+		}
+		if next == position {
+			break
+		}
+		position = next
+	}
+
+	expected := model.Coordinate{X: 8, Y: 0}
+	if position != expected {
+		t.Errorf("Expected unit to go around the lake to warehouse at %v, but it stopped at %v", expected, position)
+	}
+}
+
+func TestMoveDeliveryUnitSkipsUnreachableWarehouse(t *testing.T) {
+	gOperator := New(rand.New(rand.NewSource(1)))
+	gOperator.grid = model.NewGrid(10, 10)
+
+	// Closest warehouse is enclosed by a closed road
+	for x := 0; x < 10; x++ {
+		gOperator.grid.SetCost(model.Coordinate{X: x, Y: 4}, model.CostBlocked)
+	}
+
+	gOperator.world.AddNode(model.GraphNode{ID: 0, Coordinate: &model.Coordinate{X: 0, Y: 5}, Payload: model.WarehouseCapacity{}})
+	gOperator.world.AddNode(model.GraphNode{ID: 1, Coordinate: &model.Coordinate{X: 9, Y: 0}, Payload: model.WarehouseCapacity{}})
+	gOperator.world.AddNode(model.GraphNode{ID: 2, Coordinate: &model.Coordinate{X: 0, Y: 3}, Payload: model.NewCargoUnit(1, 1)})
+	gOperator.world.AddNode(model.GraphNode{ID: 3, Coordinate: &model.Coordinate{X: 2, Y: 2}, Payload: model.NewCargoUnit(1, 1)})
+	gOperator.world.AddEdge(model.GraphEdge{Source: 2, Target: 0})
+	gOperator.world.AddEdge(model.GraphEdge{Source: 2, Target: 1})
+	gOperator.world.AddEdge(model.GraphEdge{Source: 3, Target: 0})
+
+	if route := gOperator.unitRoute(gOperator.world.GetNodeByID(2)); route.warehouseID != 1 {
+		t.Errorf("Expected unit to go to the reachable warehouse 1, but it goes to %d", route.warehouseID)
+	}
+
+	expected := model.Coordinate{X: 2, Y: 2}
+	if position := gOperator.MoveDeliveryUnitToNearestWarehouse(3); position != expected {
+		t.Errorf("Expected unit without reachable warehouse to stay at %v, but it moved to %v", expected, position)
+	}
+}
+
+func BenchmarkMoveDeliveryUnitToNearestWarehouse(b *testing.B) {
+	for _, units := range []uint32{1_000, 10_000, 100_000} {
+		gOperator := New(rand.New(rand.NewSource(1)))
+		if err := gOperator.Populate(255, units); err != nil {
+			b.Fatalf("Not expected error when populating world, error: %v", err)
+		}
+
+		deliveryUnits := gOperator.GetDeliveryUnit()
+
+		// Routes are planned once per unit, so every unit makes its first move before measuring steps
+		for _, unit := range deliveryUnits {
+			gOperator.MoveDeliveryUnitToNearestWarehouse(unit.ID)
+		}
+
+		b.Run(fmt.Sprintf("units=%d", units), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				gOperator.MoveDeliveryUnitToNearestWarehouse(deliveryUnits[i%len(deliveryUnits)].ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package operator
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/pathfinder"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/vrp"
+)
+
+// RoutePlan of orders dispatched at once. Routes are planned with the terrain-blind estimate of pathfinder.MinCost,
+// their distances are the steps units walk along A* routes through the terrain.
+type RoutePlan struct {
+	Orders   int
+	Distance int
+	// GreedyDistance of the same orders carried one at a time by the closest free units, see vrp.Greedy
+	GreedyDistance int
+	// Late orders planned to be delivered after their deadline, deadlines are soft, see vrp.Solve
+	Late []uint
+}
+
+// DispatchRoutes plans routes carrying many orders at once for all free cargo units within their capacity, see vrp.Solve.
+// Deadlines are counted in ticks of tick from now and every stop takes at least a tick. Orders of a route become
+// shipments of its unit with the same ID and the unit leaves for the first stop. Orders left without a unit are
+// pending. The plan compares the distance of the routes with the greedy baseline for the same orders.
+// Orders are planned in dispatch order, see model.Order.Before, so the same orders give the same routes however they come.
+func (g *GlobalOperator) DispatchRoutes(orders []model.Order, now time.Time, tick time.Duration) (assigned, pending []model.Order, plan RoutePlan) {
+	sorted := append([]model.Order(nil), orders...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(&sorted[j]) })
+
+	g.yardsMu.Lock()
+	free := g.freeUnits()
+	if len(free) == 0 || len(sorted) == 0 {
+		g.yardsMu.Unlock()
+		return nil, sorted, RoutePlan{}
+	}
+
+	units := make(map[uint]*model.GraphNode)
+	vehicles := make([]vrp.Vehicle, 0, len(free))
+	for _, unit := range free {
+		units[unit.ID] = unit
+		vehicles = append(vehicles, vrp.Vehicle{
+			ID:        unit.ID,
+			Start:     *unit.Coordinate,
+			MaxWeight: unit.CargoUnit().MaxWeight,
+			MaxVolume: unit.CargoUnit().MaxVolume,
+		})
+	}
+
+	byID := make(map[uint]model.Order)
+	problem := make([]vrp.Order, 0, len(sorted))
+	for _, order := range sorted {
+		byID[order.ID] = order
+		problem = append(problem, vrp.Order{
+			ID:       order.ID,
+			Pickup:   *g.world.GetNodeByID(order.Origin).Coordinate,
+			DropOff:  *g.world.GetNodeByID(order.Destination).Coordinate,
+			Weight:   order.Weight,
+			Volume:   order.Volume,
+			Priority: int(order.Priority),
+			Deadline: int(order.Deadline.Sub(now) / tick),
+		})
+	}
+
+	// Searching the terrain for every leg the solvers try is too slow, they estimate legs like Dispatch does
+	opts := vrp.Options{ServiceTicks: 1, Distance: pathfinder.MinCost}
+	solution := vrp.Solve(vehicles, problem, opts)
+
+	unassigned := make(map[uint]bool)
+	for _, orderID := range solution.Unassigned {
+		unassigned[orderID] = true
+		pending = append(pending, byID[orderID])
+	}
+	var routed []vrp.Order
+	for _, order := range problem {
+		if !unassigned[order.ID] {
+			routed = append(routed, order)
+		}
+	}
+
+	for _, route := range solution.Routes {
+		unit := units[route.VehicleID]
+
+		var routeOrders []model.Order
+		itinerary := make([]model.Stop, 0, len(route.Stops))
+		for _, s := range route.Stops {
+			order := byID[s.OrderID]
+			warehouseID := order.Destination
+			if s.Pickup {
+				warehouseID = order.Origin
+
+				order.Status = model.OrderAssigned
+				order.UnitID = unit.ID
+				routeOrders = append(routeOrders, order)
+			}
+			itinerary = append(itinerary, model.Stop{WarehouseID: warehouseID, ShipmentID: order.ID})
+		}
+
+		g.assign(unit, itinerary, routeOrders...)
+		assigned = append(assigned, routeOrders...)
+	}
+	g.yardsMu.Unlock()
+	sort.Slice(assigned, func(i, j int) bool { return assigned[i].ID < assigned[j].ID })
+
+	// Measuring the routes searches the terrain, it is done without holding up units arriving at warehouses
+	walked := &walkedLegs{operator: g, steps: make(map[[2]model.Coordinate]int)}
+
+	return assigned, pending, RoutePlan{
+		Orders:         len(routed),
+		Distance:       walked.distance(vehicles, problem, solution),
+		GreedyDistance: walked.distance(vehicles, routed, vrp.Greedy(vehicles, routed, opts)),
+		Late:           solution.Late,
+	}
+}
+
+// walkedLegs measures planned routes by the steps units walk along A* routes through the terrain, see routeTo.
+// Every leg is searched once, the terrain doesn't change so no lock is needed.
+type walkedLegs struct {
+	operator *GlobalOperator
+	steps    map[[2]model.Coordinate]int
+}
+
+// distance of every route of the solution from the start of its vehicle
+func (w *walkedLegs) distance(vehicles []vrp.Vehicle, orders []vrp.Order, solution vrp.Solution) int {
+	starts := make(map[uint]model.Coordinate)
+	for _, vehicle := range vehicles {
+		starts[vehicle.ID] = vehicle.Start
+	}
+	byID := make(map[uint]vrp.Order)
+	for _, order := range orders {
+		byID[order.ID] = order
+	}
+
+	distance := 0
+	for _, route := range solution.Routes {
+		position := starts[route.VehicleID]
+		for _, s := range route.Stops {
+			next := byID[s.OrderID].DropOff
+			if s.Pickup {
+				next = byID[s.OrderID].Pickup
+			}
+
+			distance += w.leg(position, next)
+			position = next
+		}
+	}
+
+	return distance
+}
+
+func (w *walkedLegs) leg(from, to model.Coordinate) int {
+	key := [2]model.Coordinate{from, to}
+	steps, ok := w.steps[key]
+	if !ok {
+		steps = len(w.operator.stepsBetween(from, to))
+		w.steps[key] = steps
+	}
+
+	return steps
+}
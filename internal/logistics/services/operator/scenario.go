@@ -0,0 +1,29 @@
+package operator
+
+import (
+	"errors"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/scenario"
+)
+
+// PopulateFromScenario replaces the world with warehouses, cargo units and terrain described by the scenario
+func (g *GlobalOperator) PopulateFromScenario(s *scenario.Scenario) error {
+	if len(s.CargoUnits) == 0 {
+		return errors.New("scenario has no cargo units")
+	}
+
+	world, grid, buildErr := s.Build()
+	if buildErr != nil {
+		return buildErr
+	}
+
+	g.world = world
+	g.grid = grid
+
+	return nil
+}
+
+// Scenario describing the current world, it can be saved and loaded back with PopulateFromScenario
+func (g *GlobalOperator) Scenario() (*scenario.Scenario, error) {
+	return scenario.FromWorld(g.world, g.grid)
+}
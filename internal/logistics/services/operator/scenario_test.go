@@ -0,0 +1,30 @@
+package operator
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/scenario"
+)
+
+func TestPopulateFromScenarioRejectsEmptyWorld(t *testing.T) {
+	warehouses := []scenario.Warehouse{{ID: 0, Name: "Warehouse 0", X: 1, Y: 1}}
+	units := []scenario.CargoUnit{{ID: 1, Name: "Unit 1", X: 5, Y: 5}}
+
+	tests := map[string]*scenario.Scenario{
+		"no warehouses": {Grid: scenario.Grid{Width: 10, Height: 10}, CargoUnits: units},
+		"no units":      {Grid: scenario.Grid{Width: 10, Height: 10}, Warehouses: warehouses},
+	}
+	for name, s := range tests {
+		gOperator := New(rand.New(rand.NewSource(1)))
+		if err := gOperator.PopulateFromScenario(s); err == nil {
+			t.Errorf("Expected error when populating world from scenario with %s", name)
+		}
+	}
+
+	gOperator := New(rand.New(rand.NewSource(1)))
+	s := &scenario.Scenario{Grid: scenario.Grid{Width: 10, Height: 10}, Warehouses: warehouses, CargoUnits: units}
+	if err := gOperator.PopulateFromScenario(s); err != nil {
+		t.Errorf("Not expected error when populating world from scenario, error: %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package operator
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/services/pathfinder"
+)
+
+// PlanShipments gives every cargo unit a shipment. Goods are picked up at the warehouse the unit would go to
+// without a shipment and dropped at 1 to maxDropOffs other warehouses picked at random, in the order they
+// are visited. Units with a shipment wait at their stops instead of being rerouted from full warehouses.
+func (g *GlobalOperator) PlanShipments(maxDropOffs uint) error {
+	if maxDropOffs == 0 {
+		return errors.New("shipments need at least one drop-off")
+	}
+
+	warehouses := g.GetWarehouses()
+	if len(warehouses) < 2 {
+		return errors.New("shipments need at least two warehouses")
+	}
+
+	shipments := make(map[uint]*model.Shipment)
+	itineraries := make(map[uint][]model.Stop)
+	for i, unit := range g.GetDeliveryUnit() {
+		pickup := g.unitRoute(unit).warehouseID
+
+		dropOffs := make([]uint, 0, len(warehouses)-1)
+		for _, warehouse := range warehouses {
+			if warehouse.ID != pickup {
+				dropOffs = append(dropOffs, warehouse.ID)
+			}
+		}
+		g.rnd.Shuffle(len(dropOffs), func(i, j int) {
+			dropOffs[i], dropOffs[j] = dropOffs[j], dropOffs[i]
+		})
+		dropOffs = dropOffs[:g.rnd.Intn(min(int(maxDropOffs), len(dropOffs)))+1]
+
+		shipment := &model.Shipment{
+			ID:     uint(i + 1),
+			UnitID: unit.ID,
+			Stops:  append([]uint{pickup}, dropOffs...),
+		}
+		shipments[shipment.ID] = shipment
+		for _, warehouseID := range shipment.Stops {
+			itineraries[unit.ID] = append(itineraries[unit.ID], model.Stop{WarehouseID: warehouseID, ShipmentID: shipment.ID})
+		}
+		unit.CargoUnit().Status = model.CargoUnitEnRoute
+	}
+
+	g.yardsMu.Lock()
+	g.shipments = shipments
+	g.itineraries = itineraries
+	g.yardsMu.Unlock()
+
+	return nil
+}
+
+// Shipment handled at the next stop of the unit, ok is false when the unit has no stops left
+func (g *GlobalOperator) Shipment(unitID uint) (shipment model.Shipment, ok bool) {
+	g.yardsMu.Lock()
+	defer g.yardsMu.Unlock()
+
+	itinerary := g.itineraries[unitID]
+	if len(itinerary) == 0 {
+		return model.Shipment{}, false
+	}
+
+	return *g.shipments[itinerary[0].ShipmentID], true
+}
+
+// Shipments of every unit ordered by ID, delivered ones included
+func (g *GlobalOperator) Shipments() []model.Shipment {
+	g.yardsMu.Lock()
+	defer g.yardsMu.Unlock()
+
+	shipments := make([]model.Shipment, 0, len(g.shipments))
+	for _, shipment := range g.shipments {
+		shipments = append(shipments, *shipment)
+	}
+	sort.Slice(shipments, func(i, j int) bool { return shipments[i].ID < shipments[j].ID })
+
+	return shipments
+}
+
+// CompleteStop of the unit at the warehouse it was handled at. Goods are loaded at the pickup and unloaded
+// at the last drop-off, and the unit is refueled. The unit leaves for its next stop, after the last one it stays
+// idle at the warehouse. It returns the shipment handled at the stop after the stop.
+func (g *GlobalOperator) CompleteStop(unitID uint) (model.Shipment, error) {
+	g.yardsMu.Lock()
+	defer g.yardsMu.Unlock()
+
+	itinerary := g.itineraries[unitID]
+	if len(itinerary) == 0 {
+		return model.Shipment{}, errors.New("unit has no stops left")
+	}
+
+	node := g.world.GetNodeByID(unitID)
+	unit := node.CargoUnit()
+	unit.Refuel()
+
+	shipment := g.shipments[itinerary[0].ShipmentID]
+	if shipment.Next == 0 {
+		unit.Load(shipment.Weight, shipment.Volume)
+	}
+	shipment.CompleteStop()
+	if shipment.Status == model.ShipmentDelivered {
+		unit.Unload(shipment.Weight, shipment.Volume)
+	}
+
+	itinerary = itinerary[1:]
+	if len(itinerary) == 0 {
+		delete(g.itineraries, unitID)
+		unit.Status = model.CargoUnitIdle
+		return *shipment, nil
+	}
+	g.itineraries[unitID] = itinerary
+	unit.Status = model.CargoUnitEnRoute
+
+	route := g.routeTo(node, itinerary[0].WarehouseID)
+
+	g.routesMu.Lock()
+	g.routes[unitID] = route
+	g.routesMu.Unlock()
+
+	g.waiting[unitID] = false
+
+	return *shipment, nil
+}
+
+// routeTo the warehouse through the terrain, actors of a world can always reach each other (see Populate
+// and scenario.Validate)
+func (g *GlobalOperator) routeTo(unit *model.GraphNode, warehouseID uint) *plannedRoute {
+	warehouse := g.world.GetNodeByID(warehouseID)
+
+	return &plannedRoute{warehouseID: warehouseID, steps: g.stepsBetween(*unit.Coordinate, *warehouse.Coordinate)}
+}
+
+// stepsBetween the coordinates a unit walks, see routeTo
+func (g *GlobalOperator) stepsBetween(from, to model.Coordinate) []model.Coordinate {
+	route, _ := pathfinder.FindRoute(g.grid, from, to)
+
+	return route.Steps
+}
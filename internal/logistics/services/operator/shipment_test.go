@@ -0,0 +1,98 @@
+package operator
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+func TestShipmentVisitsEveryStopInOrder(t *testing.T) {
+	gOperator := New(rand.New(rand.NewSource(3)))
+	if err := gOperator.Populate(6, 10); err != nil {
+		t.Fatalf("Not expected error when populating world, error: %v", err)
+	}
+	if err := gOperator.PlanShipments(3); err != nil {
+		t.Fatalf("Not expected error when planning shipments, error: %v", err)
+	}
+
+	shipments := gOperator.Shipments()
+	if len(shipments) != 10 {
+		t.Fatalf("Expected a shipment of every unit, but got %d", len(shipments))
+	}
+
+	for _, shipment := range shipments {
+		if dropOffs := len(shipment.DropOffs()); dropOffs < 1 || dropOffs > 3 {
+			t.Errorf("Expected 1 to 3 drop-offs in shipment %d, but got %d", shipment.ID, dropOffs)
+		}
+		for _, dropOff := range shipment.DropOffs() {
+			if dropOff == shipment.Pickup() {
+				t.Errorf("Expected drop-offs of shipment %d to differ from its pickup %d", shipment.ID, dropOff)
+			}
+		}
+	}
+
+	visited := driveShipments(t, gOperator)
+
+	for _, shipment := range gOperator.Shipments() {
+		if shipment.Status != model.ShipmentDelivered {
+			t.Errorf("Expected shipment %d to be delivered, but it is %s", shipment.ID, shipment.Status)
+		}
+		if !reflect.DeepEqual(visited[shipment.UnitID], shipment.Stops) {
+			t.Errorf("Expected unit %d to visit %v, but it visited %v", shipment.UnitID, shipment.Stops, visited[shipment.UnitID])
+		}
+	}
+}
+
+func TestPlanShipmentsNeedsTwoWarehouses(t *testing.T) {
+	gOperator := New(rand.New(rand.NewSource(1)))
+	if err := gOperator.Populate(1, 3); err != nil {
+		t.Fatalf("Not expected error when populating world, error: %v", err)
+	}
+
+	if err := gOperator.PlanShipments(2); err == nil {
+		t.Errorf("Expected error when planning shipments with a single warehouse")
+	}
+}
+
+// driveShipments moves units with a shipment until every shipment is delivered, it returns warehouses
+// every unit was handled at in order
+func driveShipments(t *testing.T, gOperator *GlobalOperator) map[uint][]uint {
+	t.Helper()
+
+	visited := make(map[uint][]uint)
+	for tick := 0; tick < 10000; tick++ {
+		inTransit := 0
+		for _, unit := range gOperator.GetDeliveryUnit() {
+			shipment, ok := gOperator.Shipment(unit.ID)
+			if !ok || shipment.Status == model.ShipmentDelivered {
+				continue
+			}
+			inTransit++
+			if gOperator.IsWaiting(unit.ID) {
+				continue
+			}
+
+			old := *unit.Coordinate
+			if gOperator.MoveDeliveryUnitToNearestWarehouse(unit.ID) == old {
+				gOperator.ArriveAtWarehouse(unit.ID)
+			}
+		}
+		if inTransit == 0 {
+			return visited
+		}
+
+		for _, delivery := range gOperator.ProcessWarehouses() {
+			visited[delivery.UnitID] = append(visited[delivery.UnitID], delivery.WarehouseID)
+
+			if _, completeErr := gOperator.CompleteStop(delivery.UnitID); completeErr != nil {
+				t.Fatalf("Not expected error when completing stop, error: %v", completeErr)
+			}
+		}
+	}
+
+	t.Fatalf("Expected every shipment to be delivered")
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package operator
+
+import (
+	"sort"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+// Delivery of a unit that finished unloading at a warehouse
+type Delivery struct {
+	UnitID      uint
+	WarehouseID uint
+	// QueueLength is the number of units that were waiting for a dock when the unit arrived
+	QueueLength uint32
+	// WaitTicks the unit spent in the queue before it was docked
+	WaitTicks uint64
+}
+
+// yard of a warehouse holds units being unloaded at its docks and units waiting for a free dock,
+// units that arrive while it is full are held outside until it has room
+type yard struct {
+	capacity model.WarehouseCapacity
+	docked   []dockedUnit
+	queue    []queuedUnit
+	held     []queuedUnit
+	stats    model.WarehouseQueue
+}
+
+type queuedUnit struct {
+	unitID      uint
+	arrivedAt   uint64
+	queueLength uint32
+}
+
+type dockedUnit struct {
+	queuedUnit
+	waitTicks uint64
+	remaining uint
+}
+
+// full reports if the yard can't take in one more unit
+func (y *yard) full() bool {
+	return y.capacity.Capacity > 0 && uint(len(y.docked)+len(y.queue)) >= y.capacity.Capacity
+}
+
+// arrive puts the unit into the queue, or holds it until the yard has room when it is full or others are held already
+func (y *yard) arrive(unitID uint, tick uint64) {
+	unit := queuedUnit{unitID: unitID, arrivedAt: tick, queueLength: uint32(len(y.queue) + len(y.held))}
+	if y.full() || len(y.held) > 0 {
+		y.held = append(y.held, unit)
+		return
+	}
+
+	y.queue = append(y.queue, unit)
+}
+
+// process one tick: held units enter the yard while it has room, queued units take free docks,
+// then every docked unit is unloaded for one tick
+func (y *yard) process(tick uint64) []Delivery {
+	for len(y.held) > 0 && !y.full() {
+		y.queue = append(y.queue, y.held[0])
+		y.held = y.held[1:]
+	}
+
+	unloadTicks := max(y.capacity.UnloadTicks, 1)
+	for len(y.queue) > 0 && (y.capacity.Docks == 0 || uint(len(y.docked)) < y.capacity.Docks) {
+		unit := y.queue[0]
+		y.queue = y.queue[1:]
+
+		waitTicks := tick - unit.arrivedAt
+		if waitTicks > 0 {
+			y.stats.Queued++
+		}
+		y.stats.TotalWait += waitTicks
+		y.stats.MaxWait = max(y.stats.MaxWait, waitTicks)
+
+		y.docked = append(y.docked, dockedUnit{queuedUnit: unit, waitTicks: waitTicks, remaining: unloadTicks})
+	}
+	y.stats.MaxLength = max(y.stats.MaxLength, uint64(len(y.queue)+len(y.held)))
+
+	var deliveries []Delivery
+	docked := y.docked[:0]
+	for _, unit := range y.docked {
+		unit.remaining--
+		if unit.remaining > 0 {
+			docked = append(docked, unit)
+			continue
+		}
+
+		y.stats.Served++
+		deliveries = append(deliveries, Delivery{
+			UnitID:      unit.unitID,
+			WarehouseID: y.stats.WarehouseID,
+			QueueLength: unit.queueLength,
+			WaitTicks:   unit.waitTicks,
+		})
+	}
+	y.docked = docked
+
+	return deliveries
+}
+
+// IsWaiting reports if the unit stays at a warehouse, queued, unloading or already unloaded, and must not move
+func (g *GlobalOperator) IsWaiting(unitID uint) bool {
+	g.yardsMu.Lock()
+	defer g.yardsMu.Unlock()
+
+	return g.waiting[unitID]
+}
+
+// ArriveAtWarehouse puts the unit that reached its warehouse into the warehouse queue. When the warehouse is full
+// the unit is rerouted to the next connected warehouse that still has room and rerouted is true,
+// if there is none the unit is held at the full one until it has room. Units with stops left always wait for their stop.
+func (g *GlobalOperator) ArriveAtWarehouse(unitID uint) (rerouted bool) {
+	unit := g.world.GetNodeByID(unitID)
+	route := g.unitRoute(unit)
+
+	g.yardsMu.Lock()
+	defer g.yardsMu.Unlock()
+
+	warehouseYard := g.yardOf(route.warehouseID)
+	for warehouseYard.full() && len(g.itineraries[unitID]) == 0 {
+		if g.triedWarehouses[unitID] == nil {
+			g.triedWarehouses[unitID] = make(map[uint]bool)
+		}
+		g.triedWarehouses[unitID][route.warehouseID] = true
+
+		// Warehouses the unit can't go to are picked under the lock, the route to the others is searched
+		// without it so other units arriving at warehouses are not held up
+		skipped := make(map[uint]bool)
+		for warehouseID := range g.triedWarehouses[unitID] {
+			skipped[warehouseID] = true
+		}
+		for warehouseID, otherYard := range g.yards {
+			if otherYard.full() {
+				skipped[warehouseID] = true
+			}
+		}
+
+		g.yardsMu.Unlock()
+		alternative := g.planRoute(unit, func(warehouse *model.GraphNode) bool {
+			return !skipped[warehouse.ID]
+		})
+		g.yardsMu.Lock()
+
+		if alternative == nil {
+			break
+		}
+		// Other units may have filled the warehouse while the route was searched
+		if g.yardOf(alternative.warehouseID).full() {
+			g.triedWarehouses[unitID][alternative.warehouseID] = true
+			continue
+		}
+
+		warehouseYard.stats.Rerouted++
+
+		g.routesMu.Lock()
+		g.routes[unitID] = alternative
+		g.routesMu.Unlock()
+
+		return true
+	}
+
+	warehouseYard.arrive(unitID, g.tick)
+	g.waiting[unitID] = true
+
+	unit.CargoUnit().Status = model.CargoUnitUnloading
+	if itinerary := g.itineraries[unitID]; len(itinerary) > 0 && g.shipments[itinerary[0].ShipmentID].Next == 0 {
+		unit.CargoUnit().Status = model.CargoUnitLoading
+	}
+
+	return false
+}
+
+// ProcessWarehouses advances all warehouses by one tick and returns units that finished unloading,
+// ordered by warehouse ID
+func (g *GlobalOperator) ProcessWarehouses() []Delivery {
+	g.yardsMu.Lock()
+	defer g.yardsMu.Unlock()
+
+	var deliveries []Delivery
+	for _, warehouseID := range g.yardIDs() {
+		deliveries = append(deliveries, g.yards[warehouseID].process(g.tick)...)
+	}
+	g.tick++
+
+	return deliveries
+}
+
+// QueueStatistics of every warehouse a unit arrived at, ordered by warehouse ID
+func (g *GlobalOperator) QueueStatistics() []model.WarehouseQueue {
+	g.yardsMu.Lock()
+	defer g.yardsMu.Unlock()
+
+	var statistics []model.WarehouseQueue
+	for _, warehouseID := range g.yardIDs() {
+		statistics = append(statistics, g.yards[warehouseID].stats)
+	}
+
+	return statistics
+}
+
+// yardOf the warehouse, created on first use from capacity in the warehouse payload, g.yardsMu must be held
+func (g *GlobalOperator) yardOf(warehouseID uint) *yard {
+	warehouseYard, ok := g.yards[warehouseID]
+	if ok {
+		return warehouseYard
+	}
+
+	warehouseYard = &yard{stats: model.WarehouseQueue{WarehouseID: warehouseID}}
+	if warehouse := g.world.GetNodeByID(warehouseID); warehouse != nil {
+		warehouseYard.capacity, _ = warehouse.Warehouse()
+	}
+	g.yards[warehouseID] = warehouseYard
+
+	return warehouseYard
+}
+
+// yardIDs sorted, g.yardsMu must be held
+func (g *GlobalOperator) yardIDs() []uint {
+	ids := make([]uint, 0, len(g.yards))
+	for warehouseID := range g.yards {
+		ids = append(ids, warehouseID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
@@ -0,0 +1,128 @@
+package operator
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+func TestYardQueuesUnitsForFreeDocks(t *testing.T) {
+	warehouseYard := &yard{
+		capacity: model.WarehouseCapacity{Capacity: 4, Docks: 1, UnloadTicks: 2},
+		stats:    model.WarehouseQueue{WarehouseID: 7},
+	}
+
+	for unitID := uint(1); unitID <= 3; unitID++ {
+		warehouseYard.arrive(unitID, 0)
+	}
+
+	var deliveries []Delivery
+	for tick := uint64(0); tick < 6; tick++ {
+		deliveries = append(deliveries, warehouseYard.process(tick)...)
+	}
+
+	expected := []Delivery{
+		{UnitID: 1, WarehouseID: 7, QueueLength: 0, WaitTicks: 0},
+		{UnitID: 2, WarehouseID: 7, QueueLength: 1, WaitTicks: 2},
+		{UnitID: 3, WarehouseID: 7, QueueLength: 2, WaitTicks: 4},
+	}
+	if !reflect.DeepEqual(deliveries, expected) {
+		t.Errorf("Expected deliveries %+v, but got %+v", expected, deliveries)
+	}
+
+	expectedStats := model.WarehouseQueue{WarehouseID: 7, Served: 3, Queued: 2, MaxLength: 2, TotalWait: 6, MaxWait: 4}
+	if warehouseYard.stats != expectedStats {
+		t.Errorf("Expected statistics %+v, but got %+v", expectedStats, warehouseYard.stats)
+	}
+}
+
+func TestYardHoldsUnitsUntilItHasRoom(t *testing.T) {
+	warehouseYard := &yard{
+		capacity: model.WarehouseCapacity{Capacity: 2, Docks: 1, UnloadTicks: 2},
+		stats:    model.WarehouseQueue{WarehouseID: 7},
+	}
+
+	for unitID := uint(1); unitID <= 4; unitID++ {
+		warehouseYard.arrive(unitID, 0)
+	}
+
+	var delivered []uint
+	for tick := uint64(0); tick < 10; tick++ {
+		for _, delivery := range warehouseYard.process(tick) {
+			delivered = append(delivered, delivery.UnitID)
+		}
+		if admitted := len(warehouseYard.docked) + len(warehouseYard.queue); admitted > 2 {
+			t.Fatalf("Expected at most 2 units in the yard of capacity 2, but got %d at tick %d", admitted, tick)
+		}
+	}
+
+	if !reflect.DeepEqual(delivered, []uint{1, 2, 3, 4}) {
+		t.Errorf("Expected held units delivered in arrival order once the yard has room, but got %v", delivered)
+	}
+	if warehouseYard.stats.MaxLength != 3 || warehouseYard.stats.MaxWait != 6 {
+		t.Errorf("Expected held units counted as waiting, but got %+v", warehouseYard.stats)
+	}
+}
+
+func TestArriveAtFullWarehouseReroutes(t *testing.T) {
+	gOperator := New(rand.New(rand.NewSource(1)))
+	gOperator.grid = model.NewGrid(20, 20)
+
+	gOperator.world.AddNode(model.GraphNode{
+		ID: 0, Coordinate: &model.Coordinate{X: 0, Y: 0},
+		Payload: model.WarehouseCapacity{Capacity: 1, Docks: 1, UnloadTicks: 1},
+	})
+	gOperator.world.AddNode(model.GraphNode{
+		ID: 1, Coordinate: &model.Coordinate{X: 10, Y: 0},
+		Payload: model.WarehouseCapacity{Capacity: 1, Docks: 1, UnloadTicks: 1},
+	})
+	for unitID, coordinate := range map[uint]model.Coordinate{2: {X: 0, Y: 0}, 3: {X: 0, Y: 0}, 4: {X: 10, Y: 0}, 5: {X: 0, Y: 0}} {
+		gOperator.world.AddNode(model.GraphNode{ID: unitID, Coordinate: &coordinate, Payload: model.NewCargoUnit(1, 1)})
+		gOperator.world.AddEdge(model.GraphEdge{Source: unitID, Target: 0})
+		gOperator.world.AddEdge(model.GraphEdge{Source: unitID, Target: 1})
+	}
+
+	if gOperator.ArriveAtWarehouse(2) {
+		t.Errorf("Expected unit 2 to be queued at the empty warehouse")
+	}
+	if !gOperator.ArriveAtWarehouse(3) {
+		t.Errorf("Expected unit 3 to be rerouted from the full warehouse")
+	}
+	if gOperator.IsWaiting(3) {
+		t.Errorf("Expected rerouted unit 3 to keep moving")
+	}
+
+	// Unit 3 walks to the other warehouse
+	for step := 0; step < 20; step++ {
+		gOperator.MoveDeliveryUnitToNearestWarehouse(3)
+	}
+	if position := *gOperator.world.GetNodeByID(3).Coordinate; position != (model.Coordinate{X: 10, Y: 0}) {
+		t.Fatalf("Expected rerouted unit at the second warehouse, but it stopped at %v", position)
+	}
+
+	if gOperator.ArriveAtWarehouse(4) {
+		t.Errorf("Expected unit 4 to be queued at the empty warehouse")
+	}
+	if gOperator.ArriveAtWarehouse(5) {
+		t.Errorf("Expected unit 5 to wait, since no warehouse has room")
+	}
+	if !gOperator.IsWaiting(5) {
+		t.Errorf("Expected unit 5 to wait in the queue")
+	}
+
+	deliveries := gOperator.ProcessWarehouses()
+	if !reflect.DeepEqual(deliveries, []Delivery{{UnitID: 2}, {UnitID: 4, WarehouseID: 1}}) {
+		t.Errorf("Expected units 2 and 4 to be delivered first, but got %+v", deliveries)
+	}
+	deliveries = gOperator.ProcessWarehouses()
+	if !reflect.DeepEqual(deliveries, []Delivery{{UnitID: 5, QueueLength: 1, WaitTicks: 1}}) {
+		t.Errorf("Expected unit 5 to be delivered after waiting 1 tick, but got %+v", deliveries)
+	}
+
+	statistics := gOperator.QueueStatistics()
+	if len(statistics) != 2 || statistics[0].Rerouted != 1 || statistics[0].Served != 2 {
+		t.Errorf("Expected 2 served and 1 rerouted unit at the first warehouse, but got %+v", statistics)
+	}
+}
@@ -0,0 +1,203 @@
+package pathfinder
+
+import (
+	"container/heap"
+	"sync"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+// Route planned through the grid
+type Route struct {
+	// Steps from the cell after start up to the goal, one step per move
+	Steps []model.Coordinate
+	// Cost is the sum of costs of all entered cells
+	Cost int
+}
+
+// directions a unit can move in, diagonal moves take one step as well
+var directions = []model.Coordinate{
+	{X: 1, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: -1},
+	{X: 1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: 1}, {X: -1, Y: -1},
+}
+
+// FindRoute with the lowest cost from start to goal using A*. Diagonal moves can't cut corners of blocked cells.
+// The second return value is false when the goal can't be reached.
+func FindRoute(grid *model.Grid, start, goal model.Coordinate) (Route, bool) {
+	if !grid.InBounds(start) || !grid.Passable(goal) {
+		return Route{}, false
+	}
+	if start == goal {
+		return Route{}, true
+	}
+
+	state := acquireSearchState(grid.Width * grid.Height)
+	defer searchStates.Put(state)
+
+	index := func(c model.Coordinate) int { return c.Y*grid.Width + c.X }
+
+	state.setCost(index(start), 0, -1)
+	heap.Push(&state.open, node{coordinate: start, priority: heuristic(start, goal)})
+
+	for state.open.Len() > 0 {
+		current := heap.Pop(&state.open).(node)
+		currentIdx := index(current.coordinate)
+		if state.isClosed(currentIdx) {
+			continue
+		}
+		state.close(currentIdx)
+
+		if current.coordinate == goal {
+			return buildRoute(grid, state.previous, start, goal, int(state.costs[currentIdx])), true
+		}
+
+		for _, direction := range directions {
+			next, ok := step(grid, current.coordinate, direction)
+			if !ok {
+				continue
+			}
+
+			nextIdx := index(next)
+			if state.isClosed(nextIdx) {
+				continue
+			}
+
+			cost := int(state.costs[currentIdx]) + int(grid.Cost(next))
+			if previousCost, ok := state.cost(nextIdx); ok && previousCost <= cost {
+				continue
+			}
+
+			state.setCost(nextIdx, cost, currentIdx)
+			heap.Push(&state.open, node{coordinate: next, priority: cost + heuristic(next, goal), cost: cost})
+		}
+	}
+
+	return Route{}, false
+}
+
+// searchStates reuses per-cell buffers between searches, a search only touches cells around the route,
+// so clearing whole buffers on every search would cost more than the search itself
+var searchStates sync.Pool
+
+// searchState of a single A* search. A cell value is valid only when its stamp equals the current generation,
+// which lets a reused state start a new search without clearing the buffers.
+type searchState struct {
+	generation uint32
+	costStamp  []uint32
+	closeStamp []uint32
+	costs      []int32
+	previous   []int32
+	open       nodeQueue
+}
+
+func acquireSearchState(cells int) *searchState {
+	state, _ := searchStates.Get().(*searchState)
+	if state == nil || len(state.costs) != cells {
+		state = &searchState{
+			costStamp:  make([]uint32, cells),
+			closeStamp: make([]uint32, cells),
+			costs:      make([]int32, cells),
+			previous:   make([]int32, cells),
+		}
+	}
+
+	state.generation++
+	if state.generation == 0 { // Stamps wrapped around, old values could look valid again
+		clear(state.costStamp)
+		clear(state.closeStamp)
+		state.generation = 1
+	}
+	state.open = state.open[:0]
+
+	return state
+}
+
+func (s *searchState) cost(i int) (int, bool) {
+	if s.costStamp[i] != s.generation {
+		return 0, false
+	}
+	return int(s.costs[i]), true
+}
+
+func (s *searchState) setCost(i, cost, previous int) {
+	s.costStamp[i] = s.generation
+	s.costs[i] = int32(cost)
+	s.previous[i] = int32(previous)
+}
+
+func (s *searchState) isClosed(i int) bool { return s.closeStamp[i] == s.generation }
+
+func (s *searchState) close(i int) { s.closeStamp[i] = s.generation }
+
+// step from the cell in the direction, it is not possible into blocked cells or diagonally across corners of blocked cells
+func step(grid *model.Grid, from, direction model.Coordinate) (model.Coordinate, bool) {
+	next := model.Coordinate{X: from.X + direction.X, Y: from.Y + direction.Y}
+	if !grid.Passable(next) {
+		return next, false
+	}
+	if direction.X != 0 && direction.Y != 0 && (!grid.Passable(model.Coordinate{X: next.X, Y: from.Y}) ||
+		!grid.Passable(model.Coordinate{X: from.X, Y: next.Y})) {
+		return next, false
+	}
+
+	return next, true
+}
+
+func buildRoute(grid *model.Grid, previous []int32, start, goal model.Coordinate, cost int) Route {
+	startIdx := int32(start.Y*grid.Width + start.X)
+
+	var steps []model.Coordinate
+	for idx := int32(goal.Y*grid.Width + goal.X); idx != startIdx; idx = previous[idx] {
+		steps = append(steps, model.Coordinate{X: int(idx) % grid.Width, Y: int(idx) / grid.Width})
+	}
+
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+
+	return Route{Steps: steps, Cost: cost}
+}
+
+// MinCost is the lowest cost a route between the coordinates can have, whatever the terrain is
+func MinCost(from, to model.Coordinate) int {
+	return heuristic(from, to)
+}
+
+// heuristic is the Chebyshev distance, admissible because every step costs at least model.CostRoad
+func heuristic(from, to model.Coordinate) int {
+	return max(abs(from.X-to.X), abs(from.Y-to.Y)) * int(model.CostRoad)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+type node struct {
+	coordinate model.Coordinate
+	priority   int
+	cost       int
+}
+
+// nodeQueue is a min-heap by priority, ties are broken by higher cost to expand nodes closer to the goal first
+type nodeQueue []node
+
+func (q nodeQueue) Len() int { return len(q) }
+func (q nodeQueue) Less(i, j int) bool {
+	if q[i].priority == q[j].priority {
+		return q[i].cost > q[j].cost
+	}
+	return q[i].priority < q[j].priority
+}
+func (q nodeQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *nodeQueue) Push(x any) { *q = append(*q, x.(node)) }
+
+func (q *nodeQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,72 @@
+package pathfinder
+
+import (
+	"testing"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+func TestFindRouteAroundWall(t *testing.T) {
+	grid := model.NewGrid(10, 10)
+	for y := 0; y < 9; y++ {
+		grid.SetCost(model.Coordinate{X: 5, Y: y}, model.CostBlocked)
+	}
+
+	start := model.Coordinate{X: 0, Y: 0}
+	goal := model.Coordinate{X: 9, Y: 0}
+	route, found := FindRoute(grid, start, goal)
+	if !found {
+		t.Fatalf("Expected route around the wall to be found")
+	}
+
+	if route.Steps[len(route.Steps)-1] != goal {
+		t.Errorf("Expected route to end in %v, but it ends in %v", goal, route.Steps[len(route.Steps)-1])
+	}
+
+	previous := start
+	for _, step := range route.Steps {
+		if !grid.Passable(step) {
+			t.Errorf("Route enters blocked cell %v", step)
+		}
+		if abs(step.X-previous.X) > 1 || abs(step.Y-previous.Y) > 1 {
+			t.Errorf("Route jumps from %v to %v", previous, step)
+		}
+		previous = step
+	}
+
+	// The only gap is in the last row and corners of the wall can't be cut, so the unit goes
+	// down to (4,9), through the gap to (6,9) and back up
+	expectedCost := 20
+	if route.Cost != expectedCost {
+		t.Errorf("Expected route cost %d, but got %d", expectedCost, route.Cost)
+	}
+}
+
+func TestFindRoutePrefersCheaperDetour(t *testing.T) {
+	grid := model.NewGrid(10, 3)
+	for x := 1; x < 9; x++ {
+		grid.SetCost(model.Coordinate{X: x, Y: 1}, 9) // Traffic jam on the direct road
+	}
+
+	route, found := FindRoute(grid, model.Coordinate{X: 0, Y: 1}, model.Coordinate{X: 9, Y: 1})
+	if !found {
+		t.Fatalf("Expected route to be found")
+	}
+
+	for _, step := range route.Steps {
+		if grid.Cost(step) > model.CostRoad {
+			t.Errorf("Expected route to avoid traffic jam, but it enters %v", step)
+		}
+	}
+}
+
+func TestFindRouteUnreachable(t *testing.T) {
+	grid := model.NewGrid(5, 5)
+	for _, c := range []model.Coordinate{{X: 3, Y: 3}, {X: 3, Y: 4}, {X: 4, Y: 3}} {
+		grid.SetCost(c, model.CostBlocked)
+	}
+
+	if _, found := FindRoute(grid, model.Coordinate{X: 0, Y: 0}, model.Coordinate{X: 4, Y: 4}); found {
+		t.Errorf("Expected enclosed goal to be unreachable")
+	}
+}
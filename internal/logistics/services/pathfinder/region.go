@@ -0,0 +1,48 @@
+package pathfinder
+
+import (
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+// Region of grid cells a unit can walk between, found the way FindRoute steps
+type Region struct {
+	grid    *model.Grid
+	reached []bool
+}
+
+// NewRegion of cells reachable from start, it is empty when start can't be entered
+func NewRegion(grid *model.Grid, start model.Coordinate) *Region {
+	region := &Region{grid: grid, reached: make([]bool, grid.Width*grid.Height)}
+	region.Extend(start)
+
+	return region
+}
+
+// Contains reports if the cell is in the region
+func (r *Region) Contains(c model.Coordinate) bool {
+	return r.grid.InBounds(c) && r.reached[c.Y*r.grid.Width+c.X]
+}
+
+// Extend the region with cells reachable from c, used after the grid was changed to join c to the region
+func (r *Region) Extend(c model.Coordinate) {
+	if !r.grid.Passable(c) || r.Contains(c) {
+		return
+	}
+
+	r.reached[c.Y*r.grid.Width+c.X] = true
+	queue := []model.Coordinate{c}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, direction := range directions {
+			next, ok := step(r.grid, current, direction)
+			if !ok || r.Contains(next) {
+				continue
+			}
+
+			r.reached[next.Y*r.grid.Width+next.X] = true
+			queue = append(queue, next)
+		}
+	}
+}
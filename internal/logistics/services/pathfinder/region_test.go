@@ -0,0 +1,38 @@
+package pathfinder
+
+import (
+	"testing"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+func TestRegionStopsAtObstacles(t *testing.T) {
+	grid := model.NewGrid(5, 5)
+	for x := 0; x < 5; x++ {
+		grid.SetCost(model.Coordinate{X: x, Y: 2}, model.CostBlocked)
+	}
+
+	region := NewRegion(grid, model.Coordinate{X: 0, Y: 0})
+	if !region.Contains(model.Coordinate{X: 4, Y: 1}) {
+		t.Errorf("Expected cell on the same side of the wall to be in the region")
+	}
+	if region.Contains(model.Coordinate{X: 0, Y: 3}) {
+		t.Errorf("Expected cell behind the wall not to be in the region")
+	}
+
+	grid.SetCost(model.Coordinate{X: 3, Y: 2}, model.CostRoad)
+	region.Extend(model.Coordinate{X: 3, Y: 2})
+	if !region.Contains(model.Coordinate{X: 0, Y: 4}) {
+		t.Errorf("Expected cells behind the opened wall to join the region")
+	}
+}
+
+func TestRegionDoesNotCutCorners(t *testing.T) {
+	grid := model.NewGrid(2, 2)
+	grid.SetCost(model.Coordinate{X: 1, Y: 0}, model.CostBlocked)
+	grid.SetCost(model.Coordinate{X: 0, Y: 1}, model.CostBlocked)
+
+	if NewRegion(grid, model.Coordinate{X: 0, Y: 0}).Contains(model.Coordinate{X: 1, Y: 1}) {
+		t.Errorf("Expected diagonal cell behind blocked corners not to be in the region")
+	}
+}
@@ -0,0 +1,55 @@
+package vrp
+
+import "sort"
+
+// Greedy plans the baseline routes: orders are taken by priority and deadline and each one is appended
+// to the route of the vehicle that ends closest to its pickup, so a vehicle carries one order at a time
+func Greedy(vehicles []Vehicle, orders []Order, opts Options) Solution {
+	p := newProblem(orders, opts)
+
+	indexes := make([]int, len(orders))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.Slice(indexes, func(i, j int) bool { return p.before(indexes[i], indexes[j]) })
+
+	stops := make([][]stop, len(vehicles))
+	var unassigned []int
+	for _, order := range indexes {
+		closest := -1
+		closestDistance := 0
+		for i, vehicle := range vehicles {
+			if orders[order].Weight > vehicle.MaxWeight+capacityTolerance || orders[order].Volume > vehicle.MaxVolume+capacityTolerance {
+				continue
+			}
+
+			end := vehicle.Start
+			if len(stops[i]) > 0 {
+				end = p.location(stops[i][len(stops[i])-1])
+			}
+			if distance := p.distance(end, orders[order].Pickup); closest < 0 || distance < closestDistance {
+				closest, closestDistance = i, distance
+			}
+		}
+		if closest < 0 {
+			unassigned = append(unassigned, order)
+			continue
+		}
+
+		stops[closest] = append(stops[closest], stop{order: order, pickup: true}, stop{order: order})
+	}
+
+	var routes []Route
+	var routeStops [][]stop
+	for i, vehicle := range vehicles {
+		if len(stops[i]) == 0 {
+			continue
+		}
+
+		distance, lateness, _ := p.evaluate(vehicle.Start, stops[i], vehicle.MaxWeight, vehicle.MaxVolume)
+		routes = append(routes, Route{VehicleID: vehicle.ID, Distance: distance, Lateness: lateness, Late: p.late(vehicle.Start, stops[i])})
+		routeStops = append(routeStops, stops[i])
+	}
+
+	return p.solution(routes, routeStops, unassigned)
+}
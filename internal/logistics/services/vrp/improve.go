@@ -0,0 +1,150 @@
+package vrp
+
+import (
+	"sort"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+// maxOrOptSegment is the longest run of consecutive stops or-opt moves
+const maxOrOptSegment = 3
+
+// improve the route of a vehicle by 2-opt and or-opt moves until neither finds a better route.
+// 2-opt reverses the stops between two positions, or-opt moves up to maxOrOptSegment consecutive stops
+// to another position. A move is taken when the route stays feasible and is less late, or as late and shorter.
+func (p *problem) improve(vehicle Vehicle, stops []stop) (improved []stop, distance, lateness int) {
+	distance, lateness, _ = p.evaluate(vehicle.Start, stops, vehicle.MaxWeight, vehicle.MaxVolume)
+
+	try := func(candidate []stop) bool {
+		candidateDistance, candidateLateness, ok := p.evaluate(vehicle.Start, candidate, vehicle.MaxWeight, vehicle.MaxVolume)
+		if !ok || !better(candidateDistance, candidateLateness, distance, lateness) {
+			return false
+		}
+
+		stops, distance, lateness = candidate, candidateDistance, candidateLateness
+		return true
+	}
+
+	for moved := true; moved; {
+		moved = p.twoOpt(stops, try) || p.orOpt(stops, try)
+	}
+
+	return stops, distance, lateness
+}
+
+// twoOpt tries to reverse every run of stops, it stops at the first move try takes
+func (p *problem) twoOpt(stops []stop, try func([]stop) bool) bool {
+	for i := 0; i < len(stops)-1; i++ {
+		for j := i + 1; j < len(stops); j++ {
+			candidate := append([]stop(nil), stops...)
+			for l, r := i, j; l < r; l, r = l+1, r-1 {
+				candidate[l], candidate[r] = candidate[r], candidate[l]
+			}
+
+			if try(candidate) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// orOpt tries to move every run of up to maxOrOptSegment stops to every other position,
+// it stops at the first move try takes
+func (p *problem) orOpt(stops []stop, try func([]stop) bool) bool {
+	for length := 1; length <= min(maxOrOptSegment, len(stops)-1); length++ {
+		for i := 0; i+length <= len(stops); i++ {
+			segment := stops[i : i+length]
+			rest := append(append([]stop(nil), stops[:i]...), stops[i+length:]...)
+
+			for k := 0; k <= len(rest); k++ {
+				if k == i {
+					continue
+				}
+
+				candidate := make([]stop, 0, len(stops))
+				candidate = append(append(append(candidate, rest[:k]...), segment...), rest[k:]...)
+				if try(candidate) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// Solve plans routes of orders for the vehicles. Routes are built by the savings construction, see construct,
+// for the smallest capacity of all vehicles, so every route fits every vehicle. Routes with higher priority
+// orders and earlier deadlines get vehicles first, each one the vehicle it is least late and shortest with,
+// and are then improved by 2-opt and or-opt. Orders of routes left without a vehicle are unassigned.
+// Capacity is a hard constraint, deadlines are soft: orders that can't make theirs are planned anyway and listed in Late.
+func Solve(vehicles []Vehicle, orders []Order, opts Options) Solution {
+	p := newProblem(orders, opts)
+	if len(vehicles) == 0 || len(orders) == 0 {
+		unassigned := make([]int, len(orders))
+		for i := range unassigned {
+			unassigned[i] = i
+		}
+		return p.solution(nil, nil, unassigned)
+	}
+
+	starts := make([]model.Coordinate, 0, len(vehicles))
+	maxWeight, maxVolume := vehicles[0].MaxWeight, vehicles[0].MaxVolume
+	for _, vehicle := range vehicles {
+		starts = append(starts, vehicle.Start)
+		maxWeight = min(maxWeight, vehicle.MaxWeight)
+		maxVolume = min(maxVolume, vehicle.MaxVolume)
+	}
+
+	planned := p.construct(starts, maxWeight, maxVolume)
+
+	// Routes are ranked by their most urgent order
+	urgent := make([]int, len(planned))
+	ranking := make([]int, len(planned))
+	for i, stops := range planned {
+		ranking[i] = i
+		urgent[i] = stops[0].order
+		for _, s := range stops[1:] {
+			if p.before(s.order, urgent[i]) {
+				urgent[i] = s.order
+			}
+		}
+	}
+	sort.SliceStable(ranking, func(i, j int) bool { return p.before(urgent[ranking[i]], urgent[ranking[j]]) })
+
+	free := append([]Vehicle(nil), vehicles...)
+	var routes []Route
+	var routeStops [][]stop
+	var unassigned []int
+	for _, i := range ranking {
+		stops := planned[i]
+
+		chosen := -1
+		var chosenDistance, chosenLateness int
+		for v, vehicle := range free {
+			distance, lateness, ok := p.evaluate(vehicle.Start, stops, vehicle.MaxWeight, vehicle.MaxVolume)
+			if ok && (chosen < 0 || better(distance, lateness, chosenDistance, chosenLateness)) {
+				chosen, chosenDistance, chosenLateness = v, distance, lateness
+			}
+		}
+		if chosen < 0 {
+			for _, s := range stops {
+				if s.pickup {
+					unassigned = append(unassigned, s.order)
+				}
+			}
+			continue
+		}
+
+		vehicle := free[chosen]
+		free = append(free[:chosen], free[chosen+1:]...)
+
+		improved, distance, lateness := p.improve(vehicle, stops)
+		routes = append(routes, Route{VehicleID: vehicle.ID, Distance: distance, Lateness: lateness, Late: p.late(vehicle.Start, improved)})
+		routeStops = append(routeStops, improved)
+	}
+
+	return p.solution(routes, routeStops, unassigned)
+}
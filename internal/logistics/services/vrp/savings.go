@@ -0,0 +1,151 @@
+package vrp
+
+import (
+	"container/heap"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+// savingsRoute is a route built by the savings construction, it is not assigned to a vehicle yet
+type savingsRoute struct {
+	id      int
+	stops   []stop
+	version int
+	merged  bool
+	// cost includes the approach from the closest vehicle start
+	cost     int
+	lateness int
+}
+
+// merge of two routes saving distance over driving them apart
+type merge struct {
+	into, from               *savingsRoute
+	intoVersion, fromVersion int
+	stops                    []stop
+	saving                   int
+	lateness                 int
+}
+
+// construct routes by the savings method of Clarke and Wright. Every order starts in a route of its own,
+// then the pair of routes saving the most distance is merged while merges save anything. Unlike the classic
+// method, which only joins routes at their ends, a route is inserted as a whole between any two stops of the other
+// one, so goods of several orders can be carried at once. Merges must fit maxWeight and maxVolume at every stop
+// and must not make orders later. Routes are driven from the closest vehicle start.
+func (p *problem) construct(starts []model.Coordinate, maxWeight, maxVolume float64) [][]stop {
+	routes := make([]*savingsRoute, len(p.orders))
+	for i := range p.orders {
+		routes[i] = &savingsRoute{id: i, stops: []stop{{order: i, pickup: true}, {order: i}}}
+		routes[i].cost, routes[i].lateness, _ = p.evaluate(p.closest(starts, routes[i].stops), routes[i].stops, maxWeight, maxVolume)
+	}
+
+	var candidates mergeQueue
+	for i := range routes {
+		for j := i + 1; j < len(routes); j++ {
+			if m, ok := p.bestMerge(routes[i], routes[j], starts, maxWeight, maxVolume); ok {
+				candidates = append(candidates, m)
+			}
+		}
+	}
+	heap.Init(&candidates)
+
+	for candidates.Len() > 0 {
+		m := heap.Pop(&candidates).(merge)
+		if m.into.merged || m.from.merged || m.into.version != m.intoVersion || m.from.version != m.fromVersion {
+			continue
+		}
+
+		m.into.stops = m.stops
+		m.into.cost = m.into.cost + m.from.cost - m.saving
+		m.into.lateness = m.lateness
+		m.into.version++
+		m.from.merged = true
+
+		for _, route := range routes {
+			if route.merged || route == m.into {
+				continue
+			}
+			if next, ok := p.bestMerge(m.into, route, starts, maxWeight, maxVolume); ok {
+				heap.Push(&candidates, next)
+			}
+		}
+	}
+
+	var result [][]stop
+	for _, route := range routes {
+		if !route.merged {
+			result = append(result, route.stops)
+		}
+	}
+
+	return result
+}
+
+// bestMerge of the routes inserting one of them between stops of the other one, ok is false when no merge saves distance
+func (p *problem) bestMerge(a, b *savingsRoute, starts []model.Coordinate, maxWeight, maxVolume float64) (merge, bool) {
+	// The lower ID is always merged into, so merges are found the same way whatever order routes are given in
+	if b.id < a.id {
+		a, b = b, a
+	}
+
+	best := merge{into: a, from: b, intoVersion: a.version, fromVersion: b.version}
+	found := false
+	for _, pair := range [2][2]*savingsRoute{{a, b}, {b, a}} {
+		outer, inner := pair[0], pair[1]
+		for k := 0; k <= len(outer.stops); k++ {
+			stops := make([]stop, 0, len(outer.stops)+len(inner.stops))
+			stops = append(append(append(stops, outer.stops[:k]...), inner.stops...), outer.stops[k:]...)
+
+			cost, lateness, ok := p.evaluate(p.closest(starts, stops), stops, maxWeight, maxVolume)
+			if !ok || lateness > a.lateness+b.lateness {
+				continue
+			}
+
+			saving := a.cost + b.cost - cost
+			if saving > 0 && (!found || saving > best.saving) {
+				best.stops, best.saving, best.lateness = stops, saving, lateness
+				found = true
+			}
+		}
+	}
+
+	return best, found
+}
+
+// closest vehicle start to the first stop, the cost of a route evaluated from it includes the approach
+func (p *problem) closest(starts []model.Coordinate, stops []stop) model.Coordinate {
+	first := p.location(stops[0])
+
+	closest := starts[0]
+	for _, start := range starts[1:] {
+		if p.distance(start, first) < p.distance(closest, first) {
+			closest = start
+		}
+	}
+
+	return closest
+}
+
+// mergeQueue pops the merge saving the most first, ties are broken by route IDs to keep plans reproducible
+type mergeQueue []merge
+
+func (q mergeQueue) Len() int { return len(q) }
+func (q mergeQueue) Less(i, j int) bool {
+	if q[i].saving != q[j].saving {
+		return q[i].saving > q[j].saving
+	}
+	if q[i].into.id != q[j].into.id {
+		return q[i].into.id < q[j].into.id
+	}
+	return q[i].from.id < q[j].from.id
+}
+func (q mergeQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *mergeQueue) Push(x any) { *q = append(*q, x.(merge)) }
+
+func (q *mergeQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return item
+}
@@ -0,0 +1,219 @@
+package vrp
+
+import (
+	"sort"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+// capacityTolerance absorbs rounding of loads summed from many orders
+const capacityTolerance = 1e-9
+
+// Order carried by a vehicle from Pickup to DropOff
+type Order struct {
+	ID      uint
+	Pickup  model.Coordinate
+	DropOff model.Coordinate
+	Weight  float64
+	Volume  float64
+	// Priority of the order, routes with higher priority orders get vehicles first
+	Priority int
+	// Deadline in ticks from the start of the plan the order should be dropped off by. It is a soft time window:
+	// the solvers keep routes as little late as they can, but a late route is still planned and its order is in Late.
+	Deadline int
+}
+
+// Vehicle routes are planned for, it starts empty at Start
+type Vehicle struct {
+	ID        uint
+	Start     model.Coordinate
+	MaxWeight float64
+	MaxVolume float64
+}
+
+// Stop of a route, goods of the order are loaded at its pickup and unloaded at its drop-off
+type Stop struct {
+	OrderID uint
+	Pickup  bool
+}
+
+// Route of a single vehicle
+type Route struct {
+	VehicleID uint
+	Stops     []Stop
+	// Distance from the vehicle start through every stop
+	Distance int
+	// Lateness is the sum of ticks orders of the route are dropped off after their deadline
+	Lateness int
+	// Late orders of the route dropped off after their deadline, ordered by ID
+	Late []uint
+}
+
+// Solution of a routing problem
+type Solution struct {
+	// Routes ordered by vehicle ID
+	Routes []Route
+	// Unassigned orders that were left without a vehicle, ordered by ID
+	Unassigned []uint
+	// Late orders of every route, ordered by ID
+	Late     []uint
+	Distance int
+	Lateness int
+}
+
+// Options of the solvers
+type Options struct {
+	// Distance between two coordinates in ticks, the Chebyshev distance when nil
+	Distance func(from, to model.Coordinate) int
+	// ServiceTicks spent at every stop
+	ServiceTicks int
+}
+
+// problem shared by the solvers, stops refer to orders by index
+type problem struct {
+	orders   []Order
+	distance func(from, to model.Coordinate) int
+	service  int
+
+	// picked marks orders loaded during evaluate, an order is loaded when its entry equals stamp
+	picked []uint64
+	stamp  uint64
+}
+
+type stop struct {
+	order  int
+	pickup bool
+}
+
+func newProblem(orders []Order, opts Options) *problem {
+	distance := opts.Distance
+	if distance == nil {
+		distance = chebyshev
+	}
+
+	return &problem{
+		orders:   orders,
+		distance: distance,
+		service:  opts.ServiceTicks,
+		picked:   make([]uint64, len(orders)),
+	}
+}
+
+func (p *problem) location(s stop) model.Coordinate {
+	if s.pickup {
+		return p.orders[s.order].Pickup
+	}
+
+	return p.orders[s.order].DropOff
+}
+
+// evaluate the stops driven from start, ok is false when an order is dropped off before it is picked up
+// or the load exceeds maxWeight or maxVolume
+func (p *problem) evaluate(start model.Coordinate, stops []stop, maxWeight, maxVolume float64) (distance, lateness int, ok bool) {
+	p.stamp++
+
+	var weight, volume float64
+	position := start
+	elapsed := 0
+	for _, s := range stops {
+		order := p.orders[s.order]
+
+		leg := p.distance(position, p.location(s))
+		distance += leg
+		elapsed += leg + p.service
+		position = p.location(s)
+
+		if s.pickup {
+			p.picked[s.order] = p.stamp
+			weight += order.Weight
+			volume += order.Volume
+			if weight > maxWeight+capacityTolerance || volume > maxVolume+capacityTolerance {
+				return 0, 0, false
+			}
+			continue
+		}
+
+		if p.picked[s.order] != p.stamp {
+			return 0, 0, false
+		}
+		weight -= order.Weight
+		volume -= order.Volume
+		if elapsed > order.Deadline {
+			lateness += elapsed - order.Deadline
+		}
+	}
+
+	return distance, lateness, true
+}
+
+// late orders of the stops driven from start, the stops must be feasible, see evaluate
+func (p *problem) late(start model.Coordinate, stops []stop) []uint {
+	var late []uint
+	position := start
+	elapsed := 0
+	for _, s := range stops {
+		elapsed += p.distance(position, p.location(s)) + p.service
+		position = p.location(s)
+
+		if order := p.orders[s.order]; !s.pickup && elapsed > order.Deadline {
+			late = append(late, order.ID)
+		}
+	}
+	sort.Slice(late, func(i, j int) bool { return late[i] < late[j] })
+
+	return late
+}
+
+// better reports if a route with distance and lateness beats the current one, lateness is reduced first
+func better(distance, lateness, currentDistance, currentLateness int) bool {
+	return lateness < currentLateness || (lateness == currentLateness && distance < currentDistance)
+}
+
+// solution of routes assigned to vehicles, it converts stops back to order IDs
+func (p *problem) solution(routes []Route, stops [][]stop, unassigned []int) Solution {
+	var result Solution
+	for i, route := range routes {
+		for _, s := range stops[i] {
+			route.Stops = append(route.Stops, Stop{OrderID: p.orders[s.order].ID, Pickup: s.pickup})
+		}
+		result.Distance += route.Distance
+		result.Lateness += route.Lateness
+		result.Late = append(result.Late, route.Late...)
+		result.Routes = append(result.Routes, route)
+	}
+	for _, order := range unassigned {
+		result.Unassigned = append(result.Unassigned, p.orders[order].ID)
+	}
+
+	sort.Slice(result.Routes, func(i, j int) bool { return result.Routes[i].VehicleID < result.Routes[j].VehicleID })
+	sort.Slice(result.Unassigned, func(i, j int) bool { return result.Unassigned[i] < result.Unassigned[j] })
+	sort.Slice(result.Late, func(i, j int) bool { return result.Late[i] < result.Late[j] })
+
+	return result
+}
+
+// before reports if order a is dispatched before order b: higher priority first, then the earlier deadline,
+// then the lower ID
+func (p *problem) before(a, b int) bool {
+	x, y := p.orders[a], p.orders[b]
+	if x.Priority != y.Priority {
+		return x.Priority > y.Priority
+	}
+	if x.Deadline != y.Deadline {
+		return x.Deadline < y.Deadline
+	}
+
+	return x.ID < y.ID
+}
+
+// chebyshev distance is the number of steps between the coordinates when diagonal moves are allowed
+func chebyshev(from, to model.Coordinate) int {
+	return max(abs(from.X-to.X), abs(from.Y-to.Y))
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
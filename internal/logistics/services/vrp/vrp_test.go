@@ -0,0 +1,184 @@
+package vrp
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+func randomOrders(rnd *rand.Rand, count int) []Order {
+	orders := make([]Order, 0, count)
+	for i := 0; i < count; i++ {
+		orders = append(orders, Order{
+			ID:       uint(i + 1),
+			Pickup:   model.Coordinate{X: rnd.Intn(100), Y: rnd.Intn(100)},
+			DropOff:  model.Coordinate{X: rnd.Intn(100), Y: rnd.Intn(100)},
+			Weight:   float64(rnd.Intn(500) + 1),
+			Volume:   float64(rnd.Intn(5) + 1),
+			Priority: rnd.Intn(3),
+			Deadline: 1000,
+		})
+	}
+
+	return orders
+}
+
+func randomVehicles(rnd *rand.Rand, count int) []Vehicle {
+	vehicles := make([]Vehicle, 0, count)
+	for i := 0; i < count; i++ {
+		vehicles = append(vehicles, Vehicle{
+			ID:        uint(100 + i),
+			Start:     model.Coordinate{X: rnd.Intn(100), Y: rnd.Intn(100)},
+			MaxWeight: 1000,
+			MaxVolume: 10,
+		})
+	}
+
+	return vehicles
+}
+
+// assertValid checks that every order is either unassigned or picked up and then dropped off by one route,
+// that no vehicle is overloaded and that distances add up
+func assertValid(t *testing.T, solution Solution, vehicles []Vehicle, orders []Order) {
+	t.Helper()
+
+	byID := make(map[uint]Order)
+	for _, order := range orders {
+		byID[order.ID] = order
+	}
+	vehiclesByID := make(map[uint]Vehicle)
+	for _, vehicle := range vehicles {
+		vehiclesByID[vehicle.ID] = vehicle
+	}
+
+	served := make(map[uint]int)
+	for _, orderID := range solution.Unassigned {
+		served[orderID]++
+	}
+
+	total := 0
+	for _, route := range solution.Routes {
+		vehicle := vehiclesByID[route.VehicleID]
+		loaded := make(map[uint]bool)
+		var weight, volume float64
+		position := vehicle.Start
+		distance := 0
+		for _, s := range route.Stops {
+			order := byID[s.OrderID]
+			if s.Pickup {
+				loaded[s.OrderID] = true
+				weight += order.Weight
+				volume += order.Volume
+				distance += chebyshev(position, order.Pickup)
+				position = order.Pickup
+			} else {
+				if !loaded[s.OrderID] {
+					t.Fatalf("Order %d is dropped off before it is picked up by vehicle %d", s.OrderID, vehicle.ID)
+				}
+				served[s.OrderID]++
+				weight -= order.Weight
+				volume -= order.Volume
+				distance += chebyshev(position, order.DropOff)
+				position = order.DropOff
+			}
+
+			if weight > vehicle.MaxWeight || volume > vehicle.MaxVolume {
+				t.Fatalf("Expected vehicle %d to carry at most %.0f kg and %.0f m3, but it carries %.0f kg and %.0f m3",
+					vehicle.ID, vehicle.MaxWeight, vehicle.MaxVolume, weight, volume)
+			}
+		}
+
+		if distance != route.Distance {
+			t.Errorf("Expected route of vehicle %d to be %d long, but got %d", vehicle.ID, distance, route.Distance)
+		}
+		total += distance
+	}
+
+	for _, order := range orders {
+		if served[order.ID] != 1 {
+			t.Errorf("Expected order %d to be served or unassigned once, but got %d", order.ID, served[order.ID])
+		}
+	}
+	if total != solution.Distance {
+		t.Errorf("Expected total distance %d, but got %d", total, solution.Distance)
+	}
+}
+
+func TestSolveBeatsGreedy(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		rnd := rand.New(rand.NewSource(seed))
+		orders := randomOrders(rnd, 40)
+		vehicles := randomVehicles(rnd, 8)
+
+		solution := Solve(vehicles, orders, Options{})
+		assertValid(t, solution, vehicles, orders)
+
+		// Greedy serves the same orders, so the distances are comparable
+		unassigned := make(map[uint]bool)
+		for _, orderID := range solution.Unassigned {
+			unassigned[orderID] = true
+		}
+		var assigned []Order
+		for _, order := range orders {
+			if !unassigned[order.ID] {
+				assigned = append(assigned, order)
+			}
+		}
+
+		baseline := Greedy(vehicles, assigned, Options{})
+		assertValid(t, baseline, vehicles, assigned)
+
+		if len(assigned) == 0 || solution.Distance >= baseline.Distance {
+			t.Errorf("Expected solver to route %d orders shorter than greedy %d, but got %d", len(assigned), baseline.Distance, solution.Distance)
+		}
+	}
+}
+
+func TestSolveKeepsDeadlines(t *testing.T) {
+	vehicles := []Vehicle{
+		{ID: 1, MaxWeight: 100, MaxVolume: 100},
+		{ID: 2, MaxWeight: 100, MaxVolume: 100},
+	}
+	orders := []Order{
+		{ID: 1, Pickup: model.Coordinate{X: 0, Y: 0}, DropOff: model.Coordinate{X: 10, Y: 0}, Weight: 1, Volume: 1, Deadline: 10},
+		{ID: 2, Pickup: model.Coordinate{X: 0, Y: 5}, DropOff: model.Coordinate{X: 10, Y: 5}, Weight: 1, Volume: 1, Deadline: 100},
+	}
+
+	// Carrying both orders is shorter, but the first one would be late
+	solution := Solve(vehicles, orders, Options{})
+	assertValid(t, solution, vehicles, orders)
+	if len(solution.Routes) != 2 || solution.Lateness != 0 {
+		t.Errorf("Expected orders carried apart on time, but got %+v", solution)
+	}
+
+	orders[0].Deadline = 100
+	solution = Solve(vehicles, orders, Options{})
+	assertValid(t, solution, vehicles, orders)
+	if len(solution.Routes) != 1 || solution.Distance != 20 {
+		t.Errorf("Expected orders carried together 20 ticks long, but got %+v", solution)
+	}
+
+	// Deadlines are soft, an order that can't make its deadline is still routed and reported late
+	orders[0].Deadline = 5
+	solution = Solve(vehicles, orders, Options{})
+	assertValid(t, solution, vehicles, orders)
+	if len(solution.Unassigned) != 0 || len(solution.Late) != 1 || solution.Late[0] != 1 {
+		t.Errorf("Expected order 1 routed and reported late, but got %+v", solution)
+	}
+}
+
+func TestSolveRespectsCapacity(t *testing.T) {
+	vehicles := []Vehicle{{ID: 1, MaxWeight: 10, MaxVolume: 10}, {ID: 2, MaxWeight: 10, MaxVolume: 10}}
+	orders := []Order{
+		{ID: 1, Pickup: model.Coordinate{X: 0, Y: 0}, DropOff: model.Coordinate{X: 10, Y: 0}, Weight: 6, Volume: 1, Deadline: 100},
+		{ID: 2, Pickup: model.Coordinate{X: 0, Y: 1}, DropOff: model.Coordinate{X: 10, Y: 1}, Weight: 6, Volume: 1, Deadline: 100},
+		{ID: 3, Pickup: model.Coordinate{X: 0, Y: 0}, DropOff: model.Coordinate{X: 10, Y: 0}, Weight: 20, Volume: 1, Deadline: 100},
+	}
+
+	solution := Solve(vehicles, orders, Options{})
+	assertValid(t, solution, vehicles, orders)
+	if len(solution.Unassigned) != 1 || solution.Unassigned[0] != 3 {
+		t.Errorf("Expected only order 3 heavier than every vehicle to be unassigned, but got %v", solution.Unassigned)
+	}
+}
@@ -0,0 +1,183 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "logistics_client"
+
+// Metrics of a client run exposed in Prometheus text format, they are updated live during the run
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests            *prometheus.CounterVec
+	requestErrors       *prometheus.CounterVec
+	requestDuration     *prometheus.HistogramVec
+	unitsInFlight       prometheus.Gauge
+	unitsDelivered      prometheus.Counter
+	warehouseDeliveries *prometheus.CounterVec
+}
+
+// New metrics registered in their own registry, so several clients can run in one process
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Requests sent to API by method, every retry and every streamed batch is counted.",
+		}, []string{"method"}),
+		requestErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_errors_total",
+			Help:      "Failed requests by method and gRPC status code, moves rejected in a stream ack are counted one by one.",
+		}, []string{"method", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of unary requests by method.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 14),
+		}, []string{"method"}),
+		unitsInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "units_in_flight",
+			Help:      "Cargo units that are not delivered yet.",
+		}),
+		unitsDelivered: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "units_delivered_total",
+			Help:      "Cargo units API accepted the warehouse announcement of.",
+		}),
+		warehouseDeliveries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "warehouse_deliveries_total",
+			Help:      "Cargo units delivered by warehouse.",
+		}, []string{"warehouse_id"}),
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.requestErrors,
+		m.requestDuration,
+		m.unitsInFlight,
+		m.unitsDelivered,
+		m.warehouseDeliveries,
+	)
+
+	return m
+}
+
+// Handler serving the metrics in Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Serve /metrics on addr until the returned server is shut down
+func (m *Metrics) Serve(addr string) (*http.Server, error) {
+	listener, listenErr := net.Listen("tcp", addr)
+	if listenErr != nil {
+		return nil, listenErr
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	return server, nil
+}
+
+// Interceptors that measure every call they see, they are installed with grpc_client.WithInterceptors
+func (m *Metrics) Interceptors() (grpc.UnaryClientInterceptor, grpc.StreamClientInterceptor) {
+	return m.unaryInterceptor, m.streamInterceptor
+}
+
+// SetUnitsInFlight to the number of units that are not delivered yet
+func (m *Metrics) SetUnitsInFlight(units int) {
+	m.unitsInFlight.Set(float64(units))
+}
+
+// AddDelivery of a unit to the warehouse
+func (m *Metrics) AddDelivery(warehouseID uint) {
+	m.unitsDelivered.Inc()
+	m.warehouseDeliveries.WithLabelValues(strconv.FormatUint(uint64(warehouseID), 10)).Inc()
+}
+
+func (m *Metrics) unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	name := path.Base(method)
+	started := time.Now()
+
+	callErr := invoker(ctx, method, req, reply, cc, opts...)
+
+	m.requests.WithLabelValues(name).Inc()
+	m.requestDuration.WithLabelValues(name).Observe(time.Since(started).Seconds())
+	if callErr != nil {
+		m.requestErrors.WithLabelValues(name, status.Code(callErr).String()).Inc()
+	}
+
+	return callErr
+}
+
+func (m *Metrics) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	name := path.Base(method)
+
+	stream, streamErr := streamer(ctx, desc, cc, method, opts...)
+	if streamErr != nil {
+		m.requests.WithLabelValues(name).Inc()
+		m.requestErrors.WithLabelValues(name, status.Code(streamErr).String()).Inc()
+		return nil, streamErr
+	}
+
+	return &measuredStream{ClientStream: stream, metrics: m, method: name}, nil
+}
+
+// measuredStream counts every sent message as a request and every rejected move of received acks as an error
+type measuredStream struct {
+	grpc.ClientStream
+	metrics *Metrics
+	method  string
+}
+
+func (s *measuredStream) SendMsg(msg any) error {
+	s.metrics.requests.WithLabelValues(s.method).Inc()
+
+	return s.ClientStream.SendMsg(msg)
+}
+
+func (s *measuredStream) RecvMsg(msg any) error {
+	recvErr := s.ClientStream.RecvMsg(msg)
+	if recvErr != nil {
+		if !errors.Is(recvErr, io.EOF) {
+			s.metrics.requestErrors.WithLabelValues(s.method, status.Code(recvErr).String()).Inc()
+		}
+		return recvErr
+	}
+
+	if ack, ok := msg.(*logistics_v1.MoveUnitsAck); ok {
+		for _, result := range ack.Results {
+			if code := codes.Code(result.Code); code != codes.OK {
+				s.metrics.requestErrors.WithLabelValues(s.method, code.String()).Inc()
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ackStream sends nothing anywhere and receives the given acks, then io.EOF
+type ackStream struct {
+	grpc.ClientStream
+	acks []*logistics_v1.MoveUnitsAck
+}
+
+func (s *ackStream) SendMsg(any) error {
+	return nil
+}
+
+func (s *ackStream) RecvMsg(msg any) error {
+	if len(s.acks) == 0 {
+		return io.EOF
+	}
+	proto.Merge(msg.(*logistics_v1.MoveUnitsAck), s.acks[0])
+	s.acks = s.acks[1:]
+
+	return nil
+}
+
+// assertScraped metrics contain every expected line, it returns every metric in Prometheus text format
+func assertScraped(t *testing.T, m *Metrics, expected ...string) string {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := recorder.Body.String()
+	for _, line := range expected {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected metrics to contain %q, but got %s", line, body)
+		}
+	}
+
+	return body
+}
+
+func TestUnaryInterceptorCountsRequestsAndErrors(t *testing.T) {
+	m := New()
+	unary := m.unaryInterceptor
+
+	failing := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.Unavailable, "down")
+	}
+	succeeding := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+
+	const method = "/logistics.api.v1.LogisticsEngineAPI/MoveUnit"
+	if err := unary(context.Background(), method, nil, nil, nil, failing); status.Code(err) != codes.Unavailable {
+		t.Fatalf("Expected error of the call returned unchanged, but got %v", err)
+	}
+	if err := unary(context.Background(), method, nil, nil, nil, succeeding); err != nil {
+		t.Fatalf("Not expected error when calling MoveUnit, error: %v", err)
+	}
+
+	assertScraped(t, m,
+		`logistics_client_requests_total{method="MoveUnit"} 2`,
+		`logistics_client_request_errors_total{code="Unavailable",method="MoveUnit"} 1`,
+		`logistics_client_request_duration_seconds_count{method="MoveUnit"} 2`,
+	)
+}
+
+func TestStreamInterceptorCountsBatchesAndRejectedMoves(t *testing.T) {
+	m := New()
+	streamInterceptor := m.streamInterceptor
+
+	acks := []*logistics_v1.MoveUnitsAck{{Results: []*logistics_v1.MoveUnitResult{
+		{Code: int32(codes.OK)},
+		{Code: int32(codes.FailedPrecondition)},
+		{Code: int32(codes.FailedPrecondition)},
+	}}}
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &ackStream{acks: acks}, nil
+	}
+
+	stream, streamErr := streamInterceptor(context.Background(), &grpc.StreamDesc{}, nil, "/logistics.api.v1.LogisticsEngineAPI/StreamMoveUnits", streamer)
+	if streamErr != nil {
+		t.Fatalf("Not expected error when opening stream, error: %v", streamErr)
+	}
+	for i := 0; i < 3; i++ {
+		if err := stream.SendMsg(&logistics_v1.MoveUnitsBatch{}); err != nil {
+			t.Fatalf("Not expected error when sending batch, error: %v", err)
+		}
+	}
+	if err := stream.RecvMsg(&logistics_v1.MoveUnitsAck{}); err != nil {
+		t.Fatalf("Not expected error when receiving ack, error: %v", err)
+	}
+	if err := stream.RecvMsg(&logistics_v1.MoveUnitsAck{}); err != io.EOF {
+		t.Fatalf("Expected io.EOF at the end of the stream, but got %v", err)
+	}
+
+	body := assertScraped(t, m,
+		`logistics_client_requests_total{method="StreamMoveUnits"} 3`,
+		`logistics_client_request_errors_total{code="FailedPrecondition",method="StreamMoveUnits"} 2`,
+	)
+	if strings.Contains(body, `code="OK"`) || strings.Contains(body, `code="Unknown"`) {
+		t.Errorf("Expected accepted moves and io.EOF not counted as errors, but got %s", body)
+	}
+}
+
+func TestHandlerServesDeliveries(t *testing.T) {
+	m := New()
+	m.SetUnitsInFlight(3)
+	m.AddDelivery(7)
+	m.AddDelivery(7)
+
+	assertScraped(t, m,
+		"logistics_client_units_in_flight 3",
+		"logistics_client_units_delivered_total 2",
+		`logistics_client_warehouse_deliveries_total{warehouse_id="7"} 2`,
+	)
+}
+
+func TestServeRejectsInvalidAddress(t *testing.T) {
+	if server, serveErr := New().Serve("localhost:-1"); serveErr == nil {
+		_ = server.Close()
+		t.Errorf("Expected error when serving metrics on an invalid address")
+	}
+}
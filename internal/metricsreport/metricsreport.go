@@ -0,0 +1,44 @@
+// Package metricsreport computes MetricsReport of API from announcements of units reaching warehouses,
+// so the fake server and transports that never reach API answer it the same way
+package metricsreport
+
+import (
+	"sort"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+)
+
+// Compute MetricsReportResponse from announcements the same way API does
+func Compute(reached []*logistics_v1.UnitReachedWarehouseRequest) *logistics_v1.MetricsReportResponse {
+	units := make(map[int64]bool)
+	warehouses := make(map[int64]int64)
+	for _, req := range reached {
+		units[req.GetAnnouncement().GetCargoUnitId()] = true
+		warehouses[req.GetAnnouncement().GetWarehouseId()]++
+	}
+
+	report := &logistics_v1.MetricsReportResponse{DeliveryUnitsNumber: int64(len(units))}
+	for unitID := range units {
+		report.DeliveryUnitsReachedDestination = append(report.DeliveryUnitsReachedDestination, unitID)
+	}
+	for warehouseID, total := range warehouses {
+		report.WarehousesReceivedSuppliesList = append(report.WarehousesReceivedSuppliesList, warehouseID)
+		report.DeliveryUnitsEachWarehouseReceivedTotalNumber = append(
+			report.DeliveryUnitsEachWarehouseReceivedTotalNumber,
+			&logistics_v1.DeliveryUnitsWarehouseReceivedTotalNumber{WarehouseId: warehouseID, DeliveryUnitsNumber: total},
+		)
+	}
+
+	sort.Slice(report.DeliveryUnitsReachedDestination, func(i, j int) bool {
+		return report.DeliveryUnitsReachedDestination[i] < report.DeliveryUnitsReachedDestination[j]
+	})
+	sort.Slice(report.WarehousesReceivedSuppliesList, func(i, j int) bool {
+		return report.WarehousesReceivedSuppliesList[i] < report.WarehousesReceivedSuppliesList[j]
+	})
+	sort.Slice(report.DeliveryUnitsEachWarehouseReceivedTotalNumber, func(i, j int) bool {
+		return report.DeliveryUnitsEachWarehouseReceivedTotalNumber[i].WarehouseId <
+			report.DeliveryUnitsEachWarehouseReceivedTotalNumber[j].WarehouseId
+	})
+
+	return report
+}
@@ -0,0 +1,27 @@
+package metricsreport
+
+import (
+	"testing"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+)
+
+func reached(unitID, warehouseID int64) *logistics_v1.UnitReachedWarehouseRequest {
+	return &logistics_v1.UnitReachedWarehouseRequest{
+		Announcement: &logistics_v1.WarehouseAnnouncement{CargoUnitId: unitID, WarehouseId: warehouseID},
+	}
+}
+
+func TestComputeCountsAnnouncementsPerWarehouse(t *testing.T) {
+	report := Compute([]*logistics_v1.UnitReachedWarehouseRequest{reached(2, 1), reached(1, 0), reached(3, 1)})
+
+	if report.GetDeliveryUnitsNumber() != 3 {
+		t.Errorf("Expected 3 delivery units, but got %d", report.GetDeliveryUnitsNumber())
+	}
+
+	totals := report.GetDeliveryUnitsEachWarehouseReceivedTotalNumber()
+	if len(totals) != 2 || totals[0].GetWarehouseId() != 0 || totals[0].GetDeliveryUnitsNumber() != 1 ||
+		totals[1].GetWarehouseId() != 1 || totals[1].GetDeliveryUnitsNumber() != 2 {
+		t.Errorf("Expected warehouse 0 to receive 1 unit and warehouse 1 to receive 2, but got %v", totals)
+	}
+}
@@ -0,0 +1,52 @@
+package printer
+
+import (
+    "strings"
+)
+
+// MarkdownTablePrinter renders a GitHub flavored Markdown table
+type MarkdownTablePrinter struct {
+    headers []string
+    rows    [][]string
+}
+
+// NewMarkdownTablePrinter simple table printer
+func NewMarkdownTablePrinter() *MarkdownTablePrinter {
+    return &MarkdownTablePrinter{
+        headers: make([]string, 0),
+        rows:    make([][]string, 0),
+    }
+}
+
+// AddHeader to table
+func (t *MarkdownTablePrinter) AddHeader(headers []string) {
+    t.headers = headers
+}
+
+// AddRow to table
+func (t *MarkdownTablePrinter) AddRow(values []string) {
+    t.rows = append(t.rows, values)
+}
+
+func (t *MarkdownTablePrinter) String() string {
+    var builder strings.Builder
+
+    writeRow := func(values []string) {
+        for _, value := range values {
+            builder.WriteString("| " + strings.ReplaceAll(value, "|", "\\|") + " ")
+        }
+        builder.WriteString("|\n")
+    }
+
+    writeRow(t.headers)
+    for range t.headers {
+        builder.WriteString("| --- ")
+    }
+    builder.WriteString("|\n")
+
+    for _, row := range t.rows {
+        writeRow(row)
+    }
+
+    return builder.String()
+}
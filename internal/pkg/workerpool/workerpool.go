@@ -0,0 +1,28 @@
+package workerpool
+
+import "sync"
+
+// Run fn for every item with at most workers goroutines at once and wait until all items are handled
+func Run[T any](workers int, items []T, fn func(item T)) {
+	workers = min(max(workers, 1), len(items))
+
+	queue := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for item := range queue {
+				fn(item)
+			}
+		}()
+	}
+
+	for _, item := range items {
+		queue <- item
+	}
+	close(queue)
+
+	wg.Wait()
+}
@@ -0,0 +1,63 @@
+package workerpool
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunHandlesEveryItemOnce(t *testing.T) {
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i
+	}
+
+	var mu sync.Mutex
+	handled := make(map[int]int)
+	Run(8, items, func(item int) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled[item]++
+	})
+
+	if len(handled) != len(items) {
+		t.Fatalf("Expected %d items handled, but got %d", len(items), len(handled))
+	}
+	for item, count := range handled {
+		if count != 1 {
+			t.Errorf("Expected item %d handled once, but got %d", item, count)
+		}
+	}
+}
+
+func TestRunBoundsConcurrency(t *testing.T) {
+	for _, workers := range []int{-1, 0, 1, 4, 100} {
+		var active, peak atomic.Int32
+		Run(workers, make([]struct{}, 40), func(struct{}) {
+			current := active.Add(1)
+			for {
+				seen := peak.Load()
+				if current <= seen || peak.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			active.Add(-1)
+		})
+
+		// Workers below 1 run items one at a time, more workers than items run every item at once
+		expected := int32(min(max(workers, 1), 40))
+		if got := peak.Load(); got > expected || (expected == 1 && got != 1) {
+			t.Errorf("Expected at most %d items handled at once with %d workers, but got %d", expected, workers, got)
+		} else if expected > 1 && got < 2 {
+			t.Errorf("Expected items handled concurrently with %d workers, but got %d at once", workers, got)
+		}
+	}
+}
+
+func TestRunWithoutItems(t *testing.T) {
+	Run(4, nil, func(int) {
+		t.Errorf("Expected no item handled")
+	})
+}
@@ -0,0 +1,120 @@
+package recorder
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Entry of a recording is one message sent to API, a recording is a JSONL file with one entry per line
+type Entry struct {
+	// Time the message was sent at
+	Time time.Time `json:"time"`
+	// Method is the full gRPC method name the message was sent with
+	Method string `json:"method"`
+	// Request is the message in protobuf JSON encoding
+	Request json.RawMessage `json:"request"`
+}
+
+// Message decoded from the request of the entry, its type is given by the method
+func (e Entry) Message() (proto.Message, error) {
+	var msg proto.Message
+	switch e.Method {
+	case logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName:
+		msg = &logistics_v1.MoveUnitRequest{}
+	case logistics_v1.LogisticsEngineAPI_UnitReachedWarehouse_FullMethodName:
+		msg = &logistics_v1.UnitReachedWarehouseRequest{}
+	case logistics_v1.LogisticsEngineAPI_PickedUp_FullMethodName:
+		msg = &logistics_v1.PickedUpRequest{}
+	case logistics_v1.LogisticsEngineAPI_Delivered_FullMethodName:
+		msg = &logistics_v1.DeliveredRequest{}
+	case logistics_v1.LogisticsEngineAPI_CreateOrder_FullMethodName:
+		msg = &logistics_v1.CreateOrderRequest{}
+	case logistics_v1.LogisticsEngineAPI_StreamMoveUnits_FullMethodName:
+		msg = &logistics_v1.MoveUnitsBatch{}
+	default:
+		return nil, fmt.Errorf("recorded method %q can't be replayed", e.Method)
+	}
+
+	if unmarshalErr := protojson.Unmarshal(e.Request, msg); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to decode recorded %s request: %w", e.Method, unmarshalErr)
+	}
+
+	return msg, nil
+}
+
+// Recorder writes every MoveUnit, UnitReachedWarehouse, PickedUp, Delivered, CreateOrder and StreamMoveUnits message
+// it is given, see transport.Recording. It is safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	writer *bufio.Writer
+	closer io.Closer
+	// err is the first failed write, calls are not failed because of the recording, Close returns it
+	err error
+}
+
+// New recorder writing entries to w
+func New(w io.Writer) *Recorder {
+	return &Recorder{writer: bufio.NewWriter(w)}
+}
+
+// Create the recording file at path, it is truncated if it exists
+func Create(path string) (*Recorder, error) {
+	file, createErr := os.Create(path)
+	if createErr != nil {
+		return nil, createErr
+	}
+
+	r := New(file)
+	r.closer = file
+
+	return r, nil
+}
+
+// Record the message sent with the method now
+func (r *Recorder) Record(method string, msg proto.Message) {
+	request, marshalErr := protojson.Marshal(msg)
+	line, entryErr := json.Marshal(Entry{Time: time.Now().UTC(), Method: method, Request: request})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return
+	}
+	if r.err = marshalErr; r.err != nil {
+		return
+	}
+	if r.err = entryErr; r.err != nil {
+		return
+	}
+
+	line = append(line, '\n')
+	_, r.err = r.writer.Write(line)
+}
+
+// Close flushes the recording and closes its file, it returns the first error of recording if any
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if flushErr := r.writer.Flush(); r.err == nil {
+		r.err = flushErr
+	}
+	if r.closer != nil {
+		if closeErr := r.closer.Close(); r.err == nil {
+			r.err = closeErr
+		}
+		r.closer = nil
+	}
+
+	return r.err
+}
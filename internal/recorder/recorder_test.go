@@ -0,0 +1,67 @@
+package recorder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/fakeserver"
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/grpc_client"
+)
+
+func connect(t *testing.T, srv *fakeserver.Server, opts ...grpc_client.Option) *grpc_client.APILogisticsClient {
+	t.Helper()
+
+	dialOption, stop := srv.Listen()
+	t.Cleanup(stop)
+
+	noRetries := grpc_client.DefaultRetryPolicy()
+	noRetries.MaxAttempts = 1
+	opts = append([]grpc_client.Option{grpc_client.WithDialOptions(dialOption), grpc_client.WithRetryPolicy(noRetries)}, opts...)
+	lc := grpc_client.NewLogisticsClient(opts...)
+	if connErr := lc.Connect("bufnet", context.Background()); connErr != nil {
+		t.Fatalf("Not expected error when connecting, error: %v", connErr)
+	}
+	t.Cleanup(func() { _ = lc.Disconnect() })
+
+	return lc
+}
+
+func TestReplayKeepsOriginalPacing(t *testing.T) {
+	started := time.Now().UTC()
+	var entries []Entry
+	for _, offset := range []time.Duration{0, 30 * time.Millisecond, 60 * time.Millisecond} {
+		entries = append(entries, Entry{
+			Time:    started.Add(offset),
+			Method:  logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName,
+			Request: []byte(`{"cargoUnitId":"1"}`),
+		})
+	}
+
+	lc := connect(t, fakeserver.New())
+
+	replayStarted := time.Now()
+	if _, replayErr := Replay(context.Background(), lc, entries, PacingOriginal); replayErr != nil {
+		t.Fatalf("Not expected error when replaying, error: %v", replayErr)
+	}
+	if elapsed := time.Since(replayStarted); elapsed < 60*time.Millisecond {
+		t.Errorf("Expected replay to take at least 60ms like the recording, but it took %s", elapsed)
+	}
+
+	replayStarted = time.Now()
+	if _, replayErr := Replay(context.Background(), lc, entries, PacingMax); replayErr != nil {
+		t.Fatalf("Not expected error when replaying, error: %v", replayErr)
+	}
+	if elapsed := time.Since(replayStarted); elapsed >= 60*time.Millisecond {
+		t.Errorf("Expected replay at max speed to ignore recorded delays, but it took %s", elapsed)
+	}
+}
+
+func TestReplayRejectsUnknownMethod(t *testing.T) {
+	entries := []Entry{{Method: logistics_v1.LogisticsEngineAPI_MetricsReport_FullMethodName, Request: []byte(`{}`)}}
+
+	if _, replayErr := Replay(context.Background(), nil, entries, PacingMax); replayErr == nil {
+		t.Errorf("Expected error when replaying a method that is not recorded")
+	}
+}
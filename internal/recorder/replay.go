@@ -0,0 +1,181 @@
+package recorder
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"time"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/grpc_client"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxEntrySize is the longest line of a recording, a StreamMoveUnits batch of many moves is a single line
+const maxEntrySize = 64 * 1024 * 1024
+
+// Pacing of a replay
+type Pacing string
+
+const (
+	// PacingOriginal sends every message after the same delay from the start as it was recorded with
+	PacingOriginal Pacing = "original"
+	// PacingMax sends every message as soon as the previous one is answered
+	PacingMax Pacing = "max"
+)
+
+// ParsePacing from its name
+func ParsePacing(name string) (Pacing, error) {
+	switch pacing := Pacing(name); pacing {
+	case PacingOriginal, PacingMax:
+		return pacing, nil
+	}
+
+	return "", fmt.Errorf("unknown replay pacing %q, expected %s or %s", name, PacingOriginal, PacingMax)
+}
+
+// Result of a replay
+type Result struct {
+	// Sent messages, a StreamMoveUnits batch is a single message
+	Sent int
+	// Failed messages API returned an error for or rejected at least one move of
+	Failed int
+}
+
+// Read every entry of a recording
+func Read(r io.Reader) ([]Entry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+
+	var entries []Entry
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if unmarshalErr := json.Unmarshal(scanner.Bytes(), &entry); unmarshalErr != nil {
+			return nil, fmt.Errorf("failed to decode recording line %d: %w", line, unmarshalErr)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// Load every entry of the recording file
+func Load(path string) ([]Entry, error) {
+	file, openErr := os.Open(path)
+	if openErr != nil {
+		return nil, openErr
+	}
+	defer file.Close()
+
+	return Read(file)
+}
+
+// Replay sends every recorded message again with lc in recorded order. Messages API fails are counted and
+// the replay goes on, so failures of the original run are replayed as well. lc should not retry calls,
+// attempts of the original run are recorded one by one already.
+func Replay(ctx context.Context, lc *grpc_client.APILogisticsClient, entries []Entry, pacing Pacing) (Result, error) {
+	messages := make([]proto.Message, 0, len(entries))
+	for _, entry := range entries {
+		msg, msgErr := entry.Message()
+		if msgErr != nil {
+			return Result{}, msgErr
+		}
+
+		messages = append(messages, msg)
+	}
+
+	var result Result
+	var moveStream *grpc_client.MoveUnitsStream
+	defer func() {
+		if moveStream != nil {
+			_ = moveStream.Close()
+		}
+	}()
+
+	started := time.Now()
+	for i, msg := range messages {
+		if pacing == PacingOriginal {
+			if waitErr := wait(ctx, entries[i].Time.Sub(entries[0].Time)-time.Since(started)); waitErr != nil {
+				return result, waitErr
+			}
+		}
+
+		var sendErr error
+		switch req := msg.(type) {
+		case *logistics_v1.MoveUnitRequest:
+			sendErr = lc.MoveUnit(ctx, req)
+		case *logistics_v1.UnitReachedWarehouseRequest:
+			sendErr = lc.UnitReachedWarehouse(ctx, req)
+		case *logistics_v1.PickedUpRequest:
+			sendErr = lc.PickedUp(ctx, req)
+		case *logistics_v1.DeliveredRequest:
+			sendErr = lc.Delivered(ctx, req)
+		case *logistics_v1.CreateOrderRequest:
+			sendErr = lc.CreateOrder(ctx, req)
+		case *logistics_v1.MoveUnitsBatch:
+			if moveStream == nil {
+				// Recorded batches are sent as they are, the stream must not split them
+				moveStream, sendErr = lc.OpenMoveUnitsStream(ctx, math.MaxInt)
+			}
+			if sendErr == nil {
+				sendErr = sendBatch(moveStream, req)
+			}
+			if sendErr != nil && moveStream != nil {
+				_ = moveStream.Close()
+				moveStream = nil
+			}
+		}
+
+		result.Sent++
+		if sendErr != nil {
+			log.Printf("replayed %s request %d failed, API error: %v\n", entries[i].Method, i+1, sendErr)
+			result.Failed++
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return result, ctxErr
+		}
+	}
+
+	return result, nil
+}
+
+// sendBatch over the stream, a move API rejected fails the batch
+func sendBatch(stream *grpc_client.MoveUnitsStream, batch *logistics_v1.MoveUnitsBatch) error {
+	unitErrs, streamErr := stream.Send(batch.GetMoves())
+	if streamErr != nil {
+		return streamErr
+	}
+
+	for unitID, unitErr := range unitErrs {
+		return fmt.Errorf("move of unit %d rejected: %w", unitID, unitErr)
+	}
+
+	return nil
+}
+
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,181 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/pkg/printer"
+)
+
+// Format of a rendered report
+type Format string
+
+const (
+	FormatASCII    Format = "ascii"
+	FormatJSON     Format = "json"
+	FormatCSV      Format = "csv"
+	FormatMarkdown Format = "markdown"
+)
+
+// Renderer writes the report in its format
+type Renderer interface {
+	Render(w io.Writer, r *Report) error
+}
+
+// NewRenderer of the format
+func NewRenderer(format Format) (Renderer, error) {
+	switch format {
+	case FormatASCII:
+		return asciiRenderer{}, nil
+	case FormatJSON:
+		return jsonRenderer{}, nil
+	case FormatCSV:
+		return csvRenderer{}, nil
+	case FormatMarkdown:
+		return markdownRenderer{}, nil
+	}
+
+	return nil, errUnknownFormat(format)
+}
+
+// WriteTables in the format, it renders tables that are not a run report, e.g. a report fetched from API.
+// title heads Markdown output.
+func WriteTables(w io.Writer, format Format, title string, tables []Table) error {
+	switch format {
+	case FormatASCII:
+		return writeASCII(w, tables)
+	case FormatJSON:
+		return writeJSON(w, tables)
+	case FormatCSV:
+		return writeCSV(w, tables)
+	case FormatMarkdown:
+		return writeMarkdown(w, title, tables)
+	}
+
+	return errUnknownFormat(format)
+}
+
+func errUnknownFormat(format Format) error {
+	return fmt.Errorf("unknown report format %q, expected %s, %s, %s or %s", format, FormatASCII, FormatJSON, FormatCSV, FormatMarkdown)
+}
+
+// WriteFile renders the report in the format to the file, an empty path writes it to stdout
+func WriteFile(path string, format Format, r *Report) error {
+	renderer, rendererErr := NewRenderer(format)
+	if rendererErr != nil {
+		return rendererErr
+	}
+
+	if len(path) == 0 {
+		return renderer.Render(os.Stdout, r)
+	}
+
+	file, createErr := os.Create(path)
+	if createErr != nil {
+		return createErr
+	}
+
+	if renderErr := renderer.Render(file, r); renderErr != nil {
+		_ = file.Close()
+		return renderErr
+	}
+
+	return file.Close()
+}
+
+// asciiRenderer prints every table of the report with printer.ASCIITablePrinter
+type asciiRenderer struct{}
+
+func (asciiRenderer) Render(w io.Writer, r *Report) error {
+	return writeASCII(w, r.Tables())
+}
+
+func writeASCII(w io.Writer, tables []Table) error {
+	for _, table := range tables {
+		tablePrinter := printer.NewASCIITablePrinter()
+		tablePrinter.AddHeader(table.Headers)
+		for _, row := range table.Rows {
+			tablePrinter.AddRow(row)
+		}
+
+		if _, writeErr := fmt.Fprintf(w, "%s\n%s\n", table.Title, tablePrinter); writeErr != nil {
+			return writeErr
+		}
+	}
+
+	return nil
+}
+
+// jsonRenderer writes the whole report as a single JSON document
+type jsonRenderer struct{}
+
+func (jsonRenderer) Render(w io.Writer, r *Report) error {
+	return writeJSON(w, r)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+// csvRenderer flattens every table of the report into section,key,field,value records,
+// so one file holds all tables and diffs of two runs stay line by line
+type csvRenderer struct{}
+
+func (csvRenderer) Render(w io.Writer, r *Report) error {
+	return writeCSV(w, r.Tables())
+}
+
+func writeCSV(w io.Writer, tables []Table) error {
+	writer := csv.NewWriter(w)
+	if writeErr := writer.Write([]string{"section", "key", "field", "value"}); writeErr != nil {
+		return writeErr
+	}
+
+	for _, table := range tables {
+		for _, row := range table.Rows {
+			for i := 1; i < len(row) && i < len(table.Headers); i++ {
+				if writeErr := writer.Write([]string{table.Title, row[0], table.Headers[i], row[i]}); writeErr != nil {
+					return writeErr
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// markdownRenderer writes every table of the report under its own heading
+type markdownRenderer struct{}
+
+func (markdownRenderer) Render(w io.Writer, r *Report) error {
+	return writeMarkdown(w, "Run report", r.Tables())
+}
+
+func writeMarkdown(w io.Writer, title string, tables []Table) error {
+	var builder strings.Builder
+	builder.WriteString("# " + title + "\n")
+
+	for _, table := range tables {
+		tablePrinter := printer.NewMarkdownTablePrinter()
+		tablePrinter.AddHeader(table.Headers)
+		for _, row := range table.Rows {
+			tablePrinter.AddRow(row)
+		}
+
+		builder.WriteString("\n## " + table.Title + "\n\n")
+		builder.WriteString(tablePrinter.String())
+	}
+
+	_, writeErr := io.WriteString(w, builder.String())
+
+	return writeErr
+}
@@ -0,0 +1,127 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/config"
+)
+
+func newTestReport() *Report {
+	return &Report{
+		Seed:          42,
+		Config:        []config.Setting{{Name: "Host", Value: "localhost"}},
+		World:         World{Warehouses: 2, CargoUnits: 3, GridWidth: 255, GridHeight: 255},
+		ExecutionTime: 1500 * time.Millisecond,
+		SimulatedTime: 10 * time.Second,
+		Ticks:         10,
+		BrokenUnits:   1,
+		Operations: []Operation{
+			{Name: "MoveUnit", Count: 30, Errors: 1, Latency: Latency{Count: 30, Min: time.Millisecond, Max: 3 * time.Millisecond}},
+			{Name: "StreamMoveUnits"},
+		},
+		WarehouseQueues: []WarehouseQueue{{WarehouseID: 0, Served: 2, Queued: 1, TotalWait: 3, MaxWait: 3}, {WarehouseID: 1, Served: 1}},
+		Warehouses: []WarehouseTotals{
+			{WarehouseID: 0, Received: 2, Delivered: 2, Status: StatusOK},
+			{WarehouseID: 1, Received: 2, Delivered: 1, Status: StatusMismatch},
+		},
+		Mismatches: 1,
+		Shipments:  []Shipment{{ID: 1, UnitID: 2, Pickup: 0, DropOffs: []uint{1, 0}, Status: "in transit"}},
+		Orders: []OrderTotals{
+			{Priority: "high", Created: 2, Delivered: 2, Late: 1, LeadTime: 30 * time.Second},
+			{Priority: "low", Created: 1, Stranded: 1},
+		},
+		Routing: &Routing{Plans: 2, Orders: 3, Distance: 60, GreedyDistance: 80, Late: 1},
+	}
+}
+
+func render(t *testing.T, format Format) string {
+	t.Helper()
+
+	renderer, rendererErr := NewRenderer(format)
+	if rendererErr != nil {
+		t.Fatalf("Not expected error when creating %s renderer, error: %v", format, rendererErr)
+	}
+
+	var buffer bytes.Buffer
+	if renderErr := renderer.Render(&buffer, newTestReport()); renderErr != nil {
+		t.Fatalf("Not expected error when rendering %s, error: %v", format, renderErr)
+	}
+
+	return buffer.String()
+}
+
+func TestRenderCSV(t *testing.T) {
+	records, readErr := csv.NewReader(strings.NewReader(render(t, FormatCSV))).ReadAll()
+	if readErr != nil {
+		t.Fatalf("Not expected error when reading CSV, error: %v", readErr)
+	}
+
+	expected := map[[3]string]string{
+		{"Summary", "Seed", "Value"}:                  "42",
+		{"Summary", "Broken units", "Value"}:          "1",
+		{"Configuration", "Host", "Value"}:            "localhost",
+		{"Operations", "MoveUnit", "Errors"}:          "1",
+		{"Operations", "MoveUnit", "Max"}:             "3ms",
+		{"Operations", "StreamMoveUnits", "P99"}:      "-",
+		{"Warehouse queues", "0", "Avg wait (ticks)"}: "1.50",
+		{"Warehouse queues", "Total", "Served"}:       "3",
+		{"Warehouses", "1", "Status"}:                 StatusMismatch,
+		{"Warehouses", "Total", "Status"}:             "1 mismatched",
+		{"Shipments", "1", "Drop-offs"}:               "1 0",
+		{"Shipments", "1", "Status"}:                  "in transit",
+		{"Orders", "high", "Late"}:                    "1",
+		{"Orders", "Total", "Created"}:                "3",
+		{"Orders", "Total", "Stranded"}:               "1",
+		{"Orders", "Total", "Avg lead time"}:          "30s",
+		{"Routing", "2", "Greedy distance"}:           "80",
+		{"Routing", "2", "Saved"}:                     "25.0%",
+		{"Routing", "2", "Planned late"}:              "1",
+	}
+
+	found := make(map[[3]string]string)
+	for _, record := range records[1:] {
+		found[[3]string{record[0], record[1], record[2]}] = record[3]
+	}
+	for key, value := range expected {
+		if found[key] != value {
+			t.Errorf("Expected %v to be %q, but got %q", key, value, found[key])
+		}
+	}
+}
+
+func TestRenderTables(t *testing.T) {
+	markdown := render(t, FormatMarkdown)
+	for _, expected := range []string{"# Run report", "## Operations", "| Operation | Count | Errors |", "| 1 | 2 | 1 | MISMATCH |"} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expected markdown to contain %q, got:\n%s", expected, markdown)
+		}
+	}
+
+	ascii := render(t, FormatASCII)
+	for _, expected := range []string{"Summary\n", "| Seed ", "Warehouse queues\n", "| Total     | 4 "} {
+		if !strings.Contains(ascii, expected) {
+			t.Errorf("Expected ascii to contain %q, got:\n%s", expected, ascii)
+		}
+	}
+}
+
+func TestTablesWithoutShipments(t *testing.T) {
+	r := newTestReport()
+	r.Shipments = nil
+
+	for _, table := range r.Tables() {
+		if table.Title == "Shipments" {
+			t.Errorf("Expected no shipments table in a report without shipments")
+		}
+	}
+}
+
+func TestNewRendererUnknownFormat(t *testing.T) {
+	if _, err := NewRenderer("xml"); err == nil {
+		t.Errorf("Expected error for unknown format")
+	}
+}
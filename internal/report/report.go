@@ -0,0 +1,405 @@
+package report
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/config"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/logistics/model"
+)
+
+// Report of a single simulation run
+type Report struct {
+	Seed          int64            `json:"seed"`
+	Config        []config.Setting `json:"config"`
+	World         World            `json:"world"`
+	ExecutionTime time.Duration    `json:"execution_time_ns"`
+	SimulatedTime time.Duration    `json:"simulated_time_ns"`
+	Ticks         uint64           `json:"ticks"`
+	// BrokenUnits ran out of fuel and never reached their warehouse
+	BrokenUnits int `json:"broken_units"`
+
+	Operations      []Operation       `json:"operations"`
+	WarehouseQueues []WarehouseQueue  `json:"warehouse_queues"`
+	Warehouses      []WarehouseTotals `json:"warehouses"`
+	// Mismatches is the number of warehouses API received a different number of units than the client delivered
+	Mismatches int `json:"mismatches"`
+	// Shipments carried by units, empty when units were not given shipments
+	Shipments []Shipment `json:"shipments,omitempty"`
+	// Orders totals by priority, empty when the run had no orders
+	Orders []OrderTotals `json:"orders,omitempty"`
+	// Routing of orders planned by the vrp solver, nil with greedy dispatch
+	Routing *Routing `json:"routing,omitempty"`
+}
+
+// World size the run was simulated in
+type World struct {
+	Warehouses int `json:"warehouses"`
+	CargoUnits int `json:"cargo_units"`
+	GridWidth  int `json:"grid_width"`
+	GridHeight int `json:"grid_height"`
+}
+
+// Operation statistics of a single RPC
+type Operation struct {
+	Name    string  `json:"name"`
+	Count   uint64  `json:"count"`
+	Errors  uint64  `json:"errors"`
+	Latency Latency `json:"latency"`
+}
+
+// Latency of calls in nanoseconds, retries made by the client included
+type Latency struct {
+	Count uint64        `json:"count"`
+	Min   time.Duration `json:"min_ns"`
+	Avg   time.Duration `json:"avg_ns"`
+	P50   time.Duration `json:"p50_ns"`
+	P90   time.Duration `json:"p90_ns"`
+	P99   time.Duration `json:"p99_ns"`
+	Max   time.Duration `json:"max_ns"`
+}
+
+// WarehouseQueue statistics of a single warehouse, wait times are in simulation ticks
+type WarehouseQueue struct {
+	WarehouseID uint   `json:"warehouse_id"`
+	Served      uint64 `json:"served"`
+	Queued      uint64 `json:"queued"`
+	MaxLength   uint64 `json:"max_length"`
+	TotalWait   uint64 `json:"total_wait"`
+	MaxWait     uint64 `json:"max_wait"`
+	Rerouted    uint64 `json:"rerouted"`
+}
+
+// WarehouseTotals of units API received and the client delivered during the run
+type WarehouseTotals struct {
+	WarehouseID uint   `json:"warehouse_id"`
+	Received    int64  `json:"received"`
+	Delivered   uint64 `json:"delivered"`
+	Status      string `json:"status"`
+}
+
+// Shipment of a unit with its stops and the status it ended the run in
+type Shipment struct {
+	ID       uint   `json:"id"`
+	UnitID   uint   `json:"unit_id"`
+	Pickup   uint   `json:"pickup"`
+	DropOffs []uint `json:"drop_offs"`
+	Status   string `json:"status"`
+}
+
+// OrderTotals of orders of a single priority
+type OrderTotals struct {
+	Priority  string `json:"priority"`
+	Created   uint64 `json:"created"`
+	Delivered uint64 `json:"delivered"`
+	// Late orders were delivered after their deadline
+	Late uint64 `json:"late"`
+	// Stranded orders were left on units that broke down, see model.OrderStranded
+	Stranded uint64 `json:"stranded"`
+	// LeadTime is the mean simulated time from arrival to delivery of delivered orders
+	LeadTime time.Duration `json:"lead_time_ns"`
+}
+
+// Routing totals of every route plan of a run, distances are steps units have to walk
+type Routing struct {
+	Plans    int `json:"plans"`
+	Orders   int `json:"orders"`
+	Distance int `json:"distance"`
+	// GreedyDistance of the same orders carried one at a time by the closest free units
+	GreedyDistance int `json:"greedy_distance"`
+	// Late orders planned to be delivered after their deadline, deadlines don't keep an order from being routed
+	Late int `json:"late"`
+}
+
+// Warehouse totals statuses
+const (
+	StatusOK       = "OK"
+	StatusMismatch = "MISMATCH"
+)
+
+// NewOperations from statistics of operations
+func NewOperations(operations []*model.Operation) []Operation {
+	result := make([]Operation, 0, len(operations))
+	for _, o := range operations {
+		result = append(result, Operation{Name: o.Name, Count: o.A, Errors: o.B, Latency: Latency(o.Latency())})
+	}
+
+	return result
+}
+
+// NewWarehouseQueues from statistics of warehouse queues
+func NewWarehouseQueues(queues []model.WarehouseQueue) []WarehouseQueue {
+	result := make([]WarehouseQueue, 0, len(queues))
+	for _, q := range queues {
+		result = append(result, WarehouseQueue(q))
+	}
+
+	return result
+}
+
+// NewShipments from shipments of units
+func NewShipments(shipments []model.Shipment) []Shipment {
+	result := make([]Shipment, 0, len(shipments))
+	for _, s := range shipments {
+		result = append(result, Shipment{
+			ID:       s.ID,
+			UnitID:   s.UnitID,
+			Pickup:   s.Pickup(),
+			DropOffs: s.DropOffs(),
+			Status:   s.Status.String(),
+		})
+	}
+
+	return result
+}
+
+// NewOrderTotals of orders by priority, the highest priority first
+func NewOrderTotals(orders []model.Order) []OrderTotals {
+	var result []OrderTotals
+	for _, priority := range []model.OrderPriority{model.OrderPriorityHigh, model.OrderPriorityNormal, model.OrderPriorityLow} {
+		totals := OrderTotals{Priority: priority.String()}
+		var leadTime time.Duration
+		for _, o := range orders {
+			if o.Priority != priority {
+				continue
+			}
+
+			totals.Created++
+			if o.Status == model.OrderStranded {
+				totals.Stranded++
+			}
+			if o.Status != model.OrderDelivered {
+				continue
+			}
+			totals.Delivered++
+			leadTime += o.DeliveredAt.Sub(o.CreatedAt)
+			if o.Late() {
+				totals.Late++
+			}
+		}
+		if totals.Created == 0 {
+			continue
+		}
+		if totals.Delivered > 0 {
+			totals.LeadTime = leadTime / time.Duration(totals.Delivered)
+		}
+
+		result = append(result, totals)
+	}
+
+	return result
+}
+
+// Table of the report with a title, used by renderers that print tables
+type Table struct {
+	Title   string     `json:"title"`
+	Headers []string   `json:"headers"`
+	Rows    [][]string `json:"rows"`
+}
+
+// Tables of the report: summary, operations, warehouse queues, warehouse totals, and shipments and orders
+// when there are any
+func (r *Report) Tables() []Table {
+	summary := Table{
+		Title:   "Summary",
+		Headers: []string{"Name", "Value"},
+		Rows: [][]string{
+			{"Seed", strconv.FormatInt(r.Seed, 10)},
+			{"Warehouses", strconv.Itoa(r.World.Warehouses)},
+			{"Cargo units", strconv.Itoa(r.World.CargoUnits)},
+			{"Grid", strconv.Itoa(r.World.GridWidth) + "x" + strconv.Itoa(r.World.GridHeight)},
+			{"Execution time", r.ExecutionTime.String()},
+			{"Simulated time", r.SimulatedTime.String()},
+			{"Ticks", strconv.FormatUint(r.Ticks, 10)},
+			{"Broken units", strconv.Itoa(r.BrokenUnits)},
+		},
+	}
+
+	configuration := Table{Title: "Configuration", Headers: []string{"Name", "Value"}}
+	for _, setting := range r.Config {
+		configuration.Rows = append(configuration.Rows, []string{setting.Name, setting.Value})
+	}
+
+	operations := Table{
+		Title:   "Operations",
+		Headers: []string{"Operation", "Count", "Errors", "Min", "Avg", "P50", "P90", "P99", "Max"},
+	}
+	for _, o := range r.Operations {
+		row := []string{o.Name, strconv.FormatUint(o.Count, 10), strconv.FormatUint(o.Errors, 10)}
+		for _, value := range []time.Duration{o.Latency.Min, o.Latency.Avg, o.Latency.P50, o.Latency.P90, o.Latency.P99, o.Latency.Max} {
+			if o.Latency.Count == 0 {
+				row = append(row, "-")
+				continue
+			}
+
+			row = append(row, value.Round(time.Microsecond).String())
+		}
+		operations.Rows = append(operations.Rows, row)
+	}
+
+	tables := []Table{summary, configuration, operations, r.queuesTable(), r.warehousesTable()}
+	if len(r.Shipments) > 0 {
+		tables = append(tables, r.shipmentsTable())
+	}
+	if len(r.Orders) > 0 {
+		tables = append(tables, r.ordersTable())
+	}
+	if r.Routing != nil {
+		tables = append(tables, r.routingTable())
+	}
+
+	return tables
+}
+
+// queuesTable with queue statistics of every warehouse and their totals
+func (r *Report) queuesTable() Table {
+	table := Table{
+		Title:   "Warehouse queues",
+		Headers: []string{"Warehouse", "Served", "Queued", "Max queue length", "Avg wait (ticks)", "Max wait (ticks)", "Rerouted"},
+	}
+
+	var total WarehouseQueue
+	for _, queue := range r.WarehouseQueues {
+		total.Served += queue.Served
+		total.Queued += queue.Queued
+		total.MaxLength = max(total.MaxLength, queue.MaxLength)
+		total.TotalWait += queue.TotalWait
+		total.MaxWait = max(total.MaxWait, queue.MaxWait)
+		total.Rerouted += queue.Rerouted
+
+		table.Rows = append(table.Rows, queueRow(strconv.FormatUint(uint64(queue.WarehouseID), 10), queue))
+	}
+	table.Rows = append(table.Rows, queueRow("Total", total))
+
+	return table
+}
+
+func queueRow(name string, queue WarehouseQueue) []string {
+	avgWait := 0.0
+	if queue.Served > 0 {
+		avgWait = float64(queue.TotalWait) / float64(queue.Served)
+	}
+
+	return []string{
+		name,
+		strconv.FormatUint(queue.Served, 10),
+		strconv.FormatUint(queue.Queued, 10),
+		strconv.FormatUint(queue.MaxLength, 10),
+		strconv.FormatFloat(avgWait, 'f', 2, 64),
+		strconv.FormatUint(queue.MaxWait, 10),
+		strconv.FormatUint(queue.Rerouted, 10),
+	}
+}
+
+// warehousesTable comparing API received totals with client deliveries
+func (r *Report) warehousesTable() Table {
+	table := Table{
+		Title:   "Warehouses",
+		Headers: []string{"Warehouse", "Received (API)", "Delivered (client)", "Status"},
+	}
+
+	var totalReceived int64
+	var totalDelivered uint64
+	for _, warehouse := range r.Warehouses {
+		totalReceived += warehouse.Received
+		totalDelivered += warehouse.Delivered
+		table.Rows = append(table.Rows, []string{
+			strconv.FormatUint(uint64(warehouse.WarehouseID), 10),
+			strconv.FormatInt(warehouse.Received, 10),
+			strconv.FormatUint(warehouse.Delivered, 10),
+			warehouse.Status,
+		})
+	}
+	table.Rows = append(table.Rows, []string{
+		"Total",
+		strconv.FormatInt(totalReceived, 10),
+		strconv.FormatUint(totalDelivered, 10),
+		strconv.Itoa(r.Mismatches) + " mismatched",
+	})
+
+	return table
+}
+
+// shipmentsTable with stops and status of every shipment
+func (r *Report) shipmentsTable() Table {
+	table := Table{
+		Title:   "Shipments",
+		Headers: []string{"Shipment", "Unit", "Pickup", "Drop-offs", "Status"},
+	}
+
+	for _, shipment := range r.Shipments {
+		dropOffs := make([]string, 0, len(shipment.DropOffs))
+		for _, dropOff := range shipment.DropOffs {
+			dropOffs = append(dropOffs, strconv.FormatUint(uint64(dropOff), 10))
+		}
+
+		table.Rows = append(table.Rows, []string{
+			strconv.FormatUint(uint64(shipment.ID), 10),
+			strconv.FormatUint(uint64(shipment.UnitID), 10),
+			strconv.FormatUint(uint64(shipment.Pickup), 10),
+			strings.Join(dropOffs, " "),
+			shipment.Status,
+		})
+	}
+
+	return table
+}
+
+// ordersTable with order totals of every priority and their sum
+func (r *Report) ordersTable() Table {
+	table := Table{
+		Title:   "Orders",
+		Headers: []string{"Priority", "Created", "Delivered", "Late", "Stranded", "Avg lead time"},
+	}
+
+	total := OrderTotals{Priority: "Total"}
+	var totalLeadTime time.Duration
+	for _, orders := range r.Orders {
+		total.Created += orders.Created
+		total.Delivered += orders.Delivered
+		total.Late += orders.Late
+		total.Stranded += orders.Stranded
+		totalLeadTime += orders.LeadTime * time.Duration(orders.Delivered)
+
+		table.Rows = append(table.Rows, orderRow(orders))
+	}
+	if total.Delivered > 0 {
+		total.LeadTime = totalLeadTime / time.Duration(total.Delivered)
+	}
+	table.Rows = append(table.Rows, orderRow(total))
+
+	return table
+}
+
+func orderRow(orders OrderTotals) []string {
+	return []string{
+		orders.Priority,
+		strconv.FormatUint(orders.Created, 10),
+		strconv.FormatUint(orders.Delivered, 10),
+		strconv.FormatUint(orders.Late, 10),
+		strconv.FormatUint(orders.Stranded, 10),
+		orders.LeadTime.String(),
+	}
+}
+
+// routingTable comparing the distance of planned routes with the greedy baseline
+func (r *Report) routingTable() Table {
+	saved := "-"
+	if r.Routing.GreedyDistance > 0 {
+		saved = strconv.FormatFloat(100*float64(r.Routing.GreedyDistance-r.Routing.Distance)/float64(r.Routing.GreedyDistance), 'f', 1, 64) + "%"
+	}
+
+	return Table{
+		Title:   "Routing",
+		Headers: []string{"Plans", "Orders", "Distance", "Greedy distance", "Saved", "Planned late"},
+		Rows: [][]string{{
+			strconv.Itoa(r.Routing.Plans),
+			strconv.Itoa(r.Routing.Orders),
+			strconv.Itoa(r.Routing.Distance),
+			strconv.Itoa(r.Routing.GreedyDistance),
+			saved,
+			strconv.Itoa(r.Routing.Late),
+		}},
+	}
+}
@@ -0,0 +1,94 @@
+package transport
+
+import (
+	"context"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/grpc_client"
+)
+
+// GRPC calls API over a connected gRPC client, moves can be streamed
+type GRPC struct {
+	client *grpc_client.APILogisticsClient
+}
+
+// NewGRPC transport over the client, it is disconnected when the transport is closed
+func NewGRPC(client *grpc_client.APILogisticsClient) *GRPC {
+	return &GRPC{client: client}
+}
+
+func (t *GRPC) MoveUnit(ctx context.Context, req *logistics_v1.MoveUnitRequest) error {
+	return t.client.MoveUnit(ctx, req)
+}
+
+func (t *GRPC) UnitReachedWarehouse(ctx context.Context, req *logistics_v1.UnitReachedWarehouseRequest) error {
+	return t.client.UnitReachedWarehouse(ctx, req)
+}
+
+func (t *GRPC) PickedUp(ctx context.Context, req *logistics_v1.PickedUpRequest) error {
+	return t.client.PickedUp(ctx, req)
+}
+
+func (t *GRPC) Delivered(ctx context.Context, req *logistics_v1.DeliveredRequest) error {
+	return t.client.Delivered(ctx, req)
+}
+
+func (t *GRPC) CreateOrder(ctx context.Context, req *logistics_v1.CreateOrderRequest) error {
+	return t.client.CreateOrder(ctx, req)
+}
+
+func (t *GRPC) MetricsReport(ctx context.Context) (*logistics_v1.MetricsReportResponse, error) {
+	return t.client.MetricsReport(ctx)
+}
+
+func (t *GRPC) OpenMoveUnitsStream(ctx context.Context, batchSize int) (MoveSender, error) {
+	stream, openErr := t.client.OpenMoveUnitsStream(ctx, batchSize)
+	if openErr != nil {
+		return nil, openErr
+	}
+
+	return stream, nil
+}
+
+func (t *GRPC) Close() error {
+	return t.client.Disconnect()
+}
+
+// HTTP calls the HTTP/JSON gateway of API over a client created with grpc_client.WithHTTPTransport.
+// The gateway has no route for StreamMoveUnits, so moves are only sent one by one.
+type HTTP struct {
+	client *grpc_client.APILogisticsClient
+}
+
+// NewHTTP transport over the client, it is disconnected when the transport is closed
+func NewHTTP(client *grpc_client.APILogisticsClient) *HTTP {
+	return &HTTP{client: client}
+}
+
+func (t *HTTP) MoveUnit(ctx context.Context, req *logistics_v1.MoveUnitRequest) error {
+	return t.client.MoveUnit(ctx, req)
+}
+
+func (t *HTTP) UnitReachedWarehouse(ctx context.Context, req *logistics_v1.UnitReachedWarehouseRequest) error {
+	return t.client.UnitReachedWarehouse(ctx, req)
+}
+
+func (t *HTTP) PickedUp(ctx context.Context, req *logistics_v1.PickedUpRequest) error {
+	return t.client.PickedUp(ctx, req)
+}
+
+func (t *HTTP) Delivered(ctx context.Context, req *logistics_v1.DeliveredRequest) error {
+	return t.client.Delivered(ctx, req)
+}
+
+func (t *HTTP) CreateOrder(ctx context.Context, req *logistics_v1.CreateOrderRequest) error {
+	return t.client.CreateOrder(ctx, req)
+}
+
+func (t *HTTP) MetricsReport(ctx context.Context) (*logistics_v1.MetricsReportResponse, error) {
+	return t.client.MetricsReport(ctx)
+}
+
+func (t *HTTP) Close() error {
+	return t.client.Disconnect()
+}
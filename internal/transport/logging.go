@@ -0,0 +1,99 @@
+package transport
+
+import (
+	"context"
+	"log"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Logging logs every request as protobuf JSON before it is sent over the next transport
+type Logging struct {
+	next   LogisticsTransport
+	logger *log.Logger
+}
+
+// NewLogging transport sending over next and logging to logger
+func NewLogging(next LogisticsTransport, logger *log.Logger) *Logging {
+	return &Logging{next: next, logger: logger}
+}
+
+func (t *Logging) MoveUnit(ctx context.Context, req *logistics_v1.MoveUnitRequest) error {
+	t.log(logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName, req)
+
+	return t.next.MoveUnit(ctx, req)
+}
+
+func (t *Logging) UnitReachedWarehouse(ctx context.Context, req *logistics_v1.UnitReachedWarehouseRequest) error {
+	t.log(logistics_v1.LogisticsEngineAPI_UnitReachedWarehouse_FullMethodName, req)
+
+	return t.next.UnitReachedWarehouse(ctx, req)
+}
+
+func (t *Logging) PickedUp(ctx context.Context, req *logistics_v1.PickedUpRequest) error {
+	t.log(logistics_v1.LogisticsEngineAPI_PickedUp_FullMethodName, req)
+
+	return t.next.PickedUp(ctx, req)
+}
+
+func (t *Logging) Delivered(ctx context.Context, req *logistics_v1.DeliveredRequest) error {
+	t.log(logistics_v1.LogisticsEngineAPI_Delivered_FullMethodName, req)
+
+	return t.next.Delivered(ctx, req)
+}
+
+func (t *Logging) CreateOrder(ctx context.Context, req *logistics_v1.CreateOrderRequest) error {
+	t.log(logistics_v1.LogisticsEngineAPI_CreateOrder_FullMethodName, req)
+
+	return t.next.CreateOrder(ctx, req)
+}
+
+func (t *Logging) MetricsReport(ctx context.Context) (*logistics_v1.MetricsReportResponse, error) {
+	t.log(logistics_v1.LogisticsEngineAPI_MetricsReport_FullMethodName, &logistics_v1.DefaultRequest{})
+
+	return t.next.MetricsReport(ctx)
+}
+
+// OpenMoveUnitsStream of the next transport, it fails with Unimplemented when the next one can't stream
+func (t *Logging) OpenMoveUnitsStream(ctx context.Context, batchSize int) (MoveSender, error) {
+	streamer, ok := t.next.(MoveStreamer)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "transport can't stream moves")
+	}
+
+	sender, openErr := streamer.OpenMoveUnitsStream(ctx, batchSize)
+	if openErr != nil {
+		return nil, openErr
+	}
+
+	return &loggingSender{MoveSender: sender, transport: t}, nil
+}
+
+func (t *Logging) Close() error {
+	return t.next.Close()
+}
+
+func (t *Logging) log(method string, msg proto.Message) {
+	request, marshalErr := protojson.Marshal(msg)
+	if marshalErr != nil {
+		t.logger.Printf("%s, failed to encode request: %v\n", method, marshalErr)
+		return
+	}
+
+	t.logger.Printf("%s %s\n", method, request)
+}
+
+type loggingSender struct {
+	MoveSender
+	transport *Logging
+}
+
+func (s *loggingSender) Send(moves []*logistics_v1.MoveUnitRequest) (map[int64]error, error) {
+	s.transport.log(logistics_v1.LogisticsEngineAPI_StreamMoveUnits_FullMethodName, &logistics_v1.MoveUnitsBatch{Moves: moves})
+
+	return s.MoveSender.Send(moves)
+}
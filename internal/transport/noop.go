@@ -0,0 +1,71 @@
+package transport
+
+import (
+	"context"
+	"sync"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/metricsreport"
+)
+
+// Noop accepts every request without sending it anywhere. MetricsReport is computed from accepted
+// announcements the way API does, so a run over it checks out like a run against a fresh API.
+type Noop struct {
+	mu      sync.Mutex
+	reached []*logistics_v1.UnitReachedWarehouseRequest
+}
+
+// NewNoop transport with nothing accepted yet
+func NewNoop() *Noop {
+	return &Noop{}
+}
+
+func (t *Noop) MoveUnit(context.Context, *logistics_v1.MoveUnitRequest) error {
+	return nil
+}
+
+func (t *Noop) UnitReachedWarehouse(_ context.Context, req *logistics_v1.UnitReachedWarehouseRequest) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.reached = append(t.reached, req)
+
+	return nil
+}
+
+func (t *Noop) PickedUp(context.Context, *logistics_v1.PickedUpRequest) error {
+	return nil
+}
+
+func (t *Noop) Delivered(context.Context, *logistics_v1.DeliveredRequest) error {
+	return nil
+}
+
+func (t *Noop) CreateOrder(context.Context, *logistics_v1.CreateOrderRequest) error {
+	return nil
+}
+
+func (t *Noop) MetricsReport(context.Context) (*logistics_v1.MetricsReportResponse, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return metricsreport.Compute(t.reached), nil
+}
+
+func (t *Noop) OpenMoveUnitsStream(context.Context, int) (MoveSender, error) {
+	return noopSender{}, nil
+}
+
+func (t *Noop) Close() error {
+	return nil
+}
+
+type noopSender struct{}
+
+func (noopSender) Send([]*logistics_v1.MoveUnitRequest) (map[int64]error, error) {
+	return nil, nil
+}
+
+func (noopSender) Close() error {
+	return nil
+}
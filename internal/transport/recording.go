@@ -0,0 +1,87 @@
+package transport
+
+import (
+	"context"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/recorder"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Recording records every request before it is sent over the next transport. A call is recorded once
+// however many attempts it took, and a batch of streamed moves is recorded as one StreamMoveUnits entry.
+type Recording struct {
+	next     LogisticsTransport
+	recorder *recorder.Recorder
+}
+
+// NewRecording transport sending over next, the recorder is closed by its owner
+func NewRecording(next LogisticsTransport, r *recorder.Recorder) *Recording {
+	return &Recording{next: next, recorder: r}
+}
+
+func (t *Recording) MoveUnit(ctx context.Context, req *logistics_v1.MoveUnitRequest) error {
+	t.recorder.Record(logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName, req)
+
+	return t.next.MoveUnit(ctx, req)
+}
+
+func (t *Recording) UnitReachedWarehouse(ctx context.Context, req *logistics_v1.UnitReachedWarehouseRequest) error {
+	t.recorder.Record(logistics_v1.LogisticsEngineAPI_UnitReachedWarehouse_FullMethodName, req)
+
+	return t.next.UnitReachedWarehouse(ctx, req)
+}
+
+func (t *Recording) PickedUp(ctx context.Context, req *logistics_v1.PickedUpRequest) error {
+	t.recorder.Record(logistics_v1.LogisticsEngineAPI_PickedUp_FullMethodName, req)
+
+	return t.next.PickedUp(ctx, req)
+}
+
+func (t *Recording) Delivered(ctx context.Context, req *logistics_v1.DeliveredRequest) error {
+	t.recorder.Record(logistics_v1.LogisticsEngineAPI_Delivered_FullMethodName, req)
+
+	return t.next.Delivered(ctx, req)
+}
+
+func (t *Recording) CreateOrder(ctx context.Context, req *logistics_v1.CreateOrderRequest) error {
+	t.recorder.Record(logistics_v1.LogisticsEngineAPI_CreateOrder_FullMethodName, req)
+
+	return t.next.CreateOrder(ctx, req)
+}
+
+func (t *Recording) MetricsReport(ctx context.Context) (*logistics_v1.MetricsReportResponse, error) {
+	return t.next.MetricsReport(ctx)
+}
+
+// OpenMoveUnitsStream of the next transport, it fails with Unimplemented when the next one can't stream
+func (t *Recording) OpenMoveUnitsStream(ctx context.Context, batchSize int) (MoveSender, error) {
+	streamer, ok := t.next.(MoveStreamer)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "transport can't stream moves")
+	}
+
+	sender, openErr := streamer.OpenMoveUnitsStream(ctx, batchSize)
+	if openErr != nil {
+		return nil, openErr
+	}
+
+	return &recordingSender{MoveSender: sender, recorder: t.recorder}, nil
+}
+
+// Close the next transport
+func (t *Recording) Close() error {
+	return t.next.Close()
+}
+
+type recordingSender struct {
+	MoveSender
+	recorder *recorder.Recorder
+}
+
+func (s *recordingSender) Send(moves []*logistics_v1.MoveUnitRequest) (map[int64]error, error) {
+	s.recorder.Record(logistics_v1.LogisticsEngineAPI_StreamMoveUnits_FullMethodName, &logistics_v1.MoveUnitsBatch{Moves: moves})
+
+	return s.MoveSender.Send(moves)
+}
@@ -0,0 +1,32 @@
+package transport
+
+import (
+	"context"
+
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+)
+
+// LogisticsTransport sends requests of a run to API, App depends only on it, so it can run over gRPC,
+// the HTTP gateway or without API at all
+type LogisticsTransport interface {
+	MoveUnit(ctx context.Context, req *logistics_v1.MoveUnitRequest) error
+	UnitReachedWarehouse(ctx context.Context, req *logistics_v1.UnitReachedWarehouseRequest) error
+	PickedUp(ctx context.Context, req *logistics_v1.PickedUpRequest) error
+	Delivered(ctx context.Context, req *logistics_v1.DeliveredRequest) error
+	CreateOrder(ctx context.Context, req *logistics_v1.CreateOrderRequest) error
+	MetricsReport(ctx context.Context) (*logistics_v1.MetricsReportResponse, error)
+	// Close releases the connection, in-flight calls fail
+	Close() error
+}
+
+// MoveStreamer is implemented by transports that can send moves in batches over StreamMoveUnits
+type MoveStreamer interface {
+	OpenMoveUnitsStream(ctx context.Context, batchSize int) (MoveSender, error)
+}
+
+// MoveSender sends moves over an open stream, see grpc_client.MoveUnitsStream
+type MoveSender interface {
+	// Send moves and wait until every one is acknowledged, unitErrs holds moves API rejected by unit ID
+	Send(moves []*logistics_v1.MoveUnitRequest) (unitErrs map[int64]error, streamErr error)
+	Close() error
+}
@@ -0,0 +1,248 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/fakeserver"
+	logistics_v1 "github.com/ivanbulyk/clients_logistics_engine_api/internal/generated/logistics/api/v1"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/grpc_client"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/metricsreport"
+	"github.com/ivanbulyk/clients_logistics_engine_api/internal/recorder"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Every implementation is a transport, the streaming ones are move streamers
+var (
+	_ MoveStreamer       = (*GRPC)(nil)
+	_ MoveStreamer       = (*Recording)(nil)
+	_ MoveStreamer       = (*Noop)(nil)
+	_ LogisticsTransport = (*HTTP)(nil)
+)
+
+func reachedRequest(unitID, warehouseID int64) *logistics_v1.UnitReachedWarehouseRequest {
+	return &logistics_v1.UnitReachedWarehouseRequest{
+		Announcement: &logistics_v1.WarehouseAnnouncement{CargoUnitId: unitID, WarehouseId: warehouseID},
+	}
+}
+
+func TestNoopReportsAcceptedAnnouncements(t *testing.T) {
+	noop := NewNoop()
+	ctx := context.Background()
+
+	if moveErr := noop.MoveUnit(ctx, &logistics_v1.MoveUnitRequest{CargoUnitId: 1}); moveErr != nil {
+		t.Fatalf("Not expected error when moving unit, error: %v", moveErr)
+	}
+	reached := []*logistics_v1.UnitReachedWarehouseRequest{reachedRequest(1, 3), reachedRequest(2, 3), reachedRequest(4, 1)}
+	for _, req := range reached {
+		if reachedErr := noop.UnitReachedWarehouse(ctx, req); reachedErr != nil {
+			t.Fatalf("Not expected error when announcing unit, error: %v", reachedErr)
+		}
+	}
+
+	report, reportErr := noop.MetricsReport(ctx)
+	if reportErr != nil {
+		t.Fatalf("Not expected error when requesting report, error: %v", reportErr)
+	}
+	if expected := metricsreport.Compute(reached); !proto.Equal(report, expected) {
+		t.Errorf("Expected report %v, but got %v", expected, report)
+	}
+}
+
+// connectRetrying to srv with the default retry policy without backoff
+func connectRetrying(t *testing.T, srv *fakeserver.Server) *grpc_client.APILogisticsClient {
+	t.Helper()
+
+	dialOption, stop := srv.Listen()
+	t.Cleanup(stop)
+
+	retries := grpc_client.DefaultRetryPolicy()
+	retries.InitialBackoff = 0
+	lc := grpc_client.NewLogisticsClient(grpc_client.WithDialOptions(dialOption), grpc_client.WithRetryPolicy(retries))
+	if connErr := lc.Connect("bufnet", context.Background()); connErr != nil {
+		t.Fatalf("Not expected error when connecting, error: %v", connErr)
+	}
+	t.Cleanup(func() { _ = lc.Disconnect() })
+
+	return lc
+}
+
+// failSecondMoveOnce makes the first attempt of MoveUnit with sequence 2 fail with Unavailable
+func failSecondMoveOnce(srv *fakeserver.Server) {
+	failed := false
+	srv.SetErrorInjector(func(_ string, req proto.Message) (bool, error) {
+		if move, ok := req.(*logistics_v1.MoveUnitRequest); ok && move.GetSequence() == 2 && !failed {
+			failed = true
+			return false, status.Error(codes.Unavailable, "overloaded")
+		}
+		return false, nil
+	})
+}
+
+func TestRecordingRecordsEveryRequestOnce(t *testing.T) {
+	srv := fakeserver.New()
+	failed := false
+	srv.SetErrorInjector(func(method string, _ proto.Message) (bool, error) {
+		if method == logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName && !failed {
+			failed = true
+			return false, status.Error(codes.Unavailable, "overloaded")
+		}
+		return false, nil
+	})
+	var recording bytes.Buffer
+	requestRecorder := recorder.New(&recording)
+	recordingTransport := NewRecording(NewGRPC(connectRetrying(t, srv)), requestRecorder)
+	ctx := context.Background()
+
+	if moveErr := recordingTransport.MoveUnit(ctx, &logistics_v1.MoveUnitRequest{CargoUnitId: 1, Sequence: 1}); moveErr != nil {
+		t.Fatalf("Not expected error when moving unit, error: %v", moveErr)
+	}
+	if reachedErr := recordingTransport.UnitReachedWarehouse(ctx, reachedRequest(1, 2)); reachedErr != nil {
+		t.Fatalf("Not expected error when announcing unit, error: %v", reachedErr)
+	}
+	stream, openErr := recordingTransport.OpenMoveUnitsStream(ctx, 1)
+	if openErr != nil {
+		t.Fatalf("Not expected error when opening stream, error: %v", openErr)
+	}
+	if _, sendErr := stream.Send([]*logistics_v1.MoveUnitRequest{{CargoUnitId: 2}, {CargoUnitId: 3}}); sendErr != nil {
+		t.Fatalf("Not expected error when sending moves, error: %v", sendErr)
+	}
+	if closeErr := stream.Close(); closeErr != nil {
+		t.Fatalf("Not expected error when closing stream, error: %v", closeErr)
+	}
+	if closeErr := requestRecorder.Close(); closeErr != nil {
+		t.Fatalf("Not expected error when closing recorder, error: %v", closeErr)
+	}
+
+	entries, readErr := recorder.Read(&recording)
+	if readErr != nil {
+		t.Fatalf("Not expected error when reading recording, error: %v", readErr)
+	}
+	expectedMethods := []string{
+		logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName,
+		logistics_v1.LogisticsEngineAPI_UnitReachedWarehouse_FullMethodName,
+		logistics_v1.LogisticsEngineAPI_StreamMoveUnits_FullMethodName,
+	}
+	if len(entries) != len(expectedMethods) {
+		t.Fatalf("Expected %d recorded requests, but got %d", len(expectedMethods), len(entries))
+	}
+	for i, entry := range entries {
+		if entry.Method != expectedMethods[i] {
+			t.Errorf("Expected %s recorded at %d, but got %s", expectedMethods[i], i, entry.Method)
+		}
+	}
+
+	batch, messageErr := entries[2].Message()
+	if messageErr != nil {
+		t.Fatalf("Not expected error when decoding batch, error: %v", messageErr)
+	}
+	if moves := batch.(*logistics_v1.MoveUnitsBatch).GetMoves(); len(moves) != 2 {
+		t.Errorf("Expected both streamed moves in one entry, but got %d", len(moves))
+	}
+	if len(srv.Moves()) != 3 {
+		t.Errorf("Expected 3 moves sent to server, but got %d", len(srv.Moves()))
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	recorded := fakeserver.New()
+	failSecondMoveOnce(recorded)
+
+	var recording bytes.Buffer
+	requestRecorder := recorder.New(&recording)
+	recordingTransport := NewRecording(NewGRPC(connectRetrying(t, recorded)), requestRecorder)
+	ctx := context.Background()
+
+	for sequence := uint64(1); sequence <= 2; sequence++ {
+		if moveErr := recordingTransport.MoveUnit(ctx, &logistics_v1.MoveUnitRequest{CargoUnitId: 1, Sequence: sequence}); moveErr != nil {
+			t.Fatalf("Not expected error when moving unit, error: %v", moveErr)
+		}
+	}
+	stream, openErr := recordingTransport.OpenMoveUnitsStream(ctx, 2)
+	if openErr != nil {
+		t.Fatalf("Not expected error when opening stream, error: %v", openErr)
+	}
+	if _, sendErr := stream.Send([]*logistics_v1.MoveUnitRequest{{CargoUnitId: 2, Sequence: 1}, {CargoUnitId: 3, Sequence: 1}}); sendErr != nil {
+		t.Fatalf("Not expected error when sending moves, error: %v", sendErr)
+	}
+	_ = stream.Close()
+	if reachedErr := recordingTransport.UnitReachedWarehouse(ctx, reachedRequest(1, 9)); reachedErr != nil {
+		t.Fatalf("Not expected error when announcing unit, error: %v", reachedErr)
+	}
+	if closeErr := requestRecorder.Close(); closeErr != nil {
+		t.Fatalf("Not expected error when closing recorder, error: %v", closeErr)
+	}
+
+	entries, readErr := recorder.Read(&recording)
+	if readErr != nil {
+		t.Fatalf("Not expected error when reading recording, error: %v", readErr)
+	}
+	// 2 MoveUnit calls, 1 batch and 1 announcement, the failed attempt is not recorded
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 recorded entries, but got %d", len(entries))
+	}
+
+	// Replay retries the same failure the run did, so nothing is lost or sent twice
+	replayed := fakeserver.New()
+	failSecondMoveOnce(replayed)
+	result, replayErr := recorder.Replay(ctx, connectRetrying(t, replayed), entries, recorder.PacingMax)
+	if replayErr != nil {
+		t.Fatalf("Not expected error when replaying, error: %v", replayErr)
+	}
+	if result.Sent != 4 || result.Failed != 0 {
+		t.Errorf("Expected 4 sent and 0 failed requests, but got %+v", result)
+	}
+
+	if len(replayed.Moves()) != len(recorded.Moves()) {
+		t.Fatalf("Expected %d replayed moves, but got %d", len(recorded.Moves()), len(replayed.Moves()))
+	}
+	for i, replayedMove := range replayed.Moves() {
+		if !proto.Equal(replayedMove, recorded.Moves()[i]) {
+			t.Errorf("Replayed move %d is %v, expected %v", i, replayedMove, recorded.Moves()[i])
+		}
+	}
+	if len(replayed.Reached()) != 1 || !proto.Equal(replayed.Reached()[0], recorded.Reached()[0]) {
+		t.Errorf("Expected replayed announcement %v, but got %v", recorded.Reached(), replayed.Reached())
+	}
+}
+
+func TestRecordingCantStreamOverHTTP(t *testing.T) {
+	recordingTransport := NewRecording(NewHTTP(grpc_client.NewLogisticsClient()), recorder.New(&bytes.Buffer{}))
+
+	if _, openErr := recordingTransport.OpenMoveUnitsStream(context.Background(), 1); status.Code(openErr) != codes.Unimplemented {
+		t.Errorf("Expected %s when opening stream, but got %v", codes.Unimplemented, openErr)
+	}
+}
+
+func TestLoggingLogsEveryRequest(t *testing.T) {
+	var logged bytes.Buffer
+	loggingTransport := NewLogging(NewNoop(), log.New(&logged, "", 0))
+	ctx := context.Background()
+
+	if moveErr := loggingTransport.MoveUnit(ctx, &logistics_v1.MoveUnitRequest{CargoUnitId: 5}); moveErr != nil {
+		t.Fatalf("Not expected error when moving unit, error: %v", moveErr)
+	}
+	stream, openErr := loggingTransport.OpenMoveUnitsStream(ctx, 10)
+	if openErr != nil {
+		t.Fatalf("Not expected error when opening stream, error: %v", openErr)
+	}
+	if _, sendErr := stream.Send([]*logistics_v1.MoveUnitRequest{{CargoUnitId: 6}}); sendErr != nil {
+		t.Fatalf("Not expected error when sending moves, error: %v", sendErr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(logged.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 logged requests, but got %q", lines)
+	}
+	if !strings.HasPrefix(lines[0], logistics_v1.LogisticsEngineAPI_MoveUnit_FullMethodName) || !strings.Contains(lines[0], `"cargoUnitId":"5"`) {
+		t.Errorf("Expected logged MoveUnit of unit 5, but got %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], logistics_v1.LogisticsEngineAPI_StreamMoveUnits_FullMethodName) || !strings.Contains(lines[1], `"cargoUnitId":"6"`) {
+		t.Errorf("Expected logged batch with unit 6, but got %q", lines[1])
+	}
+}